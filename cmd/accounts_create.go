@@ -28,9 +28,14 @@ import (
 
 // accountsCreateCmd represents the "accounts create" command
 var accountsCreateCmd = &cobra.Command{
-	Use:               "create <stack_name>",
-	Short:             "Create a new account in the FireFly stack",
-	Long:              `Create a new account in the FireFly stack`,
+	Use:   "create <stack_name> [org_name] [account_name] [member_index]",
+	Short: "Create a new account in the FireFly stack",
+	Long: `Create a new account in the FireFly stack
+
+On quorum stacks, and geth and besu stacks with --node-per-member, each member has their own blockchain
+node, and the account is created for the member at member_index: on their geth or quorum node, or in the
+signer in front of their besu node. Quorum stacks require it, and geth and besu stacks use the first
+member's node if it is not given.`,
 	Args:              cobra.MinimumNArgs(1),
 	ValidArgsFunction: listStacks,
 	PreRunE: func(cmd *cobra.Command, args []string) error {
@@ -50,7 +55,7 @@ var accountsCreateCmd = &cobra.Command{
 		}
 		account, err := stackManager.CreateAccount(args[1:])
 		if err != nil {
-			return fmt.Errorf("%s. usage: %s accounts create <stack_name> <org_name> <account_name>", err.Error(), ExecutableName)
+			return fmt.Errorf("%s. usage: %s accounts create <stack_name> <org_name> <account_name> [member_index]", err.Error(), ExecutableName)
 		}
 		fmt.Print(account)
 		fmt.Print("\n")
@@ -0,0 +1,76 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-cli/internal/log"
+	"github.com/hyperledger/firefly-cli/internal/stacks"
+	"github.com/hyperledger/firefly-cli/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var apiOptions types.APIOptions
+
+// apiCmd represents the api command
+var apiCmd = &cobra.Command{
+	Use:               "api <stack_name> <method> <path>",
+	Short:             "Send a request to the FireFly API of a member of a stack",
+	ValidArgsFunction: listStacks,
+	Long: `Send a request to the FireFly API of a member of a stack, the first member by default,
+and print the response.
+
+The request goes to the port of the member's API, with the credentials of the member
+if the stack uses basic auth, or to the port of its SPI with --spi. A path that does
+not start with / is relative to /api/v1, or /spi/v1 for the SPI. The body of the
+request is given with --data, either as JSON, or as @ followed by the name of a file
+containing JSON, or as @- to read it from stdin.
+
+The subcommands build the requests for common tasks.`,
+	Example: `  ff api dev GET status
+  ff api dev --member 1 GET namespaces/default/messages?limit=5
+  ff api dev POST namespaces/default/messages/broadcast --data @message.json
+  ff api dev --spi GET namespaces`,
+	Args: cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stackManager, err := loadAPIStack(args[0])
+		if err != nil {
+			return err
+		}
+		return stackManager.CallAPI(&apiOptions, args[1], args[2])
+	},
+}
+
+// loadAPIStack loads a stack to send requests to its API. No docker commands are needed for this.
+func loadAPIStack(stackName string) (*stacks.StackManager, error) {
+	ctx := log.WithVerbosity(context.Background(), verbose)
+	ctx = log.WithLogger(ctx, logger)
+	stackManager := stacks.NewStackManager(ctx)
+	if err := stackManager.LoadStack(stackName); err != nil {
+		return nil, err
+	}
+	return stackManager, nil
+}
+
+func init() {
+	rootCmd.AddCommand(apiCmd)
+	apiCmd.PersistentFlags().IntVar(&apiOptions.Member, "member", 0, "Index of the member to send the request to")
+	apiCmd.PersistentFlags().StringVarP(&apiOptions.Namespace, "namespace", "n", "default", "Namespace of the requests made by the subcommands")
+	apiCmd.PersistentFlags().StringVar(&apiOptions.Data, "data", "", "Body of the request as JSON, @<file> to read it from a file, or @- to read it from stdin")
+	apiCmd.Flags().BoolVar(&apiOptions.SPI, "spi", false, "Send the request to the SPI of the member instead of its API")
+}
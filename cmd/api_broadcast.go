@@ -0,0 +1,58 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// apiBroadcastCmd represents the "api broadcast" command
+var apiBroadcastCmd = &cobra.Command{
+	Use:               "broadcast <stack_name> [value]",
+	Short:             "Broadcast a message to every member of the network",
+	ValidArgsFunction: listStacks,
+	Long: `Broadcast a message with a single value to every member of the network, and wait
+for it to be confirmed unless --confirm=false is given. A value that is valid JSON
+is sent as JSON, otherwise it is sent as a string. The value can also be given with
+--data.`,
+	Example: `  ff api broadcast dev "hello world" --tag greeting --topic chat
+  ff api broadcast dev --member 1 --data @order.json`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stackManager, err := loadAPIStack(args[0])
+		if err != nil {
+			return err
+		}
+		value := ""
+		if len(args) > 1 {
+			value = args[1]
+		}
+		return stackManager.Broadcast(&apiOptions, value)
+	},
+}
+
+// addAPIMessageFlags adds the flags for the header of a message to a command that sends one
+func addAPIMessageFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&apiOptions.Tag, "tag", "", "Tag of the message")
+	cmd.Flags().StringArrayVar(&apiOptions.Topics, "topic", nil, "Topic of the message. Can be repeated")
+	cmd.Flags().BoolVar(&apiOptions.Confirm, "confirm", true, "Wait for the message to be confirmed")
+}
+
+func init() {
+	apiCmd.AddCommand(apiBroadcastCmd)
+	addAPIMessageFlags(apiBroadcastCmd)
+}
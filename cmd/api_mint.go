@@ -0,0 +1,49 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// apiMintCmd represents the "api mint" command
+var apiMintCmd = &cobra.Command{
+	Use:               "mint <stack_name>",
+	Short:             "Mint tokens in a token pool",
+	ValidArgsFunction: listStacks,
+	Long: `Mint tokens in a token pool, and wait for the transfer to be confirmed unless
+--confirm=false is given. The pool can be left out if the namespace only has one pool.
+The tokens go to the signing key of the member unless another key is given with --to.`,
+	Example: `  ff api mint dev --amount 100
+  ff api mint dev --member 1 --pool coins --amount 5 --to 0x1234...`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stackManager, err := loadAPIStack(args[0])
+		if err != nil {
+			return err
+		}
+		return stackManager.Mint(&apiOptions)
+	},
+}
+
+func init() {
+	apiCmd.AddCommand(apiMintCmd)
+	apiMintCmd.Flags().StringVar(&apiOptions.Pool, "pool", "", "Name or ID of the token pool")
+	apiMintCmd.Flags().StringVar(&apiOptions.Amount, "amount", "1", "Amount of tokens to mint")
+	apiMintCmd.Flags().StringVar(&apiOptions.To, "to", "", "Key to mint the tokens to")
+	apiMintCmd.Flags().BoolVar(&apiOptions.Confirm, "confirm", true, "Wait for the transfer to be confirmed")
+}
@@ -0,0 +1,52 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// apiSendPrivateCmd represents the "api send-private" command
+var apiSendPrivateCmd = &cobra.Command{
+	Use:               "send-private <stack_name> [value]",
+	Short:             "Send a private message to members of the network",
+	ValidArgsFunction: listStacks,
+	Long: `Send a private message with a single value to the organizations given with --to,
+or to every member of the stack by default, and wait for it to be confirmed unless
+--confirm=false is given. A value that is valid JSON is sent as JSON, otherwise it
+is sent as a string. The value can also be given with --data.`,
+	Example: `  ff api send-private dev "hello org 1" --to org_0 --to org_1
+  ff api send-private dev --member 1 --data @order.json --tag orders`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stackManager, err := loadAPIStack(args[0])
+		if err != nil {
+			return err
+		}
+		value := ""
+		if len(args) > 1 {
+			value = args[1]
+		}
+		return stackManager.SendPrivate(&apiOptions, value)
+	},
+}
+
+func init() {
+	apiCmd.AddCommand(apiSendPrivateCmd)
+	addAPIMessageFlags(apiSendPrivateCmd)
+	apiSendPrivateCmd.Flags().StringArrayVar(&apiOptions.Recipients, "to", nil, "Name or DID of an organization to send the message to. Can be repeated")
+}
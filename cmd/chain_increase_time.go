@@ -0,0 +1,74 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hyperledger/firefly-cli/internal/log"
+	"github.com/hyperledger/firefly-cli/internal/stacks"
+	"github.com/spf13/cobra"
+)
+
+var chainIncreaseTimeCmd = &cobra.Command{
+	Use:   "increase-time <stack_name> <duration>",
+	Short: "Move the time of the blockchain of a stack forward",
+	Long: `Move the time of the blockchain of a stack forward, and mine a block with the new time.
+The duration is a number of seconds, or a duration such as 90m or 24h.`,
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: listStacks,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := log.WithVerbosity(context.Background(), verbose)
+		ctx = log.WithLogger(ctx, logger)
+
+		duration, err := parseChainDuration(args[1])
+		if err != nil {
+			return err
+		}
+
+		// A failure to reach the node is not a problem with how the command was used
+		cmd.SilenceUsage = true
+		stackManager := stacks.NewStackManager(ctx)
+		if err := stackManager.LoadStack(args[0]); err != nil {
+			return err
+		}
+		offset, err := stackManager.IncreaseTime(duration)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("time moved forward by %s, which is %s in total\n", duration, offset)
+		return nil
+	},
+}
+
+func parseChainDuration(input string) (time.Duration, error) {
+	if seconds, err := strconv.ParseInt(input, 10, 64); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second, nil
+	}
+	duration, err := time.ParseDuration(input)
+	if err != nil || duration < time.Second {
+		return 0, fmt.Errorf("invalid duration '%s': must be a number of seconds, or a duration of at least 1s such as 90m", input)
+	}
+	return duration, nil
+}
+
+func init() {
+	chainCmd.AddCommand(chainIncreaseTimeCmd)
+}
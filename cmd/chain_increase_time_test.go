@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseChainDuration(t *testing.T) {
+	duration, err := parseChainDuration("3600")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour, duration)
+
+	duration, err = parseChainDuration("90m")
+	assert.NoError(t, err)
+	assert.Equal(t, 90*time.Minute, duration)
+
+	_, err = parseChainDuration("0")
+	assert.Regexp(t, "invalid duration", err)
+	_, err = parseChainDuration("10ms")
+	assert.Regexp(t, "invalid duration", err)
+	_, err = parseChainDuration("tomorrow")
+	assert.Regexp(t, "invalid duration", err)
+}
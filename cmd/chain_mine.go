@@ -0,0 +1,63 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hyperledger/firefly-cli/internal/log"
+	"github.com/hyperledger/firefly-cli/internal/stacks"
+	"github.com/spf13/cobra"
+)
+
+var chainMineCmd = &cobra.Command{
+	Use:               "mine <stack_name> [blocks]",
+	Short:             "Mine blocks on the blockchain of a stack straight away",
+	Long:              `Mine blocks on the blockchain of a stack straight away. Mines one block if the number of blocks is not set.`,
+	Args:              cobra.RangeArgs(1, 2),
+	ValidArgsFunction: listStacks,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := log.WithVerbosity(context.Background(), verbose)
+		ctx = log.WithLogger(ctx, logger)
+
+		blocks := 1
+		if len(args) > 1 {
+			var err error
+			if blocks, err = strconv.Atoi(args[1]); err != nil || blocks < 1 {
+				return fmt.Errorf("invalid number of blocks '%s'", args[1])
+			}
+		}
+
+		// A failure to reach the node is not a problem with how the command was used
+		cmd.SilenceUsage = true
+		stackManager := stacks.NewStackManager(ctx)
+		if err := stackManager.LoadStack(args[0]); err != nil {
+			return err
+		}
+		if err := stackManager.MineBlocks(blocks); err != nil {
+			return err
+		}
+		fmt.Printf("mined %d block(s)\n", blocks)
+		return nil
+	},
+}
+
+func init() {
+	chainCmd.AddCommand(chainMineCmd)
+}
@@ -0,0 +1,67 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"regexp"
+
+	"github.com/hyperledger/firefly-cli/internal/log"
+	"github.com/hyperledger/firefly-cli/internal/stacks"
+	"github.com/spf13/cobra"
+)
+
+var ethereumAddressRegex = regexp.MustCompile("^0x[0-9a-fA-F]{40}$")
+
+var chainSetBalanceCmd = &cobra.Command{
+	Use:               "set-balance <stack_name> <address> <wei>",
+	Short:             "Set the balance of an account on the blockchain of a stack",
+	Long:              `Set the balance of an account on the blockchain of a stack, in wei`,
+	Args:              cobra.ExactArgs(3),
+	ValidArgsFunction: listStacks,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := log.WithVerbosity(context.Background(), verbose)
+		ctx = log.WithLogger(ctx, logger)
+
+		address := args[1]
+		if !ethereumAddressRegex.MatchString(address) {
+			return fmt.Errorf("invalid address '%s'", address)
+		}
+		wei, ok := new(big.Int).SetString(args[2], 10)
+		if !ok || wei.Sign() < 0 {
+			return fmt.Errorf("invalid balance '%s': must be a number of wei", args[2])
+		}
+
+		// A failure to reach the node is not a problem with how the command was used
+		cmd.SilenceUsage = true
+		stackManager := stacks.NewStackManager(ctx)
+		if err := stackManager.LoadStack(args[0]); err != nil {
+			return err
+		}
+		if err := stackManager.SetBalance(address, wei); err != nil {
+			return err
+		}
+		fmt.Printf("balance of %s set to %s wei\n", address, wei)
+		return nil
+	},
+}
+
+func init() {
+	chainCmd.AddCommand(chainSetBalanceCmd)
+}
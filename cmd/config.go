@@ -0,0 +1,36 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var configMember int
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "View and edit the generated FireFly core config files of the members of a stack",
+	Long: `View and edit the generated FireFly core config files of the members of a stack. Before the stack has been
+started this is the config in the stack's init directory, and afterwards it is the runtime config that the
+FireFly core containers load.`,
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+}
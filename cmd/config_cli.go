@@ -0,0 +1,157 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// configCLICmd represents the config-cli command
+var configCLICmd = &cobra.Command{
+	Use:   "config-cli",
+	Short: "View and edit the defaults of the CLI's flags in ~/.firefly-cli.yaml",
+	Long: `View and edit the defaults of the CLI's flags in ~/.firefly-cli.yaml.
+
+The flags of the root command, such as ansi and verbose, are top level keys. The flags of every other
+command are under the path of the command, such as init.database or pull.retries, and a command also uses
+the defaults under the commands it belongs to, so init.blockchain-connector is used by init ethereum.
+
+Each default can be overridden with an environment variable named after its key, in upper case with an FF_
+prefix and underscores in place of dots and dashes, such as FF_INIT_DATABASE=postgres. Flags that are given
+on the command line override both.`,
+	Example: `  ff config-cli set init.database postgres
+  ff config-cli set init.blockchain-connector evmconnect
+  ff config-cli set init.prometheus-enabled true
+  ff config-cli get init`,
+}
+
+func init() {
+	rootCmd.AddCommand(configCLICmd)
+}
+
+// configCLIFile returns the config file that initConfig read, or the default location in the home directory if
+// there is not one yet
+func configCLIFile() (string, error) {
+	if file := viper.ConfigFileUsed(); file != "" {
+		return file, nil
+	}
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".firefly-cli.yaml"), nil
+}
+
+// commandConfigPath returns the names of the commands from the first command below the root down to the command
+func commandConfigPath(cmd *cobra.Command) []string {
+	var path []string
+	for c := cmd; c.HasParent(); c = c.Parent() {
+		path = append([]string{c.Name()}, path...)
+	}
+	return path
+}
+
+// configDefaultKeys returns the keys in the CLI config that can set the default of a flag of a command, from the
+// most to the least specific. Flags of the root command only have a top level key.
+func configDefaultKeys(cmd *cobra.Command, flag *pflag.Flag) []string {
+	if cmd.Root().PersistentFlags().Lookup(flag.Name) == flag {
+		return []string{flag.Name}
+	}
+	path := commandConfigPath(cmd)
+	keys := make([]string, 0, len(path))
+	for i := len(path); i > 0; i-- {
+		keys = append(keys, strings.Join(append(path[:i:i], flag.Name), "."))
+	}
+	return keys
+}
+
+// applyConfigDefaults sets each flag of a command that was not given on the command line to its default from the
+// CLI config or environment, if there is one. The flags are not marked as changed, so commands still treat them as
+// defaults, such as when deciding whether to prompt.
+func applyConfigDefaults(cmd *cobra.Command) error {
+	var err error
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if err != nil || flag.Changed || flag.Name == "help" {
+			return
+		}
+		for _, key := range configDefaultKeys(cmd, flag) {
+			if !viper.IsSet(key) {
+				continue
+			}
+			for _, value := range configDefaultValues(key) {
+				if setErr := flag.Value.Set(value); setErr != nil {
+					err = fmt.Errorf("invalid value '%s' for '%s' in the CLI config or environment: %s", value, key, setErr)
+					return
+				}
+			}
+			return
+		}
+	})
+	return err
+}
+
+// configDefaultValues returns the value of a key in the CLI config as the strings to pass to the flag, with one
+// string for each entry of a list, and one key=value string for each entry of a map
+func configDefaultValues(key string) []string {
+	switch value := viper.Get(key).(type) {
+	case []interface{}:
+		values := make([]string, len(value))
+		for i, v := range value {
+			values[i] = fmt.Sprint(v)
+		}
+		return values
+	case map[string]interface{}:
+		values := make([]string, 0, len(value))
+		for k, v := range value {
+			values = append(values, fmt.Sprintf("%s=%v", k, v))
+		}
+		sort.Strings(values)
+		return values
+	default:
+		return []string{viper.GetString(key)}
+	}
+}
+
+// validConfigKeys returns every key that sets the default of a flag of some command
+func validConfigKeys() map[string]bool {
+	keys := make(map[string]bool)
+	var visit func(cmd *cobra.Command)
+	visit = func(cmd *cobra.Command) {
+		addKeys := func(flag *pflag.Flag) {
+			if flag.Name != "help" {
+				for _, key := range configDefaultKeys(cmd, flag) {
+					keys[key] = true
+				}
+			}
+		}
+		cmd.LocalFlags().VisitAll(addKeys)
+		cmd.InheritedFlags().VisitAll(addKeys)
+		for _, child := range cmd.Commands() {
+			visit(child)
+		}
+	}
+	visit(rootCmd)
+	return keys
+}
@@ -0,0 +1,46 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// configCLIGetCmd represents the "config-cli get" command
+var configCLIGetCmd = &cobra.Command{
+	Use:   "get [key]",
+	Short: "Print the default of a flag from the CLI config, or every default if no key is given",
+	Long: `Print the default of a flag from the CLI config, such as init.database, or every default if no key
+is given. Environment variables that override the config file are included.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return printConfigValue(viper.AllSettings())
+		}
+		if !viper.IsSet(args[0]) {
+			return fmt.Errorf("'%s' is not set in the CLI config", args[0])
+		}
+		return printConfigValue(viper.Get(args[0]))
+	},
+}
+
+func init() {
+	configCLICmd.AddCommand(configCLIGetCmd)
+}
@@ -0,0 +1,67 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// configCLISetCmd represents the "config-cli set" command
+var configCLISetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set the default of a flag in the CLI config",
+	Long: `Set the default of a flag in the CLI config, such as init.database. The value is parsed as YAML,
+so that lists such as [erc20_erc721,erc1155] can be given for flags that can be repeated.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key := args[0]
+		if !validConfigKeys()[key] {
+			return fmt.Errorf("'%s' is not a flag of any command. Keys are the name of a flag of the root command, or the path of a command and the name of one of its flags, such as init.database", key)
+		}
+		var value interface{}
+		if err := yaml.Unmarshal([]byte(args[1]), &value); err != nil || value == nil {
+			value = args[1]
+		}
+
+		configFile, err := configCLIFile()
+		if err != nil {
+			return err
+		}
+		// Use a config that only has the contents of the file, so that environment variables are not written to it
+		fileConfig := viper.New()
+		fileConfig.SetConfigFile(configFile)
+		if err := fileConfig.ReadInConfig(); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		fileConfig.Set(key, value)
+		if err := fileConfig.WriteConfigAs(configFile); err != nil {
+			return err
+		}
+		fmt.Printf("'%s' set in %s\n", key, configFile)
+		return nil
+	},
+}
+
+func init() {
+	configCLICmd.AddCommand(configCLISetCmd)
+}
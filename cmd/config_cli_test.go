@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyConfigDefaults(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	var database, connector string
+	var tokens []string
+	root := &cobra.Command{Use: "ff"}
+	parent := &cobra.Command{Use: "init"}
+	child := &cobra.Command{Use: "ethereum"}
+	root.AddCommand(parent)
+	parent.AddCommand(child)
+	parent.PersistentFlags().StringVarP(&database, "database", "d", "sqlite3", "")
+	parent.PersistentFlags().StringArrayVar(&tokens, "token-providers", []string{"erc20_erc721"}, "")
+	child.Flags().StringVar(&connector, "blockchain-connector", "evmconnect", "")
+
+	assert.Equal(t, []string{"init.ethereum.blockchain-connector", "init.blockchain-connector"}, configDefaultKeys(child, child.Flags().Lookup("blockchain-connector")))
+
+	viper.Set("init.database", "postgres")
+	viper.Set("init.blockchain-connector", "ethconnect")
+	viper.Set("init.token-providers", []interface{}{"erc20_erc721", "erc1155"})
+	assert.NoError(t, child.ParseFlags([]string{"--database", "sqlite3"}))
+	assert.NoError(t, applyConfigDefaults(child))
+	assert.Equal(t, "sqlite3", database)
+	assert.Equal(t, "ethconnect", connector)
+	assert.Equal(t, []string{"erc20_erc721", "erc1155"}, tokens)
+	assert.False(t, child.Flags().Changed("blockchain-connector"))
+}
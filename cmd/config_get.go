@@ -0,0 +1,74 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hyperledger/firefly-cli/internal/log"
+	"github.com/hyperledger/firefly-cli/internal/stacks"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// configGetCmd represents the "config get" command
+var configGetCmd = &cobra.Command{
+	Use:               "get <stack_name> <key>",
+	Short:             "Print a value from the generated FireFly core config file of a member of a stack",
+	ValidArgsFunction: listStacks,
+	Long: `Print a value from the generated FireFly core config file of a member of a stack. The file includes any
+extra core config merged in at init and the changes made with config set and config unset. Keys that are not set
+in the file are reported as not set, even though FireFly core uses its own default for them. The key is a dotted
+path, such as log.level, where a number selects an entry in a list, such as namespaces.predefined.0.name.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := log.WithVerbosity(context.Background(), verbose)
+		ctx = log.WithLogger(ctx, logger)
+
+		stackManager := stacks.NewStackManager(ctx)
+		if err := stackManager.LoadStack(args[0]); err != nil {
+			return err
+		}
+		value, err := stackManager.GetCoreConfigFile(configMember, args[1])
+		if err != nil {
+			return err
+		}
+		return printConfigValue(value)
+	},
+}
+
+// printConfigValue prints scalar config values as they are, and maps and lists as YAML
+func printConfigValue(value interface{}) error {
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		configBytes, err := yaml.Marshal(value)
+		if err != nil {
+			return err
+		}
+		fmt.Print(string(configBytes))
+	default:
+		fmt.Println(value)
+	}
+	return nil
+}
+
+func init() {
+	configGetCmd.Flags().IntVar(&configMember, "member", 0, "Index of the member whose config to print")
+
+	configCmd.AddCommand(configGetCmd)
+}
@@ -0,0 +1,56 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-cli/internal/log"
+	"github.com/hyperledger/firefly-cli/internal/stacks"
+	"github.com/spf13/cobra"
+)
+
+// configShowCmd represents the "config show" command
+var configShowCmd = &cobra.Command{
+	Use:   "show <stack_name>",
+	Short: "Print the generated FireFly core config file of a member of a stack",
+	Long: `Print the generated FireFly core config file of a member of a stack. The file includes any extra core
+config merged in at init and the changes made with config set and config unset. It does not include the defaults
+that FireFly core uses for the keys that are not set in the file.`,
+	ValidArgsFunction: listStacks,
+	Args:              cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := log.WithVerbosity(context.Background(), verbose)
+		ctx = log.WithLogger(ctx, logger)
+
+		stackManager := stacks.NewStackManager(ctx)
+		if err := stackManager.LoadStack(args[0]); err != nil {
+			return err
+		}
+		value, err := stackManager.GetCoreConfigFile(configMember, "")
+		if err != nil {
+			return err
+		}
+		return printConfigValue(value)
+	},
+}
+
+func init() {
+	configShowCmd.Flags().IntVar(&configMember, "member", 0, "Index of the member whose config to print")
+
+	configCmd.AddCommand(configShowCmd)
+}
@@ -0,0 +1,69 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hyperledger/firefly-cli/internal/docker"
+	"github.com/hyperledger/firefly-cli/internal/log"
+	"github.com/hyperledger/firefly-cli/internal/stacks"
+	"github.com/spf13/cobra"
+)
+
+// configUnsetCmd represents the "config unset" command
+var configUnsetCmd = &cobra.Command{
+	Use:               "unset <stack_name> <key>",
+	Short:             "Remove a FireFly core config value for a member of a stack",
+	ValidArgsFunction: listStacks,
+	Long: `Remove a FireFly core config value for a member of a stack, so that FireFly core uses its default,
+and restart the member's FireFly core if the stack has been started. The key is a dotted path, such as log.level.`,
+	Args: cobra.ExactArgs(2),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := log.WithVerbosity(context.Background(), verbose)
+		ctx = log.WithLogger(ctx, logger)
+
+		version, err := docker.CheckDockerConfig()
+		ctx = context.WithValue(ctx, docker.CtxComposeVersionKey{}, version)
+		cmd.SetContext(ctx)
+		return err
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stackName := args[0]
+		key := args[1]
+		stackManager := stacks.NewStackManager(cmd.Context())
+		if err := stackManager.LoadStack(stackName); err != nil {
+			return err
+		}
+		messages, err := stackManager.UnsetCoreConfig(configMember, key)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("'%s' removed for member %d of stack '%s'\n", key, configMember, stackName)
+		for _, message := range messages {
+			fmt.Printf("\n%s\n", message)
+		}
+		return nil
+	},
+}
+
+func init() {
+	configUnsetCmd.Flags().IntVar(&configMember, "member", 0, "Index of the member whose config to change")
+
+	configCmd.AddCommand(configUnsetCmd)
+}
@@ -0,0 +1,95 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/hyperledger/firefly-cli/internal/log"
+	"github.com/hyperledger/firefly-cli/internal/stacks"
+	"github.com/hyperledger/firefly-cli/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var doctorOutput string
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check this machine for problems that stop stacks from being created or started",
+	Long: `Check this machine for problems that stop stacks from being created or started.
+
+Checks the versions of docker and docker compose, that the docker daemon is reachable, the free disk
+space in docker's data root, that openssl is installed and that FIREFLY_HOME is writable. It also checks
+the images that have to run under emulation on arm64, that containers can resolve host.docker.internal
+for stacks with external members, and that the ports of every stack that is not running are free.
+
+Each problem that is found comes with what to do about it. Exits with an error if any check fails.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// A failed check is not a problem with how the command was used
+		cmd.SilenceUsage = true
+		ctx := log.WithVerbosity(context.Background(), verbose)
+		ctx = log.WithLogger(ctx, logger)
+
+		checks := stacks.Doctor(ctx)
+
+		switch doctorOutput {
+		case "text":
+			printDoctorChecks(checks)
+		case "json":
+			bytes, err := json.MarshalIndent(checks, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(bytes))
+		default:
+			return fmt.Errorf("invalid output '%s'", doctorOutput)
+		}
+
+		failed := 0
+		for _, check := range checks {
+			if check.Status.Equals(types.DoctorStatusError) {
+				failed++
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of the checks failed", failed)
+		}
+		return nil
+	},
+}
+
+func printDoctorChecks(checks []*types.DoctorCheck) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	for _, check := range checks {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", strings.ToUpper(check.Status.String()), check.Name, check.Message)
+		if check.Remediation != "" {
+			fmt.Fprintf(w, "\t\t-> %s\n", check.Remediation)
+		}
+	}
+	w.Flush()
+}
+
+func init() {
+	doctorCmd.Flags().StringVarP(&doctorOutput, "output", "o", "text", "output format (\"text\"|\"json\")")
+	rootCmd.AddCommand(doctorCmd)
+}
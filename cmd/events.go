@@ -0,0 +1,88 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/hyperledger/firefly-cli/internal/log"
+	"github.com/hyperledger/firefly-cli/internal/stacks"
+	"github.com/hyperledger/firefly-cli/pkg/types"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/spf13/cobra"
+)
+
+var eventsOptions types.EventsOptions
+
+// eventsCmd represents the events command
+var eventsCmd = &cobra.Command{
+	Use:               "events <stack_name>",
+	Short:             "Watch the events of a running stack",
+	ValidArgsFunction: listStacks,
+	Long: `Watch the events of a running stack as they happen.
+
+The events are delivered to an ephemeral subscription on the websocket of the
+FireFly core of a member, the first member by default, until the command is
+interrupted. The filters are regular expressions, in the same way as the filters
+of FireFly subscriptions. Use --output json to print each event as a line of JSON.
+
+The event types are:
+  ` + strings.Join(stacks.EventTypes, "\n  "),
+	Example: `  ff events dev
+  ff events dev --member 1 --namespace ns1 --type message_confirmed --tag '^orders'
+  ff events dev --type 'token_.*' --output json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := log.WithVerbosity(context.Background(), verbose)
+		ctx = log.WithLogger(ctx, logger)
+		ctx, cancel := signal.NotifyContext(ctx, os.Interrupt)
+		defer cancel()
+
+		stackManager := stacks.NewStackManager(ctx)
+		if err := stackManager.LoadStack(args[0]); err != nil {
+			return err
+		}
+		stackHasRunBefore, err := stackManager.Stack.HasRunBefore()
+		if err != nil {
+			return err
+		}
+		if !stackHasRunBefore {
+			return fmt.Errorf("stack '%s' has not been started", args[0])
+		}
+		return stackManager.Events(&eventsOptions)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(eventsCmd)
+	eventsCmd.Flags().IntVar(&eventsOptions.Member, "member", 0, "Index of the member whose FireFly core to watch")
+	eventsCmd.Flags().StringVarP(&eventsOptions.Namespace, "namespace", "n", "default", "Namespace to watch")
+	eventsCmd.Flags().StringArrayVar(&eventsOptions.Types, "type", nil, "Type of event to show, or a regular expression matching several types. Can be repeated")
+	eventsCmd.Flags().StringVar(&eventsOptions.Topic, "topic", "", "Regular expression matching the topic of events, which for messages is one of their topics")
+	eventsCmd.Flags().StringVar(&eventsOptions.Tag, "tag", "", "Regular expression matching the tag of messages")
+	eventsCmd.Flags().StringVar(&eventsOptions.Group, "group", "", "Regular expression matching the group hash of private messages")
+	eventsCmd.Flags().StringVar(&eventsOptions.Author, "author", "", "Regular expression matching the DID of the author of messages")
+	eventsCmd.Flags().StringVar(&eventsOptions.TransactionType, "tx-type", "", "Regular expression matching the type of the transaction of events")
+	eventsCmd.Flags().StringVar(&eventsOptions.BlockchainEventName, "blockchain-event-name", "", "Regular expression matching the name of blockchain events")
+	eventsCmd.Flags().StringVar(&eventsOptions.BlockchainEventListener, "blockchain-event-listener", "", "Regular expression matching the ID of the listener of blockchain events")
+	eventsCmd.Flags().BoolVar(&eventsOptions.WithData, "with-data", false, "Include the data of messages in the events")
+	eventsCmd.Flags().StringVarP(&eventsOptions.Output, "output", "o", "text", fmt.Sprintf("Output format. Options are: %v", fftypes.FFEnumValues(types.EventsOutput)))
+}
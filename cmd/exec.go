@@ -0,0 +1,92 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hyperledger/firefly-cli/internal/docker"
+	"github.com/hyperledger/firefly-cli/internal/log"
+	"github.com/hyperledger/firefly-cli/internal/stacks"
+	"github.com/spf13/cobra"
+)
+
+// execCmd represents the exec command
+var execCmd = &cobra.Command{
+	Use:               "exec <stack_name> <service> [member] -- <command...>",
+	Short:             "Run a command in a service of a stack",
+	ValidArgsFunction: listStacks,
+	Long: `Run a command in the running container of a service of a stack.
+
+The service is the name of a service in the stack's docker compose file, such as geth or
+evmconnect_0, or the name of a service that is run for every member along with the index
+of the member, such as "evmconnect 0". The start of a service name can also be used when
+it only matches one service.`,
+	Example: `  ff exec dev dataexchange 1 -- ls /data
+  ff exec dev geth -- geth attach --exec eth.blockNumber http://localhost:8545`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := log.WithVerbosity(context.Background(), verbose)
+		ctx = log.WithLogger(ctx, logger)
+
+		version, err := docker.CheckDockerConfig()
+		ctx = context.WithValue(ctx, docker.CtxComposeVersionKey{}, version)
+		cmd.SetContext(ctx)
+		return err
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dash := cmd.ArgsLenAtDash()
+		if dash < 0 || dash == len(args) {
+			return errors.New("no command specified - put the command to run after --")
+		}
+		if dash < 2 || dash > 3 {
+			return errors.New("expected a stack name, a service and optionally a member index before --")
+		}
+		memberIndex, err := parseMemberIndexArg(args[2:dash])
+		if err != nil {
+			return err
+		}
+
+		stackManager := stacks.NewStackManager(cmd.Context())
+		if err := stackManager.LoadStack(args[0]); err != nil {
+			return err
+		}
+		serviceName, err := stackManager.ResolveService(args[1], memberIndex)
+		if err != nil {
+			return err
+		}
+		return stackManager.Exec(serviceName, args[dash:])
+	},
+}
+
+// parseMemberIndexArg parses the optional member index argument of a command, returning -1 if there isn't one
+func parseMemberIndexArg(args []string) (int, error) {
+	if len(args) == 0 {
+		return -1, nil
+	}
+	memberIndex, err := strconv.Atoi(args[0])
+	if err != nil || memberIndex < 0 {
+		return -1, fmt.Errorf("invalid member index '%s'", args[0])
+	}
+	return memberIndex, nil
+}
+
+func init() {
+	rootCmd.AddCommand(execCmd)
+}
@@ -22,21 +22,39 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 
+	"github.com/hyperledger/firefly-cli/internal/blockchain"
+	"github.com/hyperledger/firefly-cli/internal/blockchain/ethereum/geth"
 	"github.com/hyperledger/firefly-cli/internal/log"
 	"github.com/hyperledger/firefly-cli/internal/stacks"
+	"github.com/hyperledger/firefly-cli/internal/tokens"
 	"github.com/hyperledger/firefly-cli/pkg/types"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 )
 
 var initOptions types.InitOptions
 var promptNames bool
+var additionalBlockchains []string
+var memberCoreConfigs map[string]string
+var memberConnectorConfigs map[string]string
+var serviceEnvironmentVars []string
+var serviceCPUs map[string]string
+var serviceMemory map[string]string
+var serviceRestart map[string]string
+var serviceUlimits []string
+var customTokenProviders []string
+
+var nodePerMemberUsage = "Run a geth or besu blockchain node for each member, with each member's connector and keys on their own node, rather than one node shared by every member"
+var gethModeUsage = fmt.Sprintf("How the geth node runs. clique is geth 1.10 sealing Clique blocks with unlocked accounts. dev is a current geth release with --dev, with the keys of the members in ethsigner, and always has chain ID %d. Options are: %v", geth.DevChainID, fftypes.FFEnumValues(types.GethMode))
 
 var ffNameValidator = regexp.MustCompile(`^[0-9a-zA-Z]([0-9a-zA-Z._-]{0,62}[0-9a-zA-Z])?$`)
 
@@ -51,7 +69,7 @@ var initCmd = &cobra.Command{
 		ctx := log.WithVerbosity(context.Background(), verbose)
 		ctx = log.WithLogger(ctx, logger)
 		stackManager := stacks.NewStackManager(ctx)
-		if err := initCommon(args); err != nil {
+		if err := initCommon(cmd, args); err != nil {
 			return err
 		}
 		if err := stackManager.InitStack(&initOptions); err != nil {
@@ -66,14 +84,24 @@ var initCmd = &cobra.Command{
 	},
 }
 
-func initCommon(args []string) error {
+func initCommon(cmd *cobra.Command, args []string) error {
 	if err := validateDatabaseProvider(initOptions.DatabaseProvider); err != nil {
 		return err
 	}
 	if err := validateBlockchainProvider(initOptions.BlockchainProvider, initOptions.BlockchainNodeProvider); err != nil {
 		return err
 	}
-	if err := validateTokensProvider(initOptions.TokenProviders, initOptions.BlockchainNodeProvider); err != nil {
+	customProviders, err := parseCustomTokenProviders(customTokenProviders)
+	if err != nil {
+		return err
+	}
+	initOptions.CustomTokenProviders = customProviders
+	for _, provider := range customProviders {
+		if !slices.Contains(initOptions.TokenProviders, provider.Name) {
+			initOptions.TokenProviders = append(initOptions.TokenProviders, provider.Name)
+		}
+	}
+	if err := validateTokensProvider(initOptions.TokenProviders, customProviders, initOptions.BlockchainNodeProvider); err != nil {
 		return err
 	}
 	if err := validateReleaseChannel(initOptions.ReleaseChannel); err != nil {
@@ -82,15 +110,51 @@ func initCommon(args []string) error {
 	if err := validateIPFSMode(initOptions.IPFSMode); err != nil {
 		return err
 	}
-	if err := validateConsensus(initOptions.Consensus); err != nil {
+	if err := validateLogAggregation(initOptions.LogAggregation); err != nil {
+		return err
+	}
+	if err := validateGethMode(initOptions.GethMode, initOptions.BlockchainNodeProvider); err != nil {
+		return err
+	}
+	if err := validateNodePerMember(initOptions.NodePerMember, initOptions.BlockchainNodeProvider, initOptions.GethMode); err != nil {
+		return err
+	}
+	if err := validateDevChainID(initOptions.GethMode, initOptions.ChainID, cmd.Flags().Changed("chain-id")); err != nil {
 		return err
 	}
+	if fftypes.FFEnum(initOptions.GethMode).Equals(types.GethModeDev) {
+		// Every geth --dev chain has the same chain ID
+		initOptions.ChainID = geth.DevChainID
+	}
+	if err := validateAuthMode(initOptions.AuthMode, initOptions.BlockchainProvider, initOptions.BlockchainConnector); err != nil {
+		return err
+	}
+	if err := validateConsensus(initOptions.Consensus, initOptions.BlockchainNodeProvider); err != nil {
+		return err
+	}
+	if err := validateResourceProfile(initOptions.ResourceProfile); err != nil {
+		return err
+	}
+	if initOptions.GrafanaEnabled && !initOptions.PrometheusEnabled {
+		return fmt.Errorf("--grafana-enabled requires --prometheus-enabled")
+	}
 	if err := validatePrivateTransactionManagerSelection(initOptions.PrivateTransactionManager, initOptions.BlockchainNodeProvider); err != nil {
 		return err
 	}
 	if err := validatePrivateTransactionManagerBlockchainConnectorCombination(initOptions.PrivateTransactionManager, initOptions.BlockchainConnector); err != nil {
 		return err
 	}
+	chains, err := parseAdditionalBlockchains(additionalBlockchains)
+	if err != nil {
+		return err
+	}
+	initOptions.AdditionalBlockchains = chains
+	if err := validateBesuTracing(initOptions.BesuTracingEnabled, initOptions.BlockchainProvider, initOptions.BlockchainNodeProvider, chains); err != nil {
+		return err
+	}
+	if initOptions.ServiceResources, err = parseServiceResources(); err != nil {
+		return err
+	}
 
 	fmt.Println("initializing new FireFly stack...")
 
@@ -149,9 +213,53 @@ func initCommon(args []string) error {
 	initOptions.OrgNames = orgNames
 	initOptions.NodeNames = nodeNames
 
+	if initOptions.MemberCoreConfigPaths, err = parseMemberConfigPaths("member-core-config", memberCoreConfigs); err != nil {
+		return err
+	}
+	if initOptions.MemberConnectorConfigPaths, err = parseMemberConfigPaths("member-connector-config", memberConnectorConfigs); err != nil {
+		return err
+	}
+	if initOptions.ServiceEnvironmentVars, err = parseServiceEnvironmentVars(serviceEnvironmentVars); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// parseMemberConfigPaths parses a flag mapping member indexes to config files, for example 1=./slow_batches.yml
+func parseMemberConfigPaths(flagName string, input map[string]string) (map[int]string, error) {
+	configPaths := make(map[int]string, len(input))
+	for indexString, configPath := range input {
+		index, err := strconv.Atoi(indexString)
+		if err != nil || index < 0 || index >= initOptions.MemberCount {
+			return nil, fmt.Errorf("invalid member '%s' in --%s: must be a member index between 0 and %d", indexString, flagName, initOptions.MemberCount-1)
+		}
+		if _, err := os.Stat(configPath); err != nil {
+			return nil, fmt.Errorf("invalid config file for member %d in --%s: %s", index, flagName, err)
+		}
+		configPaths[index] = configPath
+	}
+	return configPaths, nil
+}
+
+// parseServiceEnvironmentVars parses the --service-environment-vars flags, each of which is a service selector and
+// an environment variable, for example evmconnect:LOG_LEVEL=debug
+func parseServiceEnvironmentVars(input []string) (map[string]map[string]string, error) {
+	environmentVars := make(map[string]map[string]string)
+	for _, entry := range input {
+		selector, environmentVar, found := strings.Cut(entry, ":")
+		key, value, hasValue := strings.Cut(environmentVar, "=")
+		if !found || !hasValue || selector == "" || key == "" {
+			return nil, fmt.Errorf("invalid service environment variable '%s': expected <service>:<name>=<value>", entry)
+		}
+		if environmentVars[selector] == nil {
+			environmentVars[selector] = make(map[string]string)
+		}
+		environmentVars[selector][key] = value
+	}
+	return environmentVars, nil
+}
+
 func validateStackName(stackName string) error {
 	if strings.TrimSpace(stackName) == "" {
 		return errors.New("stack name must not be empty")
@@ -192,34 +300,34 @@ func validateDatabaseProvider(input string) error {
 }
 
 func validateBlockchainProvider(providerString, nodeString string) error {
-	_, err := fftypes.FFEnumParseString(context.Background(), types.BlockchainProvider, providerString)
-	if err != nil {
-		return nil
-	}
-
-	v, err := fftypes.FFEnumParseString(context.Background(), types.BlockchainNodeProvider, nodeString)
-	if err != nil {
-		return nil
-	}
-
-	if v == types.BlockchainProviderCorda {
+	if fftypes.FFEnum(providerString).Equals(types.BlockchainProviderCorda) {
 		return errors.New("support for corda is coming soon")
 	}
 
-	return nil
+	// Only the blockchain providers and node providers that are registered can be created
+	return blockchain.Validate(providerString, nodeString)
 }
 
-func validateConsensus(consensusString string) error {
+func validateConsensus(consensusString, nodeString string) error {
 	v, err := fftypes.FFEnumParseString(context.Background(), types.Consensus, consensusString)
 	if err != nil {
-		return nil
+		return err
 	}
 
-	if v != types.ConsensusClique {
-		return errors.New("currently only Clique consensus is supported")
+	// Clique is supported by every Ethereum node, the BFT and Raft algorithms only by the nodes that implement them
+	supported := []fftypes.FFEnum{types.ConsensusClique}
+	switch fftypes.FFEnum(nodeString) {
+	case types.BlockchainNodeProviderBesu:
+		supported = append(supported, types.ConsensusQbft, types.ConsensusIbft)
+	case types.BlockchainNodeProviderQuorum:
+		supported = append(supported, types.ConsensusQbft, types.ConsensusRaft)
 	}
-
-	return nil
+	for _, s := range supported {
+		if v.Equals(s) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s consensus is not supported by the %s blockchain node. Supported options are %v", v, nodeString, supported)
 }
 
 func validatePrivateTransactionManagerSelection(privateTransactionManagerInput string, nodeString string) error {
@@ -260,22 +368,21 @@ func validatePrivateTransactionManagerBlockchainConnectorCombination(privateTran
 	return nil
 }
 
-func validateTokensProvider(input []string, blockchainNodeProviderInput string) error {
-	tokenProviders := make([]fftypes.FFEnum, len(input))
-	for i, t := range input {
+func validateTokensProvider(input []string, customProviders []*types.CustomTokenProvider, blockchainNodeProviderInput string) error {
+	tokenProviders := make([]fftypes.FFEnum, 0, len(input))
+	for _, t := range input {
+		if slices.ContainsFunc(customProviders, func(p *types.CustomTokenProvider) bool { return p.Name == t }) {
+			continue
+		}
 		tp, err := fftypes.FFEnumParseString(context.Background(), types.TokenProvider, t)
 		if err != nil {
 			return err
 		}
-		tokenProviders[i] = tp
+		tokenProviders = append(tokenProviders, tp)
 	}
 
-	nodeProvider, err := fftypes.FFEnumParseString(context.Background(), types.BlockchainNodeProvider, blockchainNodeProviderInput)
-	if err != nil {
-		return err
-	}
-
-	if nodeProvider.Equals(types.BlockchainNodeProviderRemoteRPC) {
+	// The node provider has already been checked against the registered providers by validateBlockchainProvider
+	if fftypes.FFEnum(blockchainNodeProviderInput).Equals(types.BlockchainNodeProviderRemoteRPC) {
 		for _, t := range tokenProviders {
 			if t.Equals(types.TokenProviderERC1155) {
 				return errors.New("erc1155 is currently not supported with a remote-rpc node")
@@ -295,6 +402,288 @@ func validateIPFSMode(input string) error {
 	return err
 }
 
+func validateLogAggregation(input string) error {
+	_, err := fftypes.FFEnumParseString(context.Background(), types.LogAggregation, input)
+	return err
+}
+
+func validateGethMode(input, blockchainNodeProviderInput string) error {
+	if input == "" {
+		return nil
+	}
+	gethMode, err := fftypes.FFEnumParseString(context.Background(), types.GethMode, input)
+	if err != nil {
+		return err
+	}
+	if gethMode.Equals(types.GethModeDev) && !fftypes.FFEnum(blockchainNodeProviderInput).Equals(types.BlockchainNodeProviderGeth) {
+		return fmt.Errorf("--geth-mode %s requires the %s blockchain node", gethMode, types.BlockchainNodeProviderGeth)
+	}
+	return nil
+}
+
+// validateDevChainID rejects a --chain-id that was set explicitly for a geth --dev chain, as its chain ID cannot be
+// changed
+func validateDevChainID(gethModeInput string, chainID int64, chainIDChanged bool) error {
+	if fftypes.FFEnum(gethModeInput).Equals(types.GethModeDev) && chainIDChanged && chainID != geth.DevChainID {
+		return fmt.Errorf("--chain-id %d is not supported with --geth-mode %s, which always has the chain ID %d", chainID, types.GethModeDev, geth.DevChainID)
+	}
+	return nil
+}
+
+func validateNodePerMember(nodePerMember bool, blockchainNodeProviderInput, gethModeInput string) error {
+	if !nodePerMember {
+		return nil
+	}
+	node := fftypes.FFEnum(blockchainNodeProviderInput)
+	if !node.Equals(types.BlockchainNodeProviderGeth) && !node.Equals(types.BlockchainNodeProviderBesu) {
+		return fmt.Errorf("--node-per-member requires the %s or %s blockchain node", types.BlockchainNodeProviderGeth, types.BlockchainNodeProviderBesu)
+	}
+	if fftypes.FFEnum(gethModeInput).Equals(types.GethModeDev) {
+		return fmt.Errorf("--node-per-member is not supported with --geth-mode %s, which is a single node chain", types.GethModeDev)
+	}
+	return nil
+}
+
+// validateAuthMode checks that basic auth is only enabled for stacks where every service it is configured on checks
+// the credentials. FireFly core and evmconnect read them from the htpasswd file generated for each member, but
+// ethconnect and the fabric, tezos and cardano connectors have no such setting.
+func validateAuthMode(authModeInput, blockchainProviderInput, blockchainConnectorInput string) error {
+	authMode, err := fftypes.FFEnumParseString(context.Background(), types.AuthMode, authModeInput)
+	if err != nil {
+		return err
+	}
+
+	if authMode.Equals(types.AuthModeBasic) {
+		blockchainProvider, _ := fftypes.FFEnumParseString(context.Background(), types.BlockchainProvider, blockchainProviderInput)
+		blockchainConnector, _ := fftypes.FFEnumParseString(context.Background(), types.BlockchainConnector, blockchainConnectorInput)
+		if !blockchainProvider.Equals(types.BlockchainProviderEthereum) || !blockchainConnector.Equals(types.BlockchainConnectorEvmconnect) {
+			return errors.New("basic auth is currently only supported for ethereum stacks using the evmconnect blockchain connector")
+		}
+	}
+	return nil
+}
+
+// validateBesuTracing checks that a stack with besu tracing enabled has a besu node, as besu is the only service in a stack
+// that exports traces over OpenTelemetry
+func validateBesuTracing(tracingEnabled bool, blockchainProviderInput, blockchainNodeProviderInput string, chains []*types.AdditionalBlockchainOptions) error {
+	if !tracingEnabled {
+		return nil
+	}
+	isBesu := func(provider, node string) bool {
+		return fftypes.FFEnum(provider).Equals(types.BlockchainProviderEthereum) && fftypes.FFEnum(node).Equals(types.BlockchainNodeProviderBesu)
+	}
+	if isBesu(blockchainProviderInput, blockchainNodeProviderInput) {
+		return nil
+	}
+	for _, chain := range chains {
+		if isBesu(chain.BlockchainProvider, chain.BlockchainNodeProvider) {
+			return nil
+		}
+	}
+	return fmt.Errorf("--besu-tracing requires the %s blockchain node, which is the only service in a stack that exports traces", types.BlockchainNodeProviderBesu)
+}
+
+// parseAdditionalBlockchains parses the --additional-blockchain flags, each of which is a comma separated list of
+// key=value pairs, for example "provider=ethereum,node=geth,connector=evmconnect,chain-id=2022"
+func parseAdditionalBlockchains(specs []string) ([]*types.AdditionalBlockchainOptions, error) {
+	if len(specs) > types.MaxAdditionalBlockchains {
+		return nil, fmt.Errorf("a stack can have at most %d additional blockchains", types.MaxAdditionalBlockchains)
+	}
+	fabricCount := 0
+	if initOptions.BlockchainProvider == types.BlockchainProviderFabric.String() {
+		fabricCount++
+	}
+	chains := make([]*types.AdditionalBlockchainOptions, len(specs))
+	for i, spec := range specs {
+		chain := &types.AdditionalBlockchainOptions{
+			BlockchainProvider: types.BlockchainProviderEthereum.String(),
+			ChainID:            initOptions.ChainID + int64(i) + 1,
+		}
+		for _, pair := range strings.Split(spec, ",") {
+			key, value, found := strings.Cut(strings.TrimSpace(pair), "=")
+			if !found {
+				return nil, fmt.Errorf("invalid additional blockchain '%s': expected a comma separated list of key=value pairs", spec)
+			}
+			switch key {
+			case "provider":
+				chain.BlockchainProvider = value
+			case "node":
+				chain.BlockchainNodeProvider = value
+			case "connector":
+				chain.BlockchainConnector = value
+			case "chain-id":
+				chainID, err := strconv.ParseInt(value, 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid chain-id '%s' for additional blockchain '%s'", value, spec)
+				}
+				chain.ChainID = chainID
+			case "remote-node-url":
+				chain.RemoteNodeURL = value
+			default:
+				return nil, fmt.Errorf("unknown key '%s' for additional blockchain '%s'. Options are: provider, node, connector, chain-id, remote-node-url", key, spec)
+			}
+		}
+
+		switch chain.BlockchainProvider {
+		case types.BlockchainProviderEthereum.String():
+			if chain.BlockchainNodeProvider == "" {
+				chain.BlockchainNodeProvider = types.BlockchainNodeProviderGeth.String()
+			}
+			if chain.BlockchainConnector == "" {
+				chain.BlockchainConnector = types.BlockchainConnectorEvmconnect.String()
+			}
+			if chain.BlockchainNodeProvider != types.BlockchainNodeProviderGeth.String() && chain.BlockchainNodeProvider != types.BlockchainNodeProviderRemoteRPC.String() {
+				return nil, fmt.Errorf("additional ethereum blockchains can only use the %s or %s node", types.BlockchainNodeProviderGeth, types.BlockchainNodeProviderRemoteRPC)
+			}
+			if chain.BlockchainConnector != types.BlockchainConnectorEvmconnect.String() && chain.BlockchainConnector != types.BlockchainConnectorEthconnect.String() {
+				return nil, fmt.Errorf("additional ethereum blockchains can only use the %s or %s connector", types.BlockchainConnectorEvmconnect, types.BlockchainConnectorEthconnect)
+			}
+			if chain.BlockchainNodeProvider == types.BlockchainNodeProviderRemoteRPC.String() && chain.RemoteNodeURL == "" {
+				return nil, fmt.Errorf("remote-node-url must be set for additional blockchain '%s'", spec)
+			}
+		case types.BlockchainProviderFabric.String():
+			if chain.BlockchainNodeProvider != "" {
+				return nil, fmt.Errorf("node cannot be set for additional fabric blockchain '%s'", spec)
+			}
+			if chain.BlockchainConnector == "" {
+				chain.BlockchainConnector = types.BlockchainConnectorFabconnect.String()
+			}
+			if chain.BlockchainConnector != types.BlockchainConnectorFabconnect.String() {
+				return nil, fmt.Errorf("additional fabric blockchains can only use the %s connector", types.BlockchainConnectorFabconnect)
+			}
+			fabricCount++
+			if fabricCount > 1 {
+				return nil, errors.New("a stack can only contain one fabric network")
+			}
+		default:
+			return nil, fmt.Errorf("unsupported provider '%s' for additional blockchain '%s'. Options are: %s, %s", chain.BlockchainProvider, spec, types.BlockchainProviderEthereum, types.BlockchainProviderFabric)
+		}
+
+		if err := validateAuthMode(initOptions.AuthMode, chain.BlockchainProvider, chain.BlockchainConnector); err != nil {
+			return nil, err
+		}
+		chains[i] = chain
+	}
+	return chains, nil
+}
+
+func validateResourceProfile(input string) error {
+	_, err := fftypes.FFEnumParseString(context.Background(), types.ResourceProfile, input)
+	return err
+}
+
+// parseServiceResources parses the flags setting the resource limits, restart policies and ulimits of each
+// class of services, which override the values from the resource profile
+func parseServiceResources() (map[string]*types.ServiceResources, error) {
+	resources := make(map[string]*types.ServiceResources)
+	forClass := func(flagName, class string) (*types.ServiceResources, error) {
+		if _, err := fftypes.FFEnumParseString(context.Background(), types.ServiceClass, class); err != nil {
+			return nil, fmt.Errorf("invalid service class '%s' in --%s. Options are: %v", class, flagName, fftypes.FFEnumValues(types.ServiceClass))
+		}
+		if resources[class] == nil {
+			resources[class] = &types.ServiceResources{}
+		}
+		return resources[class], nil
+	}
+
+	for class, cpus := range serviceCPUs {
+		r, err := forClass("service-cpus", class)
+		if err != nil {
+			return nil, err
+		}
+		if err := types.ValidateCPUs(cpus); err != nil {
+			return nil, fmt.Errorf("--service-cpus for service class '%s': %s", class, err)
+		}
+		r.CPUs = cpus
+	}
+	for class, memory := range serviceMemory {
+		r, err := forClass("service-memory", class)
+		if err != nil {
+			return nil, err
+		}
+		if err := types.ValidateMemoryLimit(memory); err != nil {
+			return nil, fmt.Errorf("--service-memory for service class '%s': %s", class, err)
+		}
+		r.Memory = memory
+	}
+	for class, restart := range serviceRestart {
+		r, err := forClass("service-restart", class)
+		if err != nil {
+			return nil, err
+		}
+		if err := types.ValidateRestartPolicy(restart); err != nil {
+			return nil, fmt.Errorf("--service-restart for service class '%s': %s", class, err)
+		}
+		r.Restart = restart
+	}
+	for _, entry := range serviceUlimits {
+		class, ulimit, found := strings.Cut(entry, ":")
+		name, limits, hasLimits := strings.Cut(ulimit, "=")
+		if !found || !hasLimits || name == "" {
+			return nil, fmt.Errorf("invalid ulimit '%s': expected <class>:<name>=<soft>[:<hard>]", entry)
+		}
+		softString, hardString, hasHard := strings.Cut(limits, ":")
+		soft, err := strconv.Atoi(softString)
+		hard := soft
+		if err == nil && hasHard {
+			hard, err = strconv.Atoi(hardString)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid ulimit '%s': limits must be numbers", entry)
+		}
+		r, err := forClass("service-ulimit", class)
+		if err != nil {
+			return nil, err
+		}
+		if r.Ulimits == nil {
+			r.Ulimits = make(map[string]*types.Ulimit)
+		}
+		r.Ulimits[name] = &types.Ulimit{Soft: soft, Hard: hard}
+	}
+	return resources, nil
+}
+
+// parseCustomTokenProviders reads the files passed to --custom-token-provider. The contracts file of each one is
+// relative to the directory of the file it is set in.
+func parseCustomTokenProviders(paths []string) ([]*types.CustomTokenProvider, error) {
+	providers := make([]*types.CustomTokenProvider, 0, len(paths))
+	for _, path := range paths {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var provider *types.CustomTokenProvider
+		if err := yaml.Unmarshal(b, &provider); err != nil || provider == nil {
+			return nil, fmt.Errorf("invalid custom token provider file '%s': %v", path, err)
+		}
+		if err := fftypes.ValidateFFNameField(context.Background(), provider.Name, "name"); err != nil {
+			return nil, fmt.Errorf("invalid custom token provider file '%s': %s", path, err)
+		}
+		if tokens.IsRegistered(provider.Name) || provider.Name == types.TokenProviderNone.String() {
+			return nil, fmt.Errorf("custom token provider '%s' has the same name as a built in token provider", provider.Name)
+		}
+		if slices.ContainsFunc(providers, func(p *types.CustomTokenProvider) bool { return p.Name == provider.Name }) {
+			return nil, fmt.Errorf("custom token provider '%s' is set more than once", provider.Name)
+		}
+		if provider.Image == "" {
+			return nil, fmt.Errorf("custom token provider '%s' does not have an image", provider.Name)
+		}
+		if (provider.Contracts == "") != (provider.FactoryContract == "") {
+			return nil, fmt.Errorf("custom token provider '%s' must set both contracts and factoryContract, or neither", provider.Name)
+		}
+		if provider.Contracts != "" {
+			if !filepath.IsAbs(provider.Contracts) {
+				provider.Contracts = filepath.Join(filepath.Dir(path), provider.Contracts)
+			}
+			if _, err := os.Stat(provider.Contracts); err != nil {
+				return nil, fmt.Errorf("contracts file of custom token provider '%s' not found: %s", provider.Name, err)
+			}
+		}
+		providers = append(providers, provider)
+	}
+	return providers, nil
+}
+
 func randomHexString(length int) (string, error) {
 	bytes := make([]byte, length)
 	if _, err := rand.Read(bytes); err != nil {
@@ -314,6 +703,7 @@ func init() {
 	initCmd.PersistentFlags().StringVar(&initOptions.PrivateTransactionManager, "private-transaction-manager", "none", fmt.Sprintf("Private Transaction Manager to use. Options are: %v", fftypes.FFEnumValues(types.PrivateTransactionManager)))
 	initCmd.PersistentFlags().StringVar(&initOptions.Consensus, "consensus", "clique", fmt.Sprintf("Consensus algorithm to use. Options are %v", fftypes.FFEnumValues(types.Consensus)))
 	initCmd.PersistentFlags().StringArrayVarP(&initOptions.TokenProviders, "token-providers", "t", []string{"erc20_erc721"}, fmt.Sprintf("Token providers to use. Options are: %v", fftypes.FFEnumValues(types.TokenProvider)))
+	initCmd.PersistentFlags().StringArrayVar(&customTokenProviders, "custom-token-provider", []string{}, "The path to a yaml file configuring a token connector that implements the fftokens API with its name, image, port, healthCheckPath, environment, contracts file, factoryContract and factoryAddressEnv. It is added to --token-providers")
 	initCmd.PersistentFlags().IntVarP(&initOptions.ExternalProcesses, "external", "e", 0, "Manage a number of FireFly core processes outside of the docker-compose stack - useful for development and debugging")
 	initCmd.PersistentFlags().StringVarP(&initOptions.FireFlyVersion, "release", "r", "latest", fmt.Sprintf("Select the FireFly release version to use. Options are: %v", fftypes.FFEnumValues(types.ReleaseChannelSelection)))
 	initCmd.PersistentFlags().StringVarP(&initOptions.ManifestPath, "manifest", "m", "", "Path to a manifest.json file containing the versions of each FireFly microservice to use. Overrides the --release flag.")
@@ -321,8 +711,20 @@ func init() {
 	initCmd.PersistentFlags().BoolVar(&initOptions.PrometheusEnabled, "prometheus-enabled", false, "Enables Prometheus metrics exposition and aggregation to a shared Prometheus server")
 	initCmd.PersistentFlags().BoolVar(&initOptions.SandboxEnabled, "sandbox-enabled", true, "Enables the FireFly Sandbox to be started with your FireFly stack")
 	initCmd.PersistentFlags().IntVar(&initOptions.PrometheusPort, "prometheus-port", 9090, "Port for the shared Prometheus server")
+	initCmd.PersistentFlags().BoolVar(&initOptions.GrafanaEnabled, "grafana-enabled", false, "Enables a Grafana server with the shared Prometheus server as a datasource and dashboards for the stack. Requires --prometheus-enabled")
+	initCmd.PersistentFlags().IntVar(&initOptions.GrafanaPort, "grafana-port", 3000, "Port for the Grafana server")
+	initCmd.PersistentFlags().BoolVar(&initOptions.BesuTracingEnabled, "besu-tracing", false, "Enables an OpenTelemetry collector and a Jaeger UI, and configures the besu nodes to export traces to them. Requires the besu blockchain node. FireFly core, the blockchain and token connectors and data exchange do not emit OpenTelemetry spans, so a transaction cannot be traced through the rest of the stack")
+	initCmd.PersistentFlags().IntVar(&initOptions.JaegerPort, "jaeger-port", 16686, "Port for the Jaeger UI when --besu-tracing is enabled")
+	initCmd.PersistentFlags().StringVar(&initOptions.LogAggregation, "log-aggregation", "none", fmt.Sprintf("Ship the logs of every container in the stack to a log aggregation service, which is a datasource of Grafana if it is enabled. Options are: %v", fftypes.FFEnumValues(types.LogAggregation)))
+	initCmd.PersistentFlags().IntVar(&initOptions.LokiPort, "loki-port", 3100, "Port for the Loki API when --log-aggregation is loki")
+	initCmd.PersistentFlags().BoolVar(&initOptions.TLSEnabled, "tls", false, "Generate a stack CA and serve the FireFly core, ethereum connector, data exchange and IPFS APIs over HTTPS. The token connectors are served through a TLS terminating proxy. The fabric, tezos and cardano connectors stay on plain HTTP")
+	initCmd.PersistentFlags().StringVar(&initOptions.AuthMode, "auth", "none", fmt.Sprintf("Generate credentials for each member and require them on the FireFly core and blockchain connector APIs. Only supported for ethereum with the evmconnect connector, as the other connectors do not check credentials. The token connector APIs are not protected, and use the credentials only to call the blockchain connector. Options are: %v", fftypes.FFEnumValues(types.AuthMode)))
 	initCmd.PersistentFlags().StringVar(&initOptions.ExtraCoreConfigPath, "core-config", "", "The path to a yaml file containing extra config for FireFly Core")
 	initCmd.PersistentFlags().StringVar(&initOptions.ExtraConnectorConfigPath, "connector-config", "", "The path to a yaml file containing extra config for the blockchain connector")
+	initCmd.PersistentFlags().StringToStringVar(&memberCoreConfigs, "member-core-config", map[string]string{}, "The path to a yaml file containing extra config for the FireFly Core of one member, merged after --core-config. For example: 1=./slow_batches.yml")
+	initCmd.PersistentFlags().StringToStringVar(&memberConnectorConfigs, "member-connector-config", map[string]string{}, "The path to a yaml file containing extra config for the blockchain connector of one member, merged after --connector-config. For example: 1=./evmconnect.yml")
+	initCmd.Flags().StringVar(&initOptions.GethMode, "geth-mode", "clique", gethModeUsage)
+	initCmd.Flags().BoolVar(&initOptions.NodePerMember, "node-per-member", false, nodePerMemberUsage)
 	initCmd.Flags().IntVar(&initOptions.BlockPeriod, "block-period", -1, "Block period in seconds. Default is variable based on selected blockchain provider.")
 	initCmd.Flags().StringVar(&initOptions.ContractAddress, "contract-address", "", "Do not automatically deploy a contract, instead use a pre-configured address")
 	initCmd.Flags().StringVar(&initOptions.RemoteNodeURL, "remote-node-url", "", "For cases where the node is pre-existing and running remotely")
@@ -334,6 +736,13 @@ func init() {
 	initCmd.PersistentFlags().StringArrayVar(&initOptions.OrgNames, "org-name", []string{}, "Organization name")
 	initCmd.PersistentFlags().StringArrayVar(&initOptions.NodeNames, "node-name", []string{}, "Node name")
 	initCmd.PersistentFlags().BoolVar(&initOptions.RemoteNodeDeploy, "remote-node-deploy", false, "Enable or disable deployment of FireFly contracts on remote nodes")
+	initCmd.PersistentFlags().StringArrayVar(&additionalBlockchains, "additional-blockchain", []string{}, "Add another blockchain to the stack, as a comma separated list of provider, node, connector, chain-id and remote-node-url settings. For example: provider=ethereum,node=geth,chain-id=2022")
+	initCmd.PersistentFlags().StringVar(&initOptions.ResourceProfile, "profile", "default", fmt.Sprintf("Preset resource limits and restart policies for the services in the stack. Options are: %v", fftypes.FFEnumValues(types.ResourceProfile)))
+	initCmd.PersistentFlags().StringToStringVar(&serviceCPUs, "service-cpus", map[string]string{}, fmt.Sprintf("CPU limit for each container of a service class, overriding --profile. For example: core=1.5. Service classes are: %v", fftypes.FFEnumValues(types.ServiceClass)))
+	initCmd.PersistentFlags().StringToStringVar(&serviceMemory, "service-memory", map[string]string{}, "Memory limit for each container of a service class, overriding --profile, as a number of bytes with an optional b, k, m or g unit. For example: blockchain=1G")
+	initCmd.PersistentFlags().StringToStringVar(&serviceRestart, "service-restart", map[string]string{}, fmt.Sprintf("Restart policy for the containers of a service class, overriding --profile. For example: core=unless-stopped. Policies are: %v", types.RestartPolicies))
+	initCmd.PersistentFlags().StringArrayVar(&serviceUlimits, "service-ulimit", []string{}, "Ulimit for the containers of a service class, as <class>:<name>=<soft>[:<hard>]. For example: blockchain:nofile=65535")
 	initCmd.PersistentFlags().StringToStringVar(&initOptions.EnvironmentVars, "environment-vars", map[string]string{}, "Common environment variables to set on all containers in FireFly stack")
+	initCmd.PersistentFlags().StringArrayVar(&serviceEnvironmentVars, "service-environment-vars", []string{}, "Environment variable to set on selected containers, as <service>:<name>=<value>. The service selects every container whose service name is or starts with <service>_, for example evmconnect or evmconnect_1")
 	rootCmd.AddCommand(initCmd)
 }
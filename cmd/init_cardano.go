@@ -45,7 +45,7 @@ var initCardanoCmd = &cobra.Command{
 			// stacks are enforced to have 1 member
 			args = append(args, "1")
 		}
-		if err := initCommon(args); err != nil {
+		if err := initCommon(cmd, args); err != nil {
 			return err
 		}
 		if err := stackManager.InitStack(&initOptions); err != nil {
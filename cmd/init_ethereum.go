@@ -47,7 +47,7 @@ var initEthereumCmd = &cobra.Command{
 		ctx = context.WithValue(ctx, docker.CtxComposeVersionKey{}, version)
 
 		stackManager := stacks.NewStackManager(ctx)
-		if err := initCommon(args); err != nil {
+		if err := initCommon(cmd, args); err != nil {
 			return err
 		}
 		if err := stackManager.InitStack(&initOptions); err != nil {
@@ -68,6 +68,8 @@ var initEthereumCmd = &cobra.Command{
 }
 
 func init() {
+	initEthereumCmd.Flags().StringVar(&initOptions.GethMode, "geth-mode", "clique", gethModeUsage)
+	initEthereumCmd.Flags().BoolVar(&initOptions.NodePerMember, "node-per-member", false, nodePerMemberUsage)
 	initEthereumCmd.Flags().IntVar(&initOptions.BlockPeriod, "block-period", -1, "Block period in seconds. Default is variable based on selected blockchain provider.")
 	initEthereumCmd.Flags().StringVar(&initOptions.ContractAddress, "contract-address", "", "Do not automatically deploy a contract, instead use a pre-configured address")
 	initEthereumCmd.Flags().StringVar(&initOptions.RemoteNodeURL, "remote-node-url", "", "For cases where the node is pre-existing and running remotely")
@@ -50,7 +50,7 @@ var initFabricCmd = &cobra.Command{
 		if err := validateFabricFlags(); err != nil {
 			return err
 		}
-		if err := initCommon(args); err != nil {
+		if err := initCommon(cmd, args); err != nil {
 			return err
 		}
 		if err := stackManager.InitStack(&initOptions); err != nil {
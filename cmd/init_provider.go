@@ -0,0 +1,76 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hyperledger/firefly-cli/internal/blockchain"
+	"github.com/hyperledger/firefly-cli/internal/log"
+	"github.com/hyperledger/firefly-cli/internal/stacks"
+)
+
+// addProviderInitCommands adds an init command for each registered blockchain provider that does not have one of
+// its own, such as the providers that are loaded as plugins. It runs once every init command has been added.
+func addProviderInitCommands() {
+	for _, name := range blockchain.Providers() {
+		if cmd, _, err := initCmd.Find([]string{name}); err == nil && cmd != initCmd {
+			continue
+		}
+		initCmd.AddCommand(newProviderInitCommand(name))
+	}
+}
+
+func newProviderInitCommand(name string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("%s [stack_name] [member_count]", name),
+		Short: fmt.Sprintf("Create a new FireFly local dev stack using the %s blockchain provider", name),
+		Long: fmt.Sprintf(`Create a new FireFly local dev stack using the %s blockchain provider. The provider writes its
+own config and docker compose services, and gets the --provider-option values to configure them.`, name),
+		Args: cobra.MaximumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := log.WithVerbosity(context.Background(), verbose)
+			ctx = log.WithLogger(ctx, logger)
+			stackManager := stacks.NewStackManager(ctx)
+			initOptions.BlockchainProvider = name
+			initOptions.BlockchainConnector = ""
+			initOptions.BlockchainNodeProvider = ""
+			// The token connectors that come with the CLI only work with ethereum
+			if !cmd.Flags().Changed("token-providers") {
+				initOptions.TokenProviders = []string{}
+			}
+			if err := initCommon(cmd, args); err != nil {
+				return err
+			}
+			if err := stackManager.InitStack(&initOptions); err != nil {
+				if err := stackManager.RemoveStack(); err != nil {
+					return err
+				}
+				return err
+			}
+			fmt.Printf("Stack '%s' created!\nTo start your new stack run:\n\n%s start %s\n", initOptions.StackName, rootCmd.Use, initOptions.StackName)
+			fmt.Printf("\nYour docker compose file for this stack can be found at: %s\n\n", filepath.Join(stackManager.Stack.StackDir, "docker-compose.yml"))
+			return nil
+		},
+	}
+	cmd.Flags().StringToStringVar(&initOptions.ProviderOptions, "provider-option", map[string]string{}, "An option for the blockchain provider, as key=value. Can be repeated")
+	return cmd
+}
@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/firefly-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCustomTokenProviders(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "contracts.json"), []byte(`{"contracts":{}}`), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "erc3643.yaml"), []byte("name: erc3643\nimage: example/tokens-erc3643\ncontracts: contracts.json\nfactoryContract: TREXFactory\n"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "builtin.yaml"), []byte("name: erc1155\nimage: example/tokens\n"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "noimage.yaml"), []byte("name: noimage\n"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "nofactory.yaml"), []byte("name: nofactory\nimage: example/tokens\ncontracts: contracts.json\n"), 0600))
+
+	providers, err := parseCustomTokenProviders([]string{filepath.Join(dir, "erc3643.yaml")})
+	assert.NoError(t, err)
+	assert.Len(t, providers, 1)
+	assert.Equal(t, filepath.Join(dir, "contracts.json"), providers[0].Contracts)
+	assert.NoError(t, validateTokensProvider([]string{"erc20_erc721", "erc3643"}, providers, "geth"))
+	assert.Error(t, validateTokensProvider([]string{"erc3643"}, nil, "geth"))
+
+	_, err = parseCustomTokenProviders([]string{filepath.Join(dir, "erc3643.yaml"), filepath.Join(dir, "erc3643.yaml")})
+	assert.Regexp(t, "set more than once", err)
+	_, err = parseCustomTokenProviders([]string{filepath.Join(dir, "builtin.yaml")})
+	assert.Regexp(t, "same name as a built in token provider", err)
+	_, err = parseCustomTokenProviders([]string{filepath.Join(dir, "noimage.yaml")})
+	assert.Regexp(t, "does not have an image", err)
+	_, err = parseCustomTokenProviders([]string{filepath.Join(dir, "nofactory.yaml")})
+	assert.Regexp(t, "must set both contracts and factoryContract", err)
+}
+
+func TestValidateConsensus(t *testing.T) {
+	assert.NoError(t, validateConsensus("clique", "geth"))
+	assert.NoError(t, validateConsensus("qbft", "besu"))
+	assert.NoError(t, validateConsensus("ibft", "besu"))
+	assert.NoError(t, validateConsensus("qbft", "quorum"))
+	assert.NoError(t, validateConsensus("raft", "quorum"))
+	assert.Regexp(t, "raft consensus is not supported by the besu blockchain node", validateConsensus("raft", "besu"))
+	assert.Regexp(t, "qbft consensus is not supported by the geth blockchain node", validateConsensus("qbft", "geth"))
+	assert.Error(t, validateConsensus("pow", "geth"))
+}
+
+func TestValidateDevChainID(t *testing.T) {
+	assert.NoError(t, validateDevChainID("dev", 2021, false))
+	assert.NoError(t, validateDevChainID("dev", 1337, true))
+	assert.NoError(t, validateDevChainID("clique", 2022, true))
+	assert.Regexp(t, "--chain-id 2022 is not supported with --geth-mode dev, which always has the chain ID 1337", validateDevChainID("dev", 2022, true))
+}
+
+func TestValidateNodePerMember(t *testing.T) {
+	assert.NoError(t, validateNodePerMember(false, "quorum", "clique"))
+	assert.NoError(t, validateNodePerMember(true, "geth", "clique"))
+	assert.NoError(t, validateNodePerMember(true, "besu", "clique"))
+	assert.Regexp(t, "requires the geth or besu blockchain node", validateNodePerMember(true, "quorum", "clique"))
+	assert.Regexp(t, "not supported with --geth-mode dev", validateNodePerMember(true, "geth", "dev"))
+}
+
+func TestValidateAuthMode(t *testing.T) {
+	assert.NoError(t, validateAuthMode("none", "fabric", "ethconnect"))
+	assert.NoError(t, validateAuthMode("basic", "ethereum", "evmconnect"))
+	assert.Regexp(t, "only supported for ethereum stacks using the evmconnect blockchain connector", validateAuthMode("basic", "ethereum", "ethconnect"))
+	assert.Regexp(t, "only supported for ethereum stacks using the evmconnect blockchain connector", validateAuthMode("basic", "fabric", "fabconnect"))
+	assert.Error(t, validateAuthMode("oauth", "ethereum", "evmconnect"))
+}
+
+func TestValidateBesuTracing(t *testing.T) {
+	besuChain := []*types.AdditionalBlockchainOptions{{BlockchainProvider: "ethereum", BlockchainNodeProvider: "besu"}}
+	assert.NoError(t, validateBesuTracing(false, "ethereum", "geth", nil))
+	assert.NoError(t, validateBesuTracing(true, "ethereum", "besu", nil))
+	assert.NoError(t, validateBesuTracing(true, "ethereum", "geth", besuChain))
+	assert.Regexp(t, "--besu-tracing requires the besu blockchain node", validateBesuTracing(true, "ethereum", "geth", nil))
+	assert.Regexp(t, "--besu-tracing requires the besu blockchain node", validateBesuTracing(true, "fabric", "besu", nil))
+}
+
+func TestParseAdditionalBlockchains(t *testing.T) {
+	savedOptions := initOptions
+	defer func() { initOptions = savedOptions }()
+
+	testCases := []struct {
+		Name     string
+		Provider string
+		AuthMode string
+		Specs    []string
+		Chains   []*types.AdditionalBlockchainOptions
+		Error    string
+	}{
+		{
+			Name:     "defaults",
+			Provider: "ethereum",
+			Specs:    []string{"provider=ethereum", "node=remote-rpc,remote-node-url=http://rpc:8545,connector=ethconnect,chain-id=7"},
+			Chains: []*types.AdditionalBlockchainOptions{
+				{BlockchainProvider: "ethereum", BlockchainNodeProvider: "geth", BlockchainConnector: "evmconnect", ChainID: 2023},
+				{BlockchainProvider: "ethereum", BlockchainNodeProvider: "remote-rpc", BlockchainConnector: "ethconnect", ChainID: 7, RemoteNodeURL: "http://rpc:8545"},
+			},
+		},
+		{
+			Name:     "fabric",
+			Provider: "ethereum",
+			Specs:    []string{"provider=fabric"},
+			Chains:   []*types.AdditionalBlockchainOptions{{BlockchainProvider: "fabric", BlockchainConnector: "fabric", ChainID: 2023}},
+		},
+		{
+			Name:     "second fabric network",
+			Provider: "fabric",
+			Specs:    []string{"provider=fabric"},
+			Error:    "a stack can only contain one fabric network",
+		},
+		{
+			Name:     "two fabric networks",
+			Provider: "ethereum",
+			Specs:    []string{"provider=fabric", "provider=fabric"},
+			Error:    "a stack can only contain one fabric network",
+		},
+		{
+			Name:     "remote rpc without url",
+			Provider: "ethereum",
+			Specs:    []string{"node=remote-rpc"},
+			Error:    "remote-node-url must be set for additional blockchain 'node=remote-rpc'",
+		},
+		{
+			Name:     "unsupported node",
+			Provider: "ethereum",
+			Specs:    []string{"node=besu"},
+			Error:    "can only use the geth or remote-rpc node",
+		},
+		{
+			Name:     "fabric node",
+			Provider: "ethereum",
+			Specs:    []string{"provider=fabric,node=geth"},
+			Error:    "node cannot be set for additional fabric blockchain",
+		},
+		{
+			Name:     "invalid pair",
+			Provider: "ethereum",
+			Specs:    []string{"ethereum"},
+			Error:    "expected a comma separated list of key=value pairs",
+		},
+		{
+			Name:     "unknown key",
+			Provider: "ethereum",
+			Specs:    []string{"network=mainnet"},
+			Error:    "unknown key 'network'",
+		},
+		{
+			Name:     "invalid chain id",
+			Provider: "ethereum",
+			Specs:    []string{"chain-id=one"},
+			Error:    "invalid chain-id 'one'",
+		},
+		{
+			Name:     "unsupported provider",
+			Provider: "ethereum",
+			Specs:    []string{"provider=tezos"},
+			Error:    "unsupported provider 'tezos'",
+		},
+		{
+			Name:     "too many",
+			Provider: "ethereum",
+			Specs:    make([]string, types.MaxAdditionalBlockchains+1),
+			Error:    "at most",
+		},
+		{
+			Name:     "basic auth with ethconnect",
+			Provider: "ethereum",
+			AuthMode: "basic",
+			Specs:    []string{"connector=ethconnect"},
+			Error:    "basic auth is currently only supported",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			initOptions.BlockchainProvider = tc.Provider
+			initOptions.ChainID = 2022
+			initOptions.AuthMode = "none"
+			if tc.AuthMode != "" {
+				initOptions.AuthMode = tc.AuthMode
+			}
+			chains, err := parseAdditionalBlockchains(tc.Specs)
+			if tc.Error != "" {
+				assert.Regexp(t, tc.Error, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.Chains, chains)
+			}
+		})
+	}
+}
+
+func TestParseServiceResources(t *testing.T) {
+	defer func() {
+		serviceCPUs, serviceMemory, serviceRestart, serviceUlimits = nil, nil, nil, nil
+	}()
+
+	serviceCPUs = map[string]string{"core": "1.5"}
+	serviceMemory = map[string]string{"core": "512M", "blockchain": "1.5g", "postgres": "268435456"}
+	serviceRestart = map[string]string{"core": "on-failure:3"}
+	serviceUlimits = []string{"blockchain:nofile=1024:2048"}
+	resources, err := parseServiceResources()
+	assert.NoError(t, err)
+	assert.Equal(t, &types.ServiceResources{CPUs: "1.5", Memory: "512M", Restart: "on-failure:3"}, resources["core"])
+	assert.Equal(t, &types.ServiceResources{Memory: "1.5g", Ulimits: map[string]*types.Ulimit{"nofile": {Soft: 1024, Hard: 2048}}}, resources["blockchain"])
+	assert.Equal(t, "268435456", resources["postgres"].Memory)
+
+	serviceCPUs, serviceRestart, serviceUlimits = nil, nil, nil
+	for _, memory := range []string{"512", "512b", "512k", "512KB", "1G", "0.5gb"} {
+		serviceMemory = map[string]string{"core": memory}
+		_, err := parseServiceResources()
+		assert.NoError(t, err, memory)
+	}
+	for _, memory := range []string{"lots", "1T", "512 M", "-1G", "1GiB", ""} {
+		serviceMemory = map[string]string{"core": memory}
+		_, err := parseServiceResources()
+		assert.Regexp(t, "--service-memory for service class 'core': invalid memory", err, memory)
+	}
+	serviceMemory = map[string]string{"database": "1G"}
+	_, err = parseServiceResources()
+	assert.Regexp(t, "invalid service class 'database' in --service-memory", err)
+
+	serviceMemory = nil
+	serviceCPUs = map[string]string{"core": "0"}
+	_, err = parseServiceResources()
+	assert.Regexp(t, "--service-cpus for service class 'core': invalid cpus '0'", err)
+	serviceCPUs = nil
+	serviceRestart = map[string]string{"core": "sometimes"}
+	_, err = parseServiceResources()
+	assert.Regexp(t, "--service-restart for service class 'core': invalid restart policy 'sometimes'", err)
+}
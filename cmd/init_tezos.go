@@ -46,7 +46,7 @@ var initTezosCmd = &cobra.Command{
 		if err := validateTezosFlags(); err != nil {
 			return err
 		}
-		if err := initCommon(args); err != nil {
+		if err := initCommon(cmd, args); err != nil {
 			return err
 		}
 		if err := stackManager.InitStack(&initOptions); err != nil {
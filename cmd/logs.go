@@ -19,24 +19,39 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/hyperledger/firefly-cli/internal/docker"
 	"github.com/hyperledger/firefly-cli/internal/log"
 	"github.com/hyperledger/firefly-cli/internal/stacks"
+	"github.com/hyperledger/firefly-cli/pkg/types"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/spf13/cobra"
 )
 
-var follow bool
+var logsOptions types.LogsOptions
 
 // logsCmd represents the logs command
 var logsCmd = &cobra.Command{
-	Use:               "logs <stack_name>",
+	Use:               "logs <stack_name> [service...]",
 	Short:             "View log output from a stack",
 	ValidArgsFunction: listStacks,
 	Long: `View log output from a stack.
 
 The most recent logs can be viewed, or you can follow the
-output with the -f flag.`,
+output with the -f flag.
+
+The logs can be limited to the services of selected members with --member, and to
+selected services by name, or by the start of their names such as evmconnect. Use
+--since, --until and --tail to limit them to a time window or the most recent lines.
+
+The --grep, --level, --output and --save options read the logs of each service
+separately with their timestamps. Unless following, the lines of every service are
+merged in time order. The --level option only filters the FireFly core logs.`,
+	Example: `  ff logs dev -f
+  ff logs dev --member 1 --since 10m
+  ff logs dev firefly_core evmconnect --level error --output jsonl
+  ff logs dev --since 2024-05-01T12:00:00Z --until 2024-05-01T12:05:00Z --save ./logs`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := log.WithVerbosity(context.Background(), verbose)
 		ctx = context.WithValue(ctx, docker.CtxIsLogCmdKey{}, true)
@@ -65,15 +80,9 @@ output with the -f flag.`,
 
 		if stackHasRunBefore {
 			fmt.Println("getting logs... ")
-			commandLine := []string{}
-			if fancyFeatures {
-				commandLine = append(commandLine, "--ansi", "always")
-			}
-			commandLine = append(commandLine, "-p", stackName, "logs")
-			if follow {
-				commandLine = append(commandLine, "-f")
-			}
-			if err := docker.RunDockerComposeCommand(ctx, stackManager.Stack.RuntimeDir, commandLine...); err != nil {
+			logsOptions.ANSI = fancyFeatures
+			logsOptions.Services = append(logsOptions.Services, args[1:]...)
+			if err := stackManager.Logs(&logsOptions); err != nil {
 				return err
 			}
 		} else {
@@ -85,5 +94,14 @@ output with the -f flag.`,
 
 func init() {
 	rootCmd.AddCommand(logsCmd)
-	logsCmd.Flags().BoolVarP(&follow, "follow", "f", false, "follow log output")
+	logsCmd.Flags().BoolVarP(&logsOptions.Follow, "follow", "f", false, "follow log output")
+	logsCmd.Flags().IntSliceVar(&logsOptions.Members, "member", nil, "Index of a member whose services to show logs for. Can be repeated")
+	logsCmd.Flags().StringArrayVar(&logsOptions.Services, "service", nil, "Name of a service to show logs for, or the start of the names of several services, such as evmconnect, which also matches the chain1_evmconnect services of additional blockchains. Can be repeated")
+	logsCmd.Flags().StringVar(&logsOptions.Since, "since", "", "Show logs since a timestamp (e.g. 2024-05-01T12:00:00Z) or relative time (e.g. 10m)")
+	logsCmd.Flags().StringVar(&logsOptions.Until, "until", "", "Show logs before a timestamp (e.g. 2024-05-01T12:05:00Z) or relative time (e.g. 5m)")
+	logsCmd.Flags().StringVar(&logsOptions.Tail, "tail", "", "Number of lines to show from the end of the logs of each service")
+	logsCmd.Flags().StringVar(&logsOptions.Grep, "grep", "", "Only show lines matching a regular expression")
+	logsCmd.Flags().StringVar(&logsOptions.Level, "level", "", fmt.Sprintf("Only show FireFly core lines at or above a log level. Options are: %s", strings.Join(stacks.LogLevels, ", ")))
+	logsCmd.Flags().StringVar(&logsOptions.Output, "output", "", fmt.Sprintf("Output format. Options are: %v", fftypes.FFEnumValues(types.LogsOutput)))
+	logsCmd.Flags().StringVar(&logsOptions.SaveDir, "save", "", "Write the logs of each service to a separate file in a directory, instead of printing them")
 }
@@ -0,0 +1,83 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hyperledger/firefly-cli/internal/docker"
+	"github.com/hyperledger/firefly-cli/internal/log"
+	"github.com/hyperledger/firefly-cli/internal/stacks"
+	"github.com/hyperledger/firefly-cli/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var addNamespaceOptions types.AddNamespaceOptions
+
+// namespacesAddCmd represents the "namespaces add" command
+var namespacesAddCmd = &cobra.Command{
+	Use:               "add <stack_name> <namespace_name>",
+	Short:             "Add a namespace to every member of a FireFly stack",
+	ValidArgsFunction: listStacks,
+	Long: `Add a namespace to every member of a FireFly stack. All namespaces share the stack's database, data
+exchange and shared storage plugins. A namespace uses the first blockchain in the stack, and all of the
+stack's token providers unless a subset is selected with --token-providers. Use --blockchain to put the
+namespace on one of the stack's additional blockchains instead, which does not have any token providers.
+
+If the stack has already been started it must be running. A new FireFly contract is deployed for the
+namespace if it is multiparty, and the FireFly core containers are restarted with the new config. Otherwise
+the namespace is set up the first time the stack is started.`,
+	Args: cobra.ExactArgs(2),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := log.WithVerbosity(context.Background(), verbose)
+		ctx = log.WithLogger(ctx, logger)
+
+		version, err := docker.CheckDockerConfig()
+		ctx = context.WithValue(ctx, docker.CtxComposeVersionKey{}, version)
+		cmd.SetContext(ctx)
+		return err
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stackName := args[0]
+		namespaceName := args[1]
+		stackManager := stacks.NewStackManager(cmd.Context())
+		if err := stackManager.LoadStack(stackName); err != nil {
+			return err
+		}
+		if !cmd.Flags().Changed("token-providers") {
+			addNamespaceOptions.TokenProviders = nil
+		}
+		messages, err := stackManager.AddNamespace(namespaceName, &addNamespaceOptions)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("namespace '%s' added to stack '%s'\n", namespaceName, stackName)
+		for _, message := range messages {
+			fmt.Printf("\n%s\n", message)
+		}
+		return nil
+	},
+}
+
+func init() {
+	namespacesAddCmd.Flags().BoolVar(&addNamespaceOptions.Multiparty, "multiparty", false, "Enable multiparty mode for the namespace, with its own FireFly contract")
+	namespacesAddCmd.Flags().StringArrayVarP(&addNamespaceOptions.TokenProviders, "token-providers", "t", nil, "Token providers of the stack to use in the namespace. Defaults to all of them")
+	namespacesAddCmd.Flags().IntVar(&addNamespaceOptions.Blockchain, "blockchain", 0, "Index of the blockchain in the stack to use for the namespace, where 0 is the blockchain the stack was created with")
+
+	namespacesCmd.AddCommand(namespacesAddCmd)
+}
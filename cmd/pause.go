@@ -0,0 +1,44 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// pauseCmd represents the pause command
+var pauseCmd = &cobra.Command{
+	Use:               "pause <stack_name> [service...]",
+	Short:             "Pause a stack, or selected members and services of it",
+	ValidArgsFunction: listStacks,
+	Long: `Pause a stack, or selected members and services of it.
+
+Services are selected by name, or by the start of their names, such as evmconnect for the
+connectors of every member. Use --member to select all of the services of a member.`,
+	Example: `  ff pause dev
+  ff pause dev --member 1
+  ff pause dev evmconnect_0`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runServiceCommand("pause", args)
+	},
+}
+
+func init() {
+	addServiceSelectionFlags(pauseCmd)
+	rootCmd.AddCommand(pauseCmd)
+}
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
@@ -67,8 +68,12 @@ commands to manage the lifecycle of stacks.
 
 To get started run: ` + ExecutableName + ` init
 Optional: Set FIREFLY_HOME env variable for FireFly stack configuration path.
+Optional: Set defaults for the flags of any command with ` + ExecutableName + ` config-cli set.
 	`,
-	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := applyConfigDefaults(cmd); err != nil {
+			return err
+		}
 		if ansi == "always" {
 			fancyFeatures = true
 		} else if ansi == "auto" && (isatty.IsTerminal(os.Stdout.Fd()) || isatty.IsCygwinTerminal(os.Stdout.Fd())) {
@@ -76,6 +81,7 @@ Optional: Set FIREFLY_HOME env variable for FireFly stack configuration path.
 		} else {
 			fancyFeatures = false
 		}
+		return nil
 	},
 	// Uncomment the following line if your bare application
 	// has an action associated with it:
@@ -87,6 +93,7 @@ Optional: Set FIREFLY_HOME env variable for FireFly stack configuration path.
 func Execute() {
 	rootCmd.PersistentFlags().StringVarP(&ansi, "ansi", "", "auto", "control when to print ANSI control characters (\"never\"|\"always\"|\"auto\")")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose log output")
+	addProviderInitCommands()
 	cobra.CheckErr(rootCmd.Execute())
 }
 
@@ -114,7 +121,10 @@ func initConfig() {
 		viper.SetConfigName(".firefly-cli")
 	}
 
-	viper.AutomaticEnv() // read in environment variables that match
+	// Read in environment variables that match, such as FF_INIT_DATABASE for init.database
+	viper.SetEnvPrefix("ff")
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_", "-", "_"))
+	viper.AutomaticEnv()
 
 	// If a config file is found, read it in.
 	if err := viper.ReadInConfig(); err != nil {
@@ -0,0 +1,56 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/firefly-cli/internal/log"
+	"github.com/hyperledger/firefly-cli/internal/stacks"
+	"github.com/spf13/cobra"
+)
+
+// secretsShowCmd represents the "secrets show" command
+var secretsShowCmd = &cobra.Command{
+	Use:               "show <stack_name>",
+	Short:             "Show the passwords and credentials generated for a FireFly stack",
+	Long:              `Show the passwords and credentials generated for a FireFly stack, as stored in its secrets.json file`,
+	ValidArgsFunction: listStacks,
+	Args:              cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := log.WithVerbosity(context.Background(), verbose)
+		ctx = log.WithLogger(ctx, logger)
+
+		stackName := args[0]
+		stackManager := stacks.NewStackManager(ctx)
+		if err := stackManager.LoadStack(stackName); err != nil {
+			return err
+		}
+		secrets, err := json.MarshalIndent(stackManager.Stack.Secrets, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s\n", string(secrets))
+		return nil
+	},
+}
+
+func init() {
+	secretsCmd.AddCommand(secretsShowCmd)
+}
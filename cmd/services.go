@@ -0,0 +1,77 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/firefly-cli/internal/docker"
+	"github.com/hyperledger/firefly-cli/internal/log"
+	"github.com/hyperledger/firefly-cli/internal/stacks"
+	"github.com/spf13/cobra"
+)
+
+var selectedMembers []int
+var selectedServices []string
+
+// addServiceSelectionFlags adds the flags for selecting the members and services of a stack that a command acts on
+func addServiceSelectionFlags(cmd *cobra.Command) {
+	cmd.Flags().IntSliceVar(&selectedMembers, "member", nil, "Index of a member whose services to act on. Can be repeated")
+	cmd.Flags().StringArrayVar(&selectedServices, "service", nil, "Name of a service to act on, or the start of the names of several services, such as evmconnect, which also matches the chain1_evmconnect services of additional blockchains. Can be repeated")
+}
+
+// hasServiceSelection returns true if the command was given any members or services to act on, as flags or as the
+// arguments after the stack name
+func hasServiceSelection(args []string) bool {
+	return len(selectedMembers) > 0 || len(selectedServices) > 0 || len(args) > 1
+}
+
+// runServiceCommand loads a stack and runs a docker compose command on the selected members and services, or on the
+// whole stack if none were selected
+func runServiceCommand(command string, args []string) error {
+	ctx := log.WithVerbosity(context.Background(), verbose)
+	ctx = log.WithLogger(ctx, logger)
+
+	version, err := docker.CheckDockerConfig()
+	if err != nil {
+		return err
+	}
+	ctx = context.WithValue(ctx, docker.CtxComposeVersionKey{}, version)
+
+	stackManager := stacks.NewStackManager(ctx)
+	stackName := args[0]
+	if err := stackManager.LoadStack(stackName); err != nil {
+		return err
+	}
+
+	var serviceNames []string
+	if hasServiceSelection(args) {
+		if serviceNames, err = stackManager.SelectServices(selectedMembers, append(selectedServices, args[1:]...)); err != nil {
+			return err
+		}
+		fmt.Printf("running %s on %s in stack '%s'... ", command, strings.Join(serviceNames, ", "), stackName)
+	} else {
+		fmt.Printf("running %s on stack '%s'... ", command, stackName)
+	}
+	if err := stackManager.RunServiceCommand(command, serviceNames); err != nil {
+		return err
+	}
+	fmt.Print("done\n")
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-cli/internal/docker"
+	"github.com/hyperledger/firefly-cli/internal/log"
+	"github.com/hyperledger/firefly-cli/internal/stacks"
+	"github.com/spf13/cobra"
+)
+
+// shellCmd represents the shell command
+var shellCmd = &cobra.Command{
+	Use:               "shell <stack_name> <shell> [member]",
+	Short:             "Open a console on a service of a stack",
+	ValidArgsFunction: listStacks,
+	Long: `Open a console on a service of a stack. The consoles are:
+
+  postgres      psql, connected to the database of a member (the first member by default)
+  geth          geth attach, connected to the geth node of the stack, or of a member (the first
+                member by default) if the stack runs a node per member. Use the service name,
+                such as geth_1 or chain1_geth, for a particular geth node
+  fabric-tools  a shell in a fabric-tools container, with the peer CLI set up as the admin
+                of the stack's organization`,
+	Example: `  ff shell dev postgres 1
+  ff shell dev geth
+  ff shell dev geth_1
+  ff shell dev fabric-tools`,
+	Args: cobra.RangeArgs(2, 3),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ctx := log.WithVerbosity(context.Background(), verbose)
+		ctx = log.WithLogger(ctx, logger)
+
+		version, err := docker.CheckDockerConfig()
+		ctx = context.WithValue(ctx, docker.CtxComposeVersionKey{}, version)
+		cmd.SetContext(ctx)
+		return err
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		memberIndex, err := parseMemberIndexArg(args[2:])
+		if err != nil {
+			return err
+		}
+		stackManager := stacks.NewStackManager(cmd.Context())
+		if err := stackManager.LoadStack(args[0]); err != nil {
+			return err
+		}
+		return stackManager.Shell(args[1], memberIndex)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(shellCmd)
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"time"
 
 	"github.com/briandowns/spinner"
@@ -33,14 +34,26 @@ import (
 var startOptions types.StartOptions
 
 var startCmd = &cobra.Command{
-	Use:               "start <stack_name>",
+	Use:               "start <stack_name> [service...]",
 	Short:             "Start a stack",
 	ValidArgsFunction: listStacks,
 	Long: `Start a stack
 
 This command will start a stack and run it in the background.
+
+Once a stack has been started, selected members and services that were stopped can be started
+again on their own. Services are selected by name, or by the start of their names, such as
+evmconnect for the connectors of every member. Use --member to select all of the services of
+a member.
 `,
+	Example: `  ff start dev
+  ff start dev --member 1
+  ff start dev --service evmconnect_0`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 0 && hasServiceSelection(args) {
+			return runServiceCommand("start", args)
+		}
+
 		var spin *spinner.Spinner
 		if fancyFeatures && !verbose {
 			spin = spinner.New(spinner.CharSets[11], 100*time.Millisecond)
@@ -86,8 +99,8 @@ This command will start a stack and run it in the background.
 			fmt.Printf("%s\n\n", message)
 		}
 		for _, member := range stackManager.Stack.Members {
-			fmt.Printf("Web UI for member '%v': http://127.0.0.1:%v/ui\n", member.ID, member.ExposedFireflyPort)
-			fmt.Printf("Swagger API UI for member '%v': http://127.0.0.1:%v/api\n", member.ID, member.ExposedFireflyPort)
+			fmt.Printf("Web UI for member '%v': %s://127.0.0.1:%v/ui\n", member.ID, stackManager.Stack.HTTPScheme(), member.ExposedFireflyPort)
+			fmt.Printf("Swagger API UI for member '%v': %s://127.0.0.1:%v/api\n", member.ID, stackManager.Stack.HTTPScheme(), member.ExposedFireflyPort)
 			if stackManager.Stack.SandboxEnabled {
 				fmt.Printf("Sandbox UI for member '%v': http://127.0.0.1:%v\n\n", member.ID, member.ExposedSandboxPort)
 			}
@@ -97,6 +110,26 @@ This command will start a stack and run it in the background.
 			fmt.Printf("Web UI for shared Prometheus: http://127.0.0.1:%v\n", stackManager.Stack.ExposedPrometheusPort)
 		}
 
+		if stackManager.Stack.BesuTracingEnabled {
+			fmt.Printf("Jaeger UI for besu traces: http://127.0.0.1:%v\n", stackManager.Stack.ExposedJaegerPort)
+		}
+
+		if stackManager.Stack.LogAggregation.Equals(types.LogAggregationLoki) {
+			fmt.Printf("Loki API for the stack's logs: http://127.0.0.1:%v\n", stackManager.Stack.ExposedLokiPort)
+		}
+
+		if stackManager.Stack.GrafanaEnabled {
+			fmt.Printf("Grafana dashboards: http://127.0.0.1:%v (the admin password is in %s)\n", stackManager.Stack.ExposedGrafanaPort, filepath.Join(stackManager.Stack.StackDir, "secrets.json"))
+		}
+
+		if stackManager.Stack.AuthMode.Equals(types.AuthModeBasic) {
+			fmt.Printf("\nThe APIs require basic auth. The credentials for each member can be found in:\n\n%s\n", filepath.Join(stackManager.Stack.StackDir, "secrets.json"))
+		}
+
+		if stackManager.Stack.TLSEnabled {
+			fmt.Printf("\nThe APIs are served over HTTPS with certificates signed by the stack CA at:\n\n%s\n", filepath.Join(stackManager.Stack.InitDir, "config", "tls", "ca.pem"))
+		}
+
 		fmt.Printf("\nTo see logs for your stack run:\n\n%s logs %s\n\n", rootCmd.Use, stackName)
 		return nil
 	},
@@ -104,5 +137,6 @@ This command will start a stack and run it in the background.
 
 func init() {
 	startCmd.Flags().BoolVarP(&startOptions.NoRollback, "no-rollback", "b", false, "Do not automatically rollback changes if first time setup fails")
+	addServiceSelectionFlags(startCmd)
 	rootCmd.AddCommand(startCmd)
 }
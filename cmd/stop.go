@@ -28,11 +28,22 @@ import (
 
 // stopCmd represents the stop command
 var stopCmd = &cobra.Command{
-	Use:               "stop <stack_name>",
+	Use:               "stop <stack_name> [service...]",
 	Short:             "Stop a stack",
-	Long:              `Stop a stack`,
 	ValidArgsFunction: listStacks,
+	Long: `Stop a stack, or selected members and services of it
+
+Services are selected by name, or by the start of their names, such as evmconnect for the
+connectors of every member. Use --member to select all of the services of a member, for
+example to take its organization offline.`,
+	Example: `  ff stop dev
+  ff stop dev --member 1
+  ff stop dev evmconnect_0`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 0 && hasServiceSelection(args) {
+			return runServiceCommand("stop", args)
+		}
+
 		ctx := log.WithVerbosity(context.Background(), verbose)
 		ctx = log.WithLogger(ctx, logger)
 
@@ -62,5 +73,6 @@ var stopCmd = &cobra.Command{
 }
 
 func init() {
+	addServiceSelectionFlags(stopCmd)
 	rootCmd.AddCommand(stopCmd)
 }
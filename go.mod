@@ -26,6 +26,7 @@ require (
 	github.com/briandowns/spinner v1.23.0
 	github.com/btcsuite/btcd/btcec/v2 v2.3.2
 	github.com/google/go-containerregistry v0.17.0
+	github.com/gorilla/websocket v1.5.3
 	github.com/hyperledger/firefly-common v1.4.10
 	github.com/hyperledger/firefly-signer v1.1.15
 	github.com/jarcoal/httpmock v1.3.1
@@ -34,6 +35,7 @@ require (
 	github.com/mitchellh/go-homedir v1.1.0
 	github.com/otiai10/copy v1.7.0
 	github.com/spf13/cobra v1.8.0
+	github.com/spf13/pflag v1.0.5
 	github.com/spf13/viper v1.18.2
 	github.com/stretchr/testify v1.9.0
 	golang.org/x/crypto v0.21.0
@@ -96,7 +98,6 @@ require (
 	github.com/sourcegraph/conc v0.3.0 // indirect
 	github.com/spf13/afero v1.11.0 // indirect
 	github.com/spf13/cast v1.6.0 // indirect
-	github.com/spf13/pflag v1.0.5 // indirect
 	github.com/subosito/gotenv v1.6.0 // indirect
 	github.com/tyler-smith/go-bip39 v1.1.0 // indirect
 	github.com/vbatts/tar-split v0.11.3 // indirect
@@ -75,7 +75,7 @@ type ConfirmationsConfig struct {
 	Required *int `yaml:"required,omitempty"`
 }
 
-func (c *Config) WriteConfig(filename string, extraConnectorConfigPath string) error {
+func (c *Config) WriteConfig(filename string, extraConnectorConfigPaths ...string) error {
 	configYamlBytes, err := yaml.Marshal(c)
 	if err != nil {
 		return err
@@ -87,8 +87,8 @@ func (c *Config) WriteConfig(filename string, extraConnectorConfigPath string) e
 	if err := os.WriteFile(filename, configYamlBytes, 0755); err != nil {
 		return err
 	}
-	if extraConnectorConfigPath != "" {
-		c, err := conflate.FromFiles(filename, extraConnectorConfigPath)
+	if len(extraConnectorConfigPaths) > 0 {
+		c, err := conflate.FromFiles(append([]string{filename}, extraConnectorConfigPaths...)...)
 		if err != nil {
 			return err
 		}
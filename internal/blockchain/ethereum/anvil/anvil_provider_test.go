@@ -0,0 +1,66 @@
+package anvil
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/hyperledger/firefly-cli/internal/blockchain/ethereum"
+	"github.com/hyperledger/firefly-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestStack() *types.Stack {
+	return &types.Stack{
+		Name: "anvil_stack",
+		Members: []*types.Organization{
+			{
+				ID:       "0",
+				OrgName:  "Org1",
+				NodeName: "node_0",
+				Account: &ethereum.Account{
+					Address:    "0x1234567890abcdef0123456789abcdef6789abcd",
+					PrivateKey: "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff",
+				},
+			},
+		},
+		BlockchainProvider:     types.BlockchainProviderEthereum,
+		BlockchainConnector:    types.BlockchainConnectorEvmconnect,
+		BlockchainNodeProvider: types.BlockchainNodeProviderAnvil,
+		ExposedBlockchainPort:  5100,
+		VersionManifest: &types.VersionManifest{
+			Evmconnect: &types.ManifestEntry{Image: "ghcr.io/hyperledger/firefly-evmconnect", Tag: "v1.3.0"},
+		},
+	}
+}
+
+func TestGetDockerServiceDefinitions(t *testing.T) {
+	stack := newTestStack()
+	p := NewAnvilProvider(context.Background(), stack)
+	serviceDefinitions := p.GetDockerServiceDefinitions()
+	assert.Equal(t, "anvil", serviceDefinitions[0].ServiceName)
+	assert.Equal(t, []string{"anvil"}, serviceDefinitions[0].Service.EntryPoint)
+	assert.Contains(t, serviceDefinitions[0].Service.Command, "--auto-impersonate")
+	assert.Contains(t, serviceDefinitions[0].Service.Command, "--state /data/state.json")
+	assert.NotContains(t, serviceDefinitions[0].Service.Command, "--block-time")
+	assert.Equal(t, []string{"5100:8545"}, serviceDefinitions[0].Service.Ports)
+	assert.Equal(t, map[string]string{"condition": "service_started"}, serviceDefinitions[1].Service.DependsOn["anvil"])
+
+	stack.BlockPeriod = 2
+	serviceDefinitions = p.GetDockerServiceDefinitions()
+	assert.Contains(t, serviceDefinitions[0].Service.Command, "--block-time 2")
+}
+
+func TestCreateAccount(t *testing.T) {
+	stack := newTestStack()
+	stack.InitDir = t.TempDir()
+	stack.RuntimeDir = t.TempDir()
+	p := NewAnvilProvider(context.Background(), stack)
+	account, err := p.CreateAccount([]string{})
+	assert.NoError(t, err)
+	ethAccount, ok := account.(*ethereum.Account)
+	assert.True(t, ok)
+	assert.Regexp(t, "^0x[0-9a-f]{40}$", ethAccount.Address)
+	_, err = hex.DecodeString(ethAccount.PrivateKey)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,137 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anvil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// AnvilClient calls the dev RPC methods of an anvil node, which are not part of the standard Ethereum JSON-RPC API
+type AnvilClient struct {
+	rpcURL string
+}
+
+type JSONRPCRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      int           `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type JSONRPCResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      int             `json:"id"`
+	Error   *JSONRPCError   `json:"error,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+}
+
+type JSONRPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func NewAnvilClient(rpcURL string) *AnvilClient {
+	return &AnvilClient{
+		rpcURL: rpcURL,
+	}
+}
+
+// Mine mines a number of blocks straight away, whether the node mines on every transaction or on an interval
+func (a *AnvilClient) Mine(blocks int) error {
+	if blocks <= 1 {
+		return a.call("evm_mine", nil)
+	}
+	return a.call("anvil_mine", nil, fmt.Sprintf("0x%x", blocks))
+}
+
+// IncreaseTime moves the time of the chain forward by a number of seconds, and mines a block with the new time so
+// it is seen by the contracts straight away. It returns the total number of seconds the time has been moved forward.
+func (a *AnvilClient) IncreaseTime(seconds int64) (int64, error) {
+	var offset json.RawMessage
+	if err := a.call("evm_increaseTime", &offset, seconds); err != nil {
+		return 0, err
+	}
+	if err := a.Mine(1); err != nil {
+		return 0, err
+	}
+	return parseQuantity(offset)
+}
+
+// parseQuantity parses a number that is returned as a JSON number, as a decimal string, or as a hex string
+func parseQuantity(raw json.RawMessage) (int64, error) {
+	s := strings.Trim(string(raw), `"`)
+	if strings.HasPrefix(s, "0x") {
+		return strconv.ParseInt(s[2:], 16, 64)
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
+
+// SetBalance sets the balance of an account in wei
+func (a *AnvilClient) SetBalance(address string, wei *big.Int) error {
+	return a.call("anvil_setBalance", nil, address, fmt.Sprintf("0x%s", wei.Text(16)))
+}
+
+func (a *AnvilClient) call(method string, result interface{}, params ...interface{}) error {
+	if params == nil {
+		params = []interface{}{}
+	}
+	requestBody, err := json.Marshal(&JSONRPCRequest{
+		JSONRPC: "2.0",
+		ID:      0,
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", a.rpcURL, bytes.NewBuffer(requestBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("%s [%d] %s", req.URL, resp.StatusCode, responseBody)
+	}
+	var rpcResponse *JSONRPCResponse
+	if err := json.Unmarshal(responseBody, &rpcResponse); err != nil {
+		return err
+	}
+	if rpcResponse.Error != nil {
+		return fmt.Errorf("%s", rpcResponse.Error.Message)
+	}
+	if result != nil {
+		return json.Unmarshal(rpcResponse.Result, result)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package anvil
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const rpcURL = "http://127.0.0.1:8545"
+
+func mockRPC(t *testing.T, results map[string]string) *[]*JSONRPCRequest {
+	requests := []*JSONRPCRequest{}
+	httpmock.RegisterResponder("POST", rpcURL, func(req *http.Request) (*http.Response, error) {
+		var rpcRequest *JSONRPCRequest
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&rpcRequest))
+		requests = append(requests, rpcRequest)
+		result, ok := results[rpcRequest.Method]
+		if !ok {
+			return httpmock.NewStringResponse(200, `{"jsonrpc":"2.0","id":0,"error":{"code":-32601,"message":"Method not found"}}`), nil
+		}
+		return httpmock.NewStringResponse(200, `{"jsonrpc":"2.0","id":0,"result":`+result+`}`), nil
+	})
+	return &requests
+}
+
+func TestMine(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	requests := mockRPC(t, map[string]string{"evm_mine": `"0x0"`, "anvil_mine": "null"})
+
+	client := NewAnvilClient(rpcURL)
+	assert.NoError(t, client.Mine(1))
+	assert.NoError(t, client.Mine(16))
+	assert.Equal(t, "evm_mine", (*requests)[0].Method)
+	assert.Equal(t, "anvil_mine", (*requests)[1].Method)
+	assert.Equal(t, []interface{}{"0x10"}, (*requests)[1].Params)
+}
+
+func TestIncreaseTime(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	requests := mockRPC(t, map[string]string{"evm_increaseTime": "7200", "evm_mine": `"0x0"`})
+
+	offset, err := NewAnvilClient(rpcURL).IncreaseTime(3600)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7200), offset)
+	assert.Equal(t, []interface{}{float64(3600)}, (*requests)[0].Params)
+	assert.Equal(t, "evm_mine", (*requests)[1].Method)
+}
+
+func TestSetBalance(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	requests := mockRPC(t, map[string]string{"anvil_setBalance": "null"})
+
+	assert.NoError(t, NewAnvilClient(rpcURL).SetBalance("0x1234567890abcdef0123456789abcdef6789abcd", big.NewInt(1000000)))
+	assert.Equal(t, []interface{}{"0x1234567890abcdef0123456789abcdef6789abcd", "0xf4240"}, (*requests)[0].Params)
+}
+
+func TestCallError(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	mockRPC(t, map[string]string{})
+
+	assert.Regexp(t, "Method not found", NewAnvilClient(rpcURL).Mine(1))
+}
+
+func TestParseQuantity(t *testing.T) {
+	for raw, expected := range map[string]int64{`60`: 60, `"60"`: 60, `"0x3c"`: 60} {
+		value, err := parseQuantity(json.RawMessage(raw))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value)
+	}
+}
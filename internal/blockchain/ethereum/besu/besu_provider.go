@@ -18,10 +18,12 @@ package besu
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path"
 	"path/filepath"
+	"strconv"
 
 	"github.com/hyperledger/firefly-cli/internal/blockchain/ethereum"
 	"github.com/hyperledger/firefly-cli/internal/blockchain/ethereum/connector"
@@ -34,6 +36,7 @@ import (
 )
 
 var besuImage = "hyperledger/besu:22.4"
+var besuP2PPort = 30303
 
 type BesuProvider struct {
 	ctx       context.Context
@@ -46,9 +49,9 @@ func NewBesuProvider(ctx context.Context, stack *types.Stack) *BesuProvider {
 	var connector connector.Connector
 	switch stack.BlockchainConnector {
 	case types.BlockchainConnectorEthconnect:
-		connector = ethconnect.NewEthconnect(ctx)
+		connector = ethconnect.NewEthconnect(ctx, stack)
 	case types.BlockchainConnectorEvmconnect:
-		connector = evmconnect.NewEvmconnect(ctx)
+		connector = evmconnect.NewEvmconnect(ctx, stack)
 	}
 
 	return &BesuProvider{
@@ -60,21 +63,32 @@ func NewBesuProvider(ctx context.Context, stack *types.Stack) *BesuProvider {
 }
 
 func (p *BesuProvider) WriteConfig(options *types.InitOptions) error {
-	if err := p.signer.WriteConfig(options, "http://besu:8545"); err != nil {
-		return err
+	if !p.stack.NodePerMember {
+		if err := p.signer.WriteConfig(options, p.rpcURL(0)); err != nil {
+			return err
+		}
 	}
 
 	initDir := filepath.Join(constants.StacksDir, p.stack.Name, "init")
 	for i, member := range p.stack.Members {
+		if p.stack.NodePerMember {
+			if err := p.signerFor(i).WriteConfig(options, p.rpcURL(i)); err != nil {
+				return err
+			}
+		}
 
 		// Generate the connector config for each member
 		connectorConfigPath := filepath.Join(initDir, "config", fmt.Sprintf("%s_%v.yaml", p.connector.Name(), i))
-		if err := p.connector.GenerateConfig(p.stack, member, "ethsigner").WriteConfig(connectorConfigPath, options.ExtraConnectorConfigPath); err != nil {
+		if err := p.connector.GenerateConfig(p.stack, member, p.signerFor(i).Name()).WriteConfig(connectorConfigPath, options.ConnectorConfigPaths(i)...); err != nil {
 			return nil
 		}
 
 	}
 
+	if p.hasMemberNodes() {
+		return p.writeMemberNodesConfig(options, initDir)
+	}
+
 	// Create genesis.json
 	// Generate node key
 	nodeAddress, nodeKey := ethereum.GenerateAddressAndPrivateKey()
@@ -91,12 +105,84 @@ func (p *BesuProvider) WriteConfig(options *types.InitOptions) error {
 	return nil
 }
 
+// writeMemberNodesConfig generates a node key for each member's node, the static nodes file the nodes use to find
+// each other, and a genesis.json with every node as a Clique signer or BFT validator
+func (p *BesuProvider) writeMemberNodesConfig(options *types.InitOptions, initDir string) error {
+	validators := make([]string, len(p.stack.Members))
+	staticNodes := make([]string, len(p.stack.Members))
+	for i := range p.stack.Members {
+		nodeKey := ethereum.GenerateNodeKey()
+		nodeDir := filepath.Join(initDir, "blockchain", p.nodeName(i))
+		if err := os.MkdirAll(nodeDir, 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(nodeDir, "nodeKey"), []byte(nodeKey.PrivateKey), 0755); err != nil {
+			return err
+		}
+		validators[i] = nodeKey.Address[2:]
+		staticNodes[i] = fmt.Sprintf("enode://%s@%s:%d", nodeKey.PublicKey, p.stack.ServiceName(p.nodeName(i)), besuP2PPort)
+	}
+
+	staticNodesBytes, _ := json.MarshalIndent(staticNodes, "", " ")
+	if err := os.WriteFile(filepath.Join(initDir, "blockchain", "static-nodes.json"), staticNodesBytes, 0755); err != nil {
+		return err
+	}
+
+	genesis := CreateGenesis(validators, options.BlockPeriod, p.stack.ChainID())
+	genesis.ExtraData = ethereum.CreateCliqueExtraData(validators)
+	if p.isBFT() {
+		genesis = CreateBFTGenesis(validators, options.BlockPeriod, p.stack.ChainID(), p.stack.Consensus)
+	}
+	return genesis.WriteGenesisJSON(filepath.Join(initDir, "blockchain", "genesis.json"))
+}
+
+func (p *BesuProvider) isBFT() bool {
+	return p.stack.Consensus.Equals(types.ConsensusQbft) || p.stack.Consensus.Equals(types.ConsensusIbft)
+}
+
+// hasMemberNodes is true if the stack runs a node per member, which the BFT consensus algorithms always do
+// as each member runs a validator
+func (p *BesuProvider) hasMemberNodes() bool {
+	return p.stack.NodePerMember || p.isBFT()
+}
+
+func (p *BesuProvider) nodeName(memberIndex int) string {
+	return fmt.Sprintf("besu_%d", memberIndex)
+}
+
+// signerFor returns the signer a member's connector sends transactions to, which is the member's own signer
+// in front of their own node if the stack runs a node per member
+func (p *BesuProvider) signerFor(memberIndex int) *ethsigner.EthSignerProvider {
+	if p.stack.NodePerMember {
+		return ethsigner.NewMemberEthSignerProvider(p.ctx, p.stack, memberIndex)
+	}
+	return p.signer
+}
+
+// rpcURL is the node that a member's signer sends transactions to
+func (p *BesuProvider) rpcURL(memberIndex int) string {
+	switch {
+	case p.stack.NodePerMember:
+		return fmt.Sprintf("http://%s:8545", p.stack.ServiceName(p.nodeName(memberIndex)))
+	case p.isBFT():
+		return fmt.Sprintf("http://%s:8545", p.stack.ServiceName(p.nodeName(0)))
+	default:
+		return "http://besu:8545"
+	}
+}
+
 func (p *BesuProvider) FirstTimeSetup() error {
 	besuVolumeName := fmt.Sprintf("%s_besu", p.stack.Name)
 	blockchainDir := filepath.Join(p.stack.RuntimeDir, "blockchain")
 	contractsDir := filepath.Join(p.stack.RuntimeDir, "contracts")
 
-	if err := p.signer.FirstTimeSetup(); err != nil {
+	if p.stack.NodePerMember {
+		for i := range p.stack.Members {
+			if err := p.signerFor(i).FirstTimeSetup(); err != nil {
+				return err
+			}
+		}
+	} else if err := p.signer.FirstTimeSetup(); err != nil {
 		return err
 	}
 
@@ -104,10 +190,6 @@ func (p *BesuProvider) FirstTimeSetup() error {
 		return err
 	}
 
-	if err := docker.CreateVolume(p.ctx, besuVolumeName); err != nil {
-		return err
-	}
-
 	if err := os.MkdirAll(contractsDir, 0755); err != nil {
 		return err
 	}
@@ -121,17 +203,34 @@ func (p *BesuProvider) FirstTimeSetup() error {
 		}
 	}
 
-	// Copy the genesis block information
-	if err := docker.CopyFileToVolume(p.ctx, besuVolumeName, path.Join(blockchainDir, "genesis.json"), "genesis.json"); err != nil {
+	if p.hasMemberNodes() {
+		for i := range p.stack.Members {
+			nodeVolumeName := fmt.Sprintf("%s_%s", p.stack.Name, p.nodeName(i))
+			if err := p.copyNodeFilesToVolume(nodeVolumeName, path.Join(blockchainDir, p.nodeName(i), "nodeKey")); err != nil {
+				return err
+			}
+			if err := docker.CopyFileToVolume(p.ctx, nodeVolumeName, path.Join(blockchainDir, "static-nodes.json"), "static-nodes.json"); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return p.copyNodeFilesToVolume(besuVolumeName, path.Join(blockchainDir, "nodeKey"))
+}
+
+func (p *BesuProvider) copyNodeFilesToVolume(volumeName, nodeKeyPath string) error {
+	if err := docker.CreateVolume(p.ctx, volumeName); err != nil {
 		return err
 	}
 
-	// Copy the node key
-	if err := docker.CopyFileToVolume(p.ctx, besuVolumeName, path.Join(blockchainDir, "nodeKey"), "nodeKey"); err != nil {
+	// Copy the genesis block information
+	if err := docker.CopyFileToVolume(p.ctx, volumeName, path.Join(p.stack.RuntimeDir, "blockchain", "genesis.json"), "genesis.json"); err != nil {
 		return err
 	}
 
-	return nil
+	// Copy the node key
+	return docker.CopyFileToVolume(p.ctx, volumeName, nodeKeyPath, "nodeKey")
 }
 
 func (p *BesuProvider) PreStart() error {
@@ -151,36 +250,82 @@ func (p *BesuProvider) DeployFireFlyContract() (*types.ContractDeploymentResult,
 }
 
 func (p *BesuProvider) GetDockerServiceDefinitions() []*docker.ServiceDefinition {
-	addresses := ""
-	for i, member := range p.stack.Members {
-		account := member.Account.(*ethereum.Account)
-		addresses += account.Address
-		if i+1 < len(p.stack.Members) {
-			addresses += ","
+	rpcAPI := "CLIQUE"
+	switch {
+	case p.stack.Consensus.Equals(types.ConsensusQbft):
+		rpcAPI = "QBFT"
+	case p.stack.Consensus.Equals(types.ConsensusIbft):
+		rpcAPI = "IBFT"
+	}
+	besuCommand := fmt.Sprintf(`--genesis-file=/data/genesis.json --network-id %d --rpc-http-enabled --rpc-http-api=ETH,NET,%s --host-allowlist="*" --rpc-http-cors-origins="all" --sync-mode=FULL --discovery-enabled=false --node-private-key-file=/data/nodeKey --min-gas-price=0`, p.stack.ChainID(), rpcAPI)
+	switch {
+	case p.stack.BesuTracingEnabled:
+		// Besu only exports traces when its metrics are pushed over OpenTelemetry, which replaces the Prometheus endpoint
+		besuCommand += " --metrics-enabled --metrics-protocol=opentelemetry"
+	case p.stack.PrometheusEnabled:
+		besuCommand += fmt.Sprintf(" --metrics-enabled --metrics-host=0.0.0.0 --metrics-port=%d", constants.BesuMetricsPort)
+	}
+
+	if !p.hasMemberNodes() {
+		serviceDefinitions := []*docker.ServiceDefinition{
+			p.getNodeServiceDefinition("besu", besuCommand),
+			p.signer.GetDockerServiceDefinition(p.rpcURL(0)),
 		}
+		return append(serviceDefinitions, p.connector.GetServiceDefinitions(p.stack, map[string]string{"ethsigner": "service_healthy"})...)
 	}
-	besuCommand := fmt.Sprintf(`--genesis-file=/data/genesis.json --network-id %d --rpc-http-enabled --rpc-http-api=ETH,NET,CLIQUE --host-allowlist="*" --rpc-http-cors-origins="all" --sync-mode=FULL --discovery-enabled=false --node-private-key-file=/data/nodeKey --min-gas-price=0`, p.stack.ChainID())
 
-	serviceDefinitions := make([]*docker.ServiceDefinition, 2)
-	serviceDefinitions[0] = &docker.ServiceDefinition{
-		ServiceName: "besu",
+	// The nodes connect to each other by service name, as discovery is disabled
+	besuCommand += fmt.Sprintf(" --p2p-port=%d --static-nodes-file=/data/static-nodes.json --Xdns-enabled=true --Xdns-update-enabled=true", besuP2PPort)
+	serviceDefinitions := make([]*docker.ServiceDefinition, 0, len(p.stack.Members)*2)
+	for i := range p.stack.Members {
+		serviceDefinitions = append(serviceDefinitions, p.getNodeServiceDefinition(p.nodeName(i), besuCommand))
+	}
+	signers := []*ethsigner.EthSignerProvider{p.signer}
+	if p.stack.NodePerMember {
+		signers = make([]*ethsigner.EthSignerProvider, len(p.stack.Members))
+		for i := range p.stack.Members {
+			signers[i] = p.signerFor(i)
+		}
+	}
+	connectorDependents := map[string]string{}
+	for i, signer := range signers {
+		signerServiceDefinition := signer.GetDockerServiceDefinition(p.rpcURL(i))
+		signerServiceDefinition.Service.DependsOn = map[string]map[string]string{p.nodeName(i): {"condition": "service_started"}}
+		serviceDefinitions = append(serviceDefinitions, signerServiceDefinition)
+		connectorDependents[signer.Name()] = "service_healthy"
+	}
+	return append(serviceDefinitions, p.connector.GetServiceDefinitions(p.stack, connectorDependents)...)
+}
+
+func (p *BesuProvider) getNodeServiceDefinition(serviceName, besuCommand string) *docker.ServiceDefinition {
+	return &docker.ServiceDefinition{
+		ServiceName: serviceName,
 		Service: &docker.Service{
 			Image:         besuImage,
-			ContainerName: fmt.Sprintf("%s_besu", p.stack.Name),
+			ContainerName: fmt.Sprintf("%s_%s", p.stack.Name, serviceName),
 			User:          "root",
 			Command:       besuCommand,
 			Volumes: []string{
-				"besu:/data",
+				fmt.Sprintf("%s:/data", serviceName),
 			},
 			Logging:     docker.StandardLogOptions,
-			Environment: p.stack.EnvironmentVars,
+			Environment: p.nodeEnvironmentVars(serviceName),
 		},
 
-		VolumeNames: []string{"besu"},
+		VolumeNames: []string{serviceName},
 	}
-	serviceDefinitions[1] = p.signer.GetDockerServiceDefinition("http://besu:8545")
-	serviceDefinitions = append(serviceDefinitions, p.connector.GetServiceDefinitions(p.stack, map[string]string{"ethsigner": "service_healthy"})...)
-	return serviceDefinitions
+}
+
+// nodeEnvironmentVars configures a node to export its traces to the OpenTelemetry collector of the stack, if
+// tracing is enabled
+func (p *BesuProvider) nodeEnvironmentVars(serviceName string) map[string]interface{} {
+	if !p.stack.BesuTracingEnabled {
+		return p.stack.EnvironmentVars
+	}
+	return p.stack.ConcatenateWithProvidedEnvironmentVars(map[string]interface{}{
+		"OTEL_EXPORTER_OTLP_ENDPOINT": constants.OTelCollectorEndpoint,
+		"OTEL_RESOURCE_ATTRIBUTES":    fmt.Sprintf("service.name=%s", p.stack.ServiceName(serviceName)),
+	})
 }
 
 func (p *BesuProvider) GetBlockchainPluginConfig(stack *types.Stack, m *types.Organization) (blockchainConfig *types.BlockchainConfig) {
@@ -239,7 +384,17 @@ func (p *BesuProvider) DeployContract(filename, contractName, instanceName strin
 }
 
 func (p *BesuProvider) CreateAccount(args []string) (interface{}, error) {
-	return p.signer.CreateAccount(args)
+	// Each member's keys are in their own signer if the stack runs a node per member, which is the first member's
+	// signer if no member index is given
+	memberIndex := 0
+	if p.stack.NodePerMember && len(args) > 2 {
+		i, err := strconv.Atoi(args[2])
+		if err != nil || i < 0 || i >= len(p.stack.Members) {
+			return nil, fmt.Errorf("invalid member index '%s'", args[2])
+		}
+		memberIndex = i
+	}
+	return p.signerFor(memberIndex).CreateAccount(args)
 }
 
 func (p *BesuProvider) ParseAccount(account interface{}) interface{} {
@@ -255,9 +410,9 @@ func (p *BesuProvider) GetConnectorName() string {
 }
 
 func (p *BesuProvider) GetConnectorURL(org *types.Organization) string {
-	return fmt.Sprintf("http://%s_%s:%v", p.connector.Name(), org.ID, p.connector.Port())
+	return fmt.Sprintf("%s://%s_%s:%v", p.stack.HTTPScheme(), p.connector.Name(), org.ID, p.connector.Port())
 }
 
 func (p *BesuProvider) GetConnectorExternalURL(org *types.Organization) string {
-	return fmt.Sprintf("http://127.0.0.1:%v", org.ExposedConnectorPort)
+	return fmt.Sprintf("%s://127.0.0.1:%v", p.stack.HTTPScheme(), org.ExposedConnectorPort)
 }
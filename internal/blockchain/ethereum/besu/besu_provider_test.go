@@ -2,6 +2,8 @@ package besu
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -159,3 +161,198 @@ func TestGetOrgConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestGetValidatorServiceDefinitions(t *testing.T) {
+	stack := &types.Stack{
+		Name:                   "TestBesuQBFT",
+		Members:                []*types.Organization{{ID: "0", OrgName: "Org1"}, {ID: "1", OrgName: "Org2"}},
+		BlockchainProvider:     types.BlockchainProviderEthereum,
+		BlockchainConnector:    types.BlockchainConnectorEvmconnect,
+		BlockchainNodeProvider: types.BlockchainNodeProviderBesu,
+		Consensus:              types.ConsensusQbft,
+		ExposedBlockchainPort:  5100,
+		VersionManifest: &types.VersionManifest{
+			Evmconnect: &types.ManifestEntry{Image: "ghcr.io/hyperledger/firefly-evmconnect", Tag: "v1.3.0"},
+			Signer:     &types.ManifestEntry{Image: "ghcr.io/hyperledger/firefly-signer", Tag: "v1.1.0"},
+		},
+	}
+	p := NewBesuProvider(context.Background(), stack)
+	serviceDefinitions := p.GetDockerServiceDefinitions()
+
+	for i, serviceName := range []string{"besu_0", "besu_1"} {
+		assert.Equal(t, serviceName, serviceDefinitions[i].ServiceName)
+		assert.Equal(t, []string{serviceName + ":/data"}, serviceDefinitions[i].Service.Volumes)
+		assert.Contains(t, serviceDefinitions[i].Service.Command, "--rpc-http-api=ETH,NET,QBFT")
+		assert.Contains(t, serviceDefinitions[i].Service.Command, "--static-nodes-file=/data/static-nodes.json")
+	}
+
+	assert.Equal(t, "ethsigner", serviceDefinitions[2].ServiceName)
+	assert.Equal(t, map[string]string{"condition": "service_started"}, serviceDefinitions[2].Service.DependsOn["besu_0"])
+}
+
+func TestWriteMemberNodesConfig(t *testing.T) {
+	initDir := t.TempDir()
+	stack := &types.Stack{
+		Name:      "TestBesuIBFT",
+		Members:   []*types.Organization{{ID: "0", OrgName: "Org1"}, {ID: "1", OrgName: "Org2"}},
+		Consensus: types.ConsensusIbft,
+	}
+	p := &BesuProvider{stack: stack}
+	err := p.writeMemberNodesConfig(&types.InitOptions{BlockPeriod: -1}, initDir)
+	assert.NoError(t, err)
+
+	var staticNodes []string
+	staticNodesBytes, err := os.ReadFile(filepath.Join(initDir, "blockchain", "static-nodes.json"))
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(staticNodesBytes, &staticNodes))
+	assert.Len(t, staticNodes, 2)
+	assert.Regexp(t, "^enode://[0-9a-f]{128}@besu_1:30303$", staticNodes[1])
+
+	var genesis Genesis
+	genesisBytes, err := os.ReadFile(filepath.Join(initDir, "blockchain", "genesis.json"))
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(genesisBytes, &genesis))
+	assert.NotNil(t, genesis.Config.Ibft2)
+	assert.Len(t, genesis.Alloc, 2)
+
+	stack.Consensus = types.ConsensusClique
+	assert.NoError(t, p.writeMemberNodesConfig(&types.InitOptions{BlockPeriod: -1}, initDir))
+	genesisBytes, err = os.ReadFile(filepath.Join(initDir, "blockchain", "genesis.json"))
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(genesisBytes, &genesis))
+	// Both nodes are Clique signers
+	assert.Len(t, genesis.ExtraData, 2+64+2*40+130)
+	for i := range stack.Members {
+		assert.FileExists(t, filepath.Join(initDir, "blockchain", fmt.Sprintf("besu_%d", i), "nodeKey"))
+	}
+}
+
+func TestGetNodePerMemberServiceDefinitions(t *testing.T) {
+	stack := &types.Stack{
+		Name: "TestBesuNodePerMember",
+		Members: []*types.Organization{
+			{ID: "0", OrgName: "Org1", ExposedBlockchainPort: 5100},
+			{ID: "1", OrgName: "Org2", ExposedBlockchainPort: 5200},
+		},
+		BlockchainProvider:     types.BlockchainProviderEthereum,
+		BlockchainConnector:    types.BlockchainConnectorEvmconnect,
+		BlockchainNodeProvider: types.BlockchainNodeProviderBesu,
+		Consensus:              types.ConsensusClique,
+		NodePerMember:          true,
+		ExposedBlockchainPort:  5100,
+		VersionManifest: &types.VersionManifest{
+			Evmconnect: &types.ManifestEntry{Image: "ghcr.io/hyperledger/firefly-evmconnect", Tag: "v1.3.0"},
+			Signer:     &types.ManifestEntry{Image: "ghcr.io/hyperledger/firefly-signer", Tag: "v1.1.0"},
+		},
+	}
+	p := NewBesuProvider(context.Background(), stack)
+	serviceDefinitions := p.GetDockerServiceDefinitions()
+
+	assert.Equal(t, "besu_0", serviceDefinitions[0].ServiceName)
+	assert.Contains(t, serviceDefinitions[0].Service.Command, "--rpc-http-api=ETH,NET,CLIQUE")
+	assert.Equal(t, "besu_1", serviceDefinitions[1].ServiceName)
+
+	for i, port := range []string{"5100:8545", "5200:8545"} {
+		signer := serviceDefinitions[2+i]
+		assert.Equal(t, fmt.Sprintf("ethsigner_%d", i), signer.ServiceName)
+		assert.Equal(t, []string{port}, signer.Service.Ports)
+		assert.Equal(t, []string{fmt.Sprintf("ethsigner_%d:/data", i), fmt.Sprintf("ethsigner_%d_config:/etc/firefly", i)}, signer.Service.Volumes)
+		assert.Equal(t, map[string]string{"condition": "service_started"}, signer.Service.DependsOn[fmt.Sprintf("besu_%d", i)])
+	}
+
+	assert.Equal(t, "evmconnect_0", serviceDefinitions[4].ServiceName)
+	assert.Equal(t, map[string]string{"condition": "service_healthy"}, serviceDefinitions[4].Service.DependsOn["ethsigner_1"])
+	assert.Equal(t, "http://besu_1:8545", p.rpcURL(1))
+}
+
+func TestGetServiceDefinitionsTracing(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		Prometheus    bool
+		Tracing       bool
+		Command       string
+		NotInCommand  string
+		OTelResources interface{}
+	}{
+		{
+			Name:         "prometheus",
+			Prometheus:   true,
+			Command:      "--metrics-enabled --metrics-host=0.0.0.0 --metrics-port=9545",
+			NotInCommand: "--metrics-protocol",
+		},
+		{
+			Name:          "tracing",
+			Prometheus:    true,
+			Tracing:       true,
+			Command:       "--metrics-enabled --metrics-protocol=opentelemetry",
+			NotInCommand:  "--metrics-port",
+			OTelResources: "service.name=besu",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			stack := &types.Stack{
+				Name:                   "TestBesuTracing",
+				Members:                []*types.Organization{{ID: "0", OrgName: "Org1"}},
+				BlockchainProvider:     types.BlockchainProviderEthereum,
+				BlockchainConnector:    types.BlockchainConnectorEvmconnect,
+				BlockchainNodeProvider: types.BlockchainNodeProviderBesu,
+				Consensus:              types.ConsensusClique,
+				PrometheusEnabled:      tc.Prometheus,
+				BesuTracingEnabled:     tc.Tracing,
+				EnvironmentVars:        map[string]interface{}{"HTTP_PROXY": "proxy"},
+				VersionManifest: &types.VersionManifest{
+					Evmconnect: &types.ManifestEntry{Image: "ghcr.io/hyperledger/firefly-evmconnect", Tag: "v1.3.0"},
+					Signer:     &types.ManifestEntry{Image: "ghcr.io/hyperledger/firefly-signer", Tag: "v1.1.0"},
+				},
+			}
+			node := NewBesuProvider(context.Background(), stack).GetDockerServiceDefinitions()[0]
+			assert.Equal(t, "besu", node.ServiceName)
+			assert.Contains(t, node.Service.Command, tc.Command)
+			assert.NotContains(t, node.Service.Command, tc.NotInCommand)
+			assert.Equal(t, "proxy", node.Service.Environment["HTTP_PROXY"])
+			assert.Equal(t, tc.OTelResources, node.Service.Environment["OTEL_RESOURCE_ATTRIBUTES"])
+			if tc.Tracing {
+				assert.Equal(t, "http://otel_collector:4317", node.Service.Environment["OTEL_EXPORTER_OTLP_ENDPOINT"])
+			}
+		})
+	}
+}
+
+func TestCreateAccountNodePerMember(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Args     []string
+		Keystore string
+		Error    string
+	}{
+		{Name: "first member by default", Args: []string{"org_0", "account_0"}, Keystore: "ethsigner_0"},
+		{Name: "member index", Args: []string{"org_1", "account_1", "1"}, Keystore: "ethsigner_1"},
+		{Name: "invalid member index", Args: []string{"org_1", "account_1", "2"}, Error: "invalid member index '2'"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			stack := &types.Stack{
+				Name:                   "TestBesuNodePerMember",
+				Members:                []*types.Organization{{ID: "0"}, {ID: "1"}},
+				BlockchainProvider:     types.BlockchainProviderEthereum,
+				BlockchainConnector:    types.BlockchainConnectorEvmconnect,
+				BlockchainNodeProvider: types.BlockchainNodeProviderBesu,
+				NodePerMember:          true,
+				InitDir:                t.TempDir(),
+				RuntimeDir:             t.TempDir(),
+			}
+			account, err := NewBesuProvider(context.Background(), stack).CreateAccount(tc.Args)
+			if tc.Error != "" {
+				assert.EqualError(t, err, tc.Error)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotEmpty(t, account.(*ethereum.Account).Address)
+			keys, err := os.ReadDir(filepath.Join(stack.InitDir, "blockchain", tc.Keystore, "keystore"))
+			assert.NoError(t, err)
+			assert.NotEmpty(t, keys)
+			assert.NoDirExists(t, filepath.Join(stack.InitDir, "blockchain", "keystore"))
+		})
+	}
+}
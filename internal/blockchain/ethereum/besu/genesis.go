@@ -21,6 +21,10 @@ import (
 	"fmt"
 	"os"
 	"strings"
+
+	"github.com/hyperledger/firefly-cli/internal/blockchain/ethereum"
+	"github.com/hyperledger/firefly-cli/pkg/types"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
 )
 
 type Storage struct {
@@ -47,7 +51,9 @@ type Genesis struct {
 type GenesisConfig struct {
 	ChainID                int64         `json:"chainId"`
 	ConstantinopleFixBlock int           `json:"constantinoplefixblock"`
-	Clique                 *CliqueConfig `json:"clique"`
+	Clique                 *CliqueConfig `json:"clique,omitempty"`
+	Qbft                   *BFTConfig    `json:"qbft,omitempty"`
+	Ibft2                  *BFTConfig    `json:"ibft2,omitempty"`
 }
 
 type CliqueConfig struct {
@@ -55,6 +61,12 @@ type CliqueConfig struct {
 	BlockPeriodSeconds int `json:"blockperiodseconds"`
 }
 
+type BFTConfig struct {
+	BlockPeriodSeconds    int `json:"blockperiodseconds"`
+	EpochLength           int `json:"epochlength"`
+	RequestTimeoutSeconds int `json:"requesttimeoutseconds"`
+}
+
 type Alloc struct {
 	Balance string   `json:"balance"`
 	Code    string   `json:"code,omitempty"`
@@ -104,3 +116,29 @@ func CreateGenesis(addresses []string, blockPeriod int, chainID int64) *Genesis
 		ParentHash: "0x0000000000000000000000000000000000000000000000000000000000000000",
 	}
 }
+
+// CreateBFTGenesis creates the genesis of a QBFT or IBFT 2.0 chain, where the validators are the addresses of the node keys
+func CreateBFTGenesis(validators []string, blockPeriod int, chainID int64, consensus fftypes.FFEnum) *Genesis {
+	genesis := CreateGenesis(validators, blockPeriod, chainID)
+	blockPeriod = genesis.Config.Clique.BlockPeriodSeconds
+	// A round must be able to outlast a block period before a round change is requested
+	requestTimeout := 10
+	if blockPeriod*2 > requestTimeout {
+		requestTimeout = blockPeriod * 2
+	}
+	bftConfig := &BFTConfig{
+		BlockPeriodSeconds:    blockPeriod,
+		EpochLength:           30000,
+		RequestTimeoutSeconds: requestTimeout,
+	}
+	genesis.Config.Clique = nil
+	genesis.MixHash = ethereum.BFTMixHash
+	if consensus.Equals(types.ConsensusIbft) {
+		genesis.Config.Ibft2 = bftConfig
+		genesis.ExtraData = ethereum.CreateIBFT2ExtraData(validators)
+	} else {
+		genesis.Config.Qbft = bftConfig
+		genesis.ExtraData = ethereum.CreateQBFTExtraData(validators)
+	}
+	return genesis
+}
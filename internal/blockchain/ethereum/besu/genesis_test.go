@@ -79,6 +79,23 @@ func TestCreateGenesis(t *testing.T) {
 
 }
 
+func TestCreateBFTGenesis(t *testing.T) {
+	validators := []string{"c2ab482b506de561668e07f04547232a72897daf"}
+
+	genesis := CreateBFTGenesis(validators, 2, 2021, types.ConsensusQbft)
+	assert.Nil(t, genesis.Config.Clique)
+	assert.Nil(t, genesis.Config.Ibft2)
+	assert.Equal(t, &BFTConfig{BlockPeriodSeconds: 2, EpochLength: 30000, RequestTimeoutSeconds: 10}, genesis.Config.Qbft)
+	assert.Equal(t, ethereum.CreateQBFTExtraData(validators), genesis.ExtraData)
+	assert.Equal(t, ethereum.BFTMixHash, genesis.MixHash)
+	assert.Contains(t, genesis.Alloc, validators[0])
+
+	genesis = CreateBFTGenesis(validators, 8, 2021, types.ConsensusIbft)
+	assert.Nil(t, genesis.Config.Qbft)
+	assert.Equal(t, &BFTConfig{BlockPeriodSeconds: 8, EpochLength: 30000, RequestTimeoutSeconds: 16}, genesis.Config.Ibft2)
+	assert.Equal(t, ethereum.CreateIBFT2ExtraData(validators), genesis.ExtraData)
+}
+
 func TestWriteGenesisJSON(t *testing.T) {
 	filepath := t.TempDir()
 
@@ -180,7 +197,7 @@ func TestGetConnectorExternal(t *testing.T) {
 		},
 	}
 	for _, tc := range testcase {
-		p := &BesuProvider{}
+		p := &BesuProvider{stack: &types.Stack{}}
 		result := p.GetConnectorExternalURL(tc.Org)
 		assert.Equal(t, tc.ExpectedPort, result)
 	}
@@ -0,0 +1,53 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"encoding/hex"
+
+	"github.com/hyperledger/firefly-signer/pkg/rlp"
+)
+
+// BFTMixHash identifies a block as an Istanbul BFT block, and must be the mixHash of a QBFT or IBFT genesis block
+const BFTMixHash = "0x63746963616c2062797a616e74696e65206661756c7420746f6c6572616e6365"
+
+// CreateQBFTExtraData RLP encodes the genesis extraData for a QBFT chain:
+// [32 bytes vanity, [validators], no vote, round 0, no seals]
+func CreateQBFTExtraData(validators []string) string {
+	return encodeBFTExtraData(validators, rlp.List{}, rlp.Data{})
+}
+
+// CreateIBFT2ExtraData RLP encodes the genesis extraData for a Besu IBFT 2.0 chain,
+// which differs from QBFT in how the empty vote and the round are encoded
+func CreateIBFT2ExtraData(validators []string) string {
+	return encodeBFTExtraData(validators, rlp.Data{}, rlp.Data{0, 0, 0, 0})
+}
+
+func encodeBFTExtraData(validators []string, vote, round rlp.Element) string {
+	validatorList := make(rlp.List, len(validators))
+	for i, validator := range validators {
+		validatorList[i] = rlp.MustWrapHex(validator)
+	}
+	extraData := rlp.List{
+		make(rlp.Data, 32),
+		validatorList,
+		vote,
+		round,
+		rlp.List{},
+	}
+	return "0x" + hex.EncodeToString(extraData.Encode())
+}
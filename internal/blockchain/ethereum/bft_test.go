@@ -0,0 +1,24 @@
+package ethereum
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateQBFTExtraData(t *testing.T) {
+	extraData := CreateQBFTExtraData([]string{"0xc2ab482b506de561668e07f04547232a72897daf"})
+	assert.Equal(t, "0xf83aa00000000000000000000000000000000000000000000000000000000000000000d594c2ab482b506de561668e07f04547232a72897dafc080c0", extraData)
+}
+
+func TestCreateIBFT2ExtraData(t *testing.T) {
+	extraData := CreateIBFT2ExtraData([]string{"c2ab482b506de561668e07f04547232a72897daf"})
+	assert.Equal(t, "0xf83ea00000000000000000000000000000000000000000000000000000000000000000d594c2ab482b506de561668e07f04547232a72897daf808400000000c0", extraData)
+}
+
+func TestGenerateNodeKey(t *testing.T) {
+	nodeKey := GenerateNodeKey()
+	assert.Len(t, nodeKey.Address, 42)
+	assert.Len(t, nodeKey.PrivateKey, 66)
+	assert.Len(t, nodeKey.PublicKey, 128)
+}
@@ -0,0 +1,29 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import "strings"
+
+// CreateCliqueExtraData returns the genesis extraData for a Clique chain with every signer in it:
+// 32 bytes vanity, the signer addresses, and an empty 65 byte seal
+func CreateCliqueExtraData(signers []string) string {
+	extraData := "0x" + strings.Repeat("0", 64)
+	for _, signer := range signers {
+		extraData += strings.TrimPrefix(signer, "0x")
+	}
+	return extraData + strings.Repeat("0", 130)
+}
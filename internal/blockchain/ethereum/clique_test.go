@@ -0,0 +1,13 @@
+package ethereum
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateCliqueExtraData(t *testing.T) {
+	extraData := CreateCliqueExtraData([]string{"0x1234567890abcdef0123456789abcdef6789abcd", "618e98197af52f44d1b05af0952a59b9f702dea4"})
+	assert.Equal(t, "0x"+strings.Repeat("0", 64)+"1234567890abcdef0123456789abcdef6789abcd618e98197af52f44d1b05af0952a59b9f702dea4"+strings.Repeat("0", 130), extraData)
+}
@@ -32,5 +32,5 @@ type Connector interface {
 }
 
 type Config interface {
-	WriteConfig(filename string, extraConnectorConfigPath string) error
+	WriteConfig(filename string, extraConnectorConfigPaths ...string) error
 }
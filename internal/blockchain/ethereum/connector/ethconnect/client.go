@@ -33,7 +33,8 @@ import (
 )
 
 type Ethconnect struct {
-	ctx context.Context
+	ctx   context.Context
+	stack *types.Stack
 }
 
 type PublishAbiResponseBody struct {
@@ -89,9 +90,10 @@ type EthconnectReplyHeaders struct {
 	Type          string  `json:"type,omitempty"`
 }
 
-func NewEthconnect(ctx context.Context) *Ethconnect {
+func NewEthconnect(ctx context.Context, stack *types.Stack) *Ethconnect {
 	return &Ethconnect{
-		ctx: ctx,
+		ctx:   ctx,
+		stack: stack,
 	}
 }
 
@@ -104,7 +106,7 @@ func (e *Ethconnect) Port() int {
 }
 
 func (e *Ethconnect) DeployContract(contract *ethtypes.CompiledContract, contractName string, member *types.Organization, extraArgs []string) (*types.ContractDeploymentResult, error) {
-	ethconnectURL := fmt.Sprintf("http://127.0.0.1:%v", member.ExposedConnectorPort)
+	ethconnectURL := fmt.Sprintf("%s://127.0.0.1:%v", e.stack.HTTPScheme(), member.ExposedConnectorPort)
 	address := member.Account.(*ethereum.Account).Address
 	hexBytecode, err := hex.DecodeString(strings.TrimPrefix(contract.Bytecode, "0x"))
 	if err != nil {
@@ -19,9 +19,11 @@ package ethconnect
 import (
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 
 	"github.com/hyperledger/firefly-cli/internal/blockchain/ethereum/connector"
+	"github.com/hyperledger/firefly-cli/internal/constants"
 	"github.com/hyperledger/firefly-cli/pkg/types"
 	"github.com/miracl/conflate"
 	"gopkg.in/yaml.v3"
@@ -54,10 +56,18 @@ type OpenAPI struct {
 }
 
 type HTTP struct {
-	Port int `yaml:"port,omitempty"`
+	Port int  `yaml:"port,omitempty"`
+	TLS  *TLS `yaml:"tls,omitempty"`
 }
 
-func (e *Config) WriteConfig(filename string, extraConnectorConfigPath string) error {
+type TLS struct {
+	Enabled         bool   `yaml:"enabled,omitempty"`
+	ClientCertsFile string `yaml:"clientCertsFile,omitempty"`
+	ClientKeyFile   string `yaml:"clientKeyFile,omitempty"`
+	CACertsFile     string `yaml:"caCertsFile,omitempty"`
+}
+
+func (e *Config) WriteConfig(filename string, extraConnectorConfigPaths ...string) error {
 	configYamlBytes, _ := yaml.Marshal(e)
 	basedir := filepath.Dir(filename)
 	if err := os.MkdirAll(basedir, 0755); err != nil {
@@ -66,8 +76,8 @@ func (e *Config) WriteConfig(filename string, extraConnectorConfigPath string) e
 	if err := os.WriteFile(filename, configYamlBytes, 0755); err != nil {
 		return err
 	}
-	if extraConnectorConfigPath != "" {
-		c, err := conflate.FromFiles(filename, extraConnectorConfigPath)
+	if len(extraConnectorConfigPaths) > 0 {
+		c, err := conflate.FromFiles(append([]string{filename}, extraConnectorConfigPaths...)...)
 		if err != nil {
 			return err
 		}
@@ -83,6 +93,15 @@ func (e *Config) WriteConfig(filename string, extraConnectorConfigPath string) e
 }
 
 func (e *Ethconnect) GenerateConfig(stack *types.Stack, member *types.Organization, blockchainServiceName string) connector.Config {
+	var tls *TLS
+	if stack.TLSEnabled {
+		tls = &TLS{
+			Enabled:         true,
+			ClientCertsFile: path.Join(constants.TLSCertsDir, "cert.pem"),
+			ClientKeyFile:   path.Join(constants.TLSCertsDir, "key.pem"),
+			CACertsFile:     path.Join(constants.TLSCertsDir, "ca.pem"),
+		}
+	}
 	return &Config{
 		Rest: &Rest{
 			RestGateway: &RestGateway{
@@ -96,6 +115,7 @@ func (e *Ethconnect) GenerateConfig(stack *types.Stack, member *types.Organizati
 				},
 				HTTP: &HTTP{
 					Port: 8080,
+					TLS:  tls,
 				},
 			},
 		},
@@ -50,6 +50,10 @@ func (e *Ethconnect) GetServiceDefinitions(s *types.Stack, dependentServices map
 				fmt.Sprintf("ethconnect_data_%v", member.ID),
 			},
 		}
+		if s.TLSEnabled {
+			service := serviceDefinitions[i].Service
+			service.Volumes = append(service.Volumes, docker.TLSCertsVolume(s, serviceDefinitions[i].ServiceName))
+		}
 	}
 	return serviceDefinitions
 }
@@ -58,12 +58,14 @@ type ExtraInfo struct {
 }
 
 type Evmconnect struct {
-	ctx context.Context
+	ctx   context.Context
+	stack *types.Stack
 }
 
-func NewEvmconnect(ctx context.Context) *Evmconnect {
+func NewEvmconnect(ctx context.Context, stack *types.Stack) *Evmconnect {
 	return &Evmconnect{
-		ctx: ctx,
+		ctx:   ctx,
+		stack: stack,
 	}
 }
 
@@ -76,7 +78,7 @@ func (e *Evmconnect) Port() int {
 }
 
 func (e *Evmconnect) DeployContract(contract *ethtypes.CompiledContract, contractName string, member *types.Organization, extraArgs []string) (*types.ContractDeploymentResult, error) {
-	evmconnectURL := fmt.Sprintf("http://127.0.0.1:%v", member.ExposedConnectorPort)
+	evmconnectURL := fmt.Sprintf("%s://127.0.0.1:%v", e.stack.HTTPScheme(), member.ExposedConnectorPort)
 	fromAddress := member.Account.(*ethereum.Account).Address
 
 	params := make([]interface{}, len(extraArgs))
@@ -95,12 +97,12 @@ func (e *Evmconnect) DeployContract(contract *ethtypes.CompiledContract, contrac
 	}
 
 	txResponse := &EvmconnectTransactionResponse{}
-	err := core.RequestWithRetry(e.ctx, "POST", evmconnectURL, requestBody, txResponse)
+	err := core.RequestWithRetryAndAuth(e.ctx, "POST", evmconnectURL, e.stack.MemberBasicAuth(member), requestBody, txResponse)
 	if err != nil {
 		return nil, err
 	}
 
-	txResponse, err = e.waitForTransactionSuccess(evmconnectURL, txResponse.ID)
+	txResponse, err = e.waitForTransactionSuccess(evmconnectURL, e.stack.MemberBasicAuth(member), txResponse.ID)
 	if err != nil ||
 		txResponse == nil ||
 		txResponse.Receipt == nil ||
@@ -117,10 +119,10 @@ func (e *Evmconnect) DeployContract(contract *ethtypes.CompiledContract, contrac
 	return result, nil
 }
 
-func (e *Evmconnect) waitForTransactionSuccess(evmconnectURL, id string) (*EvmconnectTransactionResponse, error) {
+func (e *Evmconnect) waitForTransactionSuccess(evmconnectURL string, auth *types.BasicAuth, id string) (*EvmconnectTransactionResponse, error) {
 	retries := 10
 	for retries > 0 {
-		tx, err := e.getTransactionStatus(evmconnectURL, id)
+		tx, err := e.getTransactionStatus(evmconnectURL, auth, id)
 		if err != nil {
 			return nil, err
 		}
@@ -132,7 +134,7 @@ func (e *Evmconnect) waitForTransactionSuccess(evmconnectURL, id string) (*Evmco
 	return nil, nil
 }
 
-func (e *Evmconnect) getTransactionStatus(evmconnectURL, id string) (*EvmconnectTransactionResponse, error) {
+func (e *Evmconnect) getTransactionStatus(evmconnectURL string, auth *types.BasicAuth, id string) (*EvmconnectTransactionResponse, error) {
 	u, err := url.Parse(evmconnectURL)
 	if err != nil {
 		return nil, err
@@ -144,7 +146,7 @@ func (e *Evmconnect) getTransactionStatus(evmconnectURL, id string) (*Evmconnect
 	requestURL := u.String()
 
 	reply := &EvmconnectTransactionResponse{}
-	err = core.RequestWithRetry(e.ctx, "GET", requestURL, nil, reply)
+	err = core.RequestWithRetryAndAuth(e.ctx, "GET", requestURL, auth, nil, reply)
 	return reply, err
 }
 
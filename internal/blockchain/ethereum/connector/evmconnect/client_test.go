@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/hyperledger/firefly-cli/pkg/types"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -27,7 +28,7 @@ func TestName(t *testing.T) {
 
 func TestNewEvmconnect(t *testing.T) {
 	var Ctx context.Context
-	EvmConnect := NewEvmconnect(Ctx)
+	EvmConnect := NewEvmconnect(Ctx, &types.Stack{})
 	assert.NotNil(t, EvmConnect)
 
 }
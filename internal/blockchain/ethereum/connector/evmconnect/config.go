@@ -22,6 +22,7 @@ import (
 	"path/filepath"
 
 	"github.com/hyperledger/firefly-cli/internal/blockchain/ethereum/connector"
+	"github.com/hyperledger/firefly-cli/internal/constants"
 	"github.com/hyperledger/firefly-cli/pkg/types"
 	"github.com/miracl/conflate"
 	"gopkg.in/yaml.v3"
@@ -39,9 +40,11 @@ type Config struct {
 }
 
 type APIConfig struct {
-	Port      int    `yaml:"port,omitempty"`
-	Address   string `yaml:"address,omitempty"`
-	PublicURL string `yaml:"publicURL,omitempty"`
+	Port      int                   `yaml:"port,omitempty"`
+	Address   string                `yaml:"address,omitempty"`
+	PublicURL string                `yaml:"publicURL,omitempty"`
+	TLS       *types.TLSConfig      `yaml:"tls,omitempty"`
+	Auth      *types.HTTPAuthConfig `yaml:"auth,omitempty"`
 }
 
 type ConnectorConfig struct {
@@ -57,8 +60,10 @@ type LevelDBConfig struct {
 }
 
 type FFCoreConfig struct {
-	URL        string   `yaml:"url,omitempty"`
-	Namespaces []string `yaml:"namespaces,omitempty"`
+	URL        string           `yaml:"url,omitempty"`
+	Namespaces []string         `yaml:"namespaces,omitempty"`
+	Auth       *types.BasicAuth `yaml:"auth,omitempty"`
+	TLS        *types.TLSConfig `yaml:"tls,omitempty"`
 }
 
 type ConfirmationsConfig struct {
@@ -74,7 +79,7 @@ type GasOracleConfig struct {
 	Mode string `yaml:"mode,omitempty"`
 }
 
-func (e *Config) WriteConfig(filename string, extraEvmconnectConfigPath string) error {
+func (e *Config) WriteConfig(filename string, extraEvmconnectConfigPaths ...string) error {
 	configYamlBytes, _ := yaml.Marshal(e)
 	basedir := filepath.Dir(filename)
 	if err := os.MkdirAll(basedir, 0755); err != nil {
@@ -83,8 +88,8 @@ func (e *Config) WriteConfig(filename string, extraEvmconnectConfigPath string)
 	if err := os.WriteFile(filename, configYamlBytes, 0755); err != nil {
 		return err
 	}
-	if extraEvmconnectConfigPath != "" {
-		c, err := conflate.FromFiles(filename, extraEvmconnectConfigPath)
+	if len(extraEvmconnectConfigPaths) > 0 {
+		c, err := conflate.FromFiles(append([]string{filename}, extraEvmconnectConfigPaths...)...)
 		if err != nil {
 			return err
 		}
@@ -127,7 +132,9 @@ func (e *Evmconnect) GenerateConfig(stack *types.Stack, org *types.Organization,
 		API: &APIConfig{
 			Port:      e.Port(),
 			Address:   "0.0.0.0",
-			PublicURL: fmt.Sprintf("http://127.0.0.1:%v", org.ExposedConnectorPort),
+			PublicURL: fmt.Sprintf("%s://127.0.0.1:%v", stack.HTTPScheme(), org.ExposedConnectorPort),
+			TLS:       stack.ServerTLSConfig(constants.TLSCertsDir),
+			Auth:      stack.ServerAuthConfig(constants.HtpasswdFile),
 		},
 		Connector: &ConnectorConfig{
 			URL: fmt.Sprintf("http://%s:8545", blockchainServiceName),
@@ -138,8 +145,10 @@ func (e *Evmconnect) GenerateConfig(stack *types.Stack, org *types.Organization,
 			},
 		},
 		FFCore: &FFCoreConfig{
-			URL:        getCoreURL(org),
+			URL:        getCoreURL(stack, org),
 			Namespaces: []string{"default"},
+			Auth:       stack.MemberBasicAuth(org),
+			TLS:        stack.ClientTLSConfig(constants.TLSCertsDir),
 		},
 		Metrics: metrics,
 		Confirmations: &ConfirmationsConfig{
@@ -154,10 +163,10 @@ func (e *Evmconnect) GenerateConfig(stack *types.Stack, org *types.Organization,
 	}
 }
 
-func getCoreURL(org *types.Organization) string {
+func getCoreURL(stack *types.Stack, org *types.Organization) string {
 	host := fmt.Sprintf("firefly_core_%v", org.ID)
 	if org.External {
 		host = "host.docker.internal"
 	}
-	return fmt.Sprintf("http://%s:%v", host, org.ExposedFireflyPort)
+	return fmt.Sprintf("%s://%s:%v", stack.HTTPScheme(), host, org.ExposedFireflyPort)
 }
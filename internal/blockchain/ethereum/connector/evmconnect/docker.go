@@ -50,6 +50,14 @@ func (e *Evmconnect) GetServiceDefinitions(s *types.Stack, dependentServices map
 				dataVolumeName,
 			},
 		}
+		if s.TLSEnabled {
+			service := serviceDefinitions[i].Service
+			service.Volumes = append(service.Volumes, docker.TLSCertsVolume(s, serviceDefinitions[i].ServiceName))
+		}
+		if s.AuthMode.Equals(types.AuthModeBasic) {
+			service := serviceDefinitions[i].Service
+			service.Volumes = append(service.Volumes, docker.HtpasswdVolume(s, member))
+		}
 	}
 	return serviceDefinitions
 }
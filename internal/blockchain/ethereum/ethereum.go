@@ -34,10 +34,24 @@ import (
 type Account struct {
 	Address      string `json:"address"`
 	PrivateKey   string `json:"privateKey"`
-	PtmPublicKey string `json:"ptmPublicKey"` // Public key used for Tessera
+	PtmPublicKey string `json:"ptmPublicKey"`          // Public key used for Tessera
+	MemberIndex  *int   `json:"memberIndex,omitempty"` // Member whose node holds the key, if each member has their own node
+}
+
+// NodeKey is the secp256k1 identity of a blockchain node. The public key is the uncompressed key without its
+// leading "04" byte, which is the node ID used in enode URLs
+type NodeKey struct {
+	Address    string
+	PrivateKey string
+	PublicKey  string
 }
 
 func GenerateAddressAndPrivateKey() (address string, privateKey string) {
+	nodeKey := GenerateNodeKey()
+	return nodeKey.Address, nodeKey.PrivateKey
+}
+
+func GenerateNodeKey() *NodeKey {
 	newPrivateKey, _ := secp256k1.NewPrivateKey()
 	privateKeyBytes := newPrivateKey.Serialize()
 	encodedPrivateKey := "0x" + hex.EncodeToString(privateKeyBytes)
@@ -49,7 +63,11 @@ func GenerateAddressAndPrivateKey() (address string, privateKey string) {
 	// Ethereum addresses only use the lower 20 bytes, so toss the rest away
 	encodedAddress := "0x" + hex.EncodeToString(hash.Sum(nil)[12:32])
 
-	return encodedAddress, encodedPrivateKey
+	return &NodeKey{
+		Address:    encodedAddress,
+		PrivateKey: encodedPrivateKey,
+		PublicKey:  hex.EncodeToString(publicKeyBytes),
+	}
 }
 
 func ReadFireFlyContract(ctx context.Context, s *types.Stack) (*ethtypes.CompiledContract, error) {
@@ -57,7 +75,7 @@ func ReadFireFlyContract(ctx context.Context, s *types.Stack) (*ethtypes.Compile
 	var containerName string
 	for _, member := range s.Members {
 		if !member.External {
-			containerName = fmt.Sprintf("%s_firefly_core_%s", s.Name, member.ID)
+			containerName = fmt.Sprintf("%s_firefly_core_%s", s.Root().Name, member.ID)
 			break
 		}
 	}
@@ -27,41 +27,70 @@ import (
 	"strings"
 
 	"github.com/hyperledger/firefly-cli/internal/blockchain/ethereum"
-	"github.com/hyperledger/firefly-cli/internal/constants"
 	"github.com/hyperledger/firefly-cli/internal/docker"
 	"github.com/hyperledger/firefly-cli/pkg/types"
 )
 
-// TODO: Probably randomize this and make it different per member?
-var keyPassword = "correcthorsebatterystaple"
-
 const useJavaSigner = false // also need to change the image appropriately if you recompile to use the Java signer
 
 type EthSignerProvider struct {
 	ctx   context.Context
 	stack *types.Stack
+	// The service name, which also names the volumes and config file of the signer
+	name string
+	// The index of the member whose keys the signer holds, or nil if it holds the keys of every member
+	memberIndex *int
 }
 
 func NewEthSignerProvider(ctx context.Context, stack *types.Stack) *EthSignerProvider {
 	return &EthSignerProvider{
 		ctx:   ctx,
 		stack: stack,
+		name:  "ethsigner",
+	}
+}
+
+// NewMemberEthSignerProvider returns the signer of a single member, for stacks that run a blockchain node per member
+func NewMemberEthSignerProvider(ctx context.Context, stack *types.Stack, memberIndex int) *EthSignerProvider {
+	return &EthSignerProvider{
+		ctx:         ctx,
+		stack:       stack,
+		name:        fmt.Sprintf("ethsigner_%d", memberIndex),
+		memberIndex: &memberIndex,
+	}
+}
+
+func (p *EthSignerProvider) Name() string {
+	return p.name
+}
+
+func (p *EthSignerProvider) keystoreDirectory(directory string) string {
+	if p.memberIndex != nil {
+		return filepath.Join(directory, "blockchain", p.name, "keystore")
+	}
+	return filepath.Join(directory, "blockchain", "keystore")
+}
+
+func (p *EthSignerProvider) exposedPort() int {
+	if p.memberIndex != nil {
+		return p.stack.Members[*p.memberIndex].ExposedBlockchainPort
 	}
+	return p.stack.ExposedBlockchainPort
 }
 
 func (p *EthSignerProvider) WriteConfig(options *types.InitOptions, rpcURL string) error {
 
 	// Write the password that will be used to encrypt the private key
-	initDir := filepath.Join(constants.StacksDir, p.stack.Name, "init")
+	initDir := p.stack.InitDir
 	blockchainDirectory := filepath.Join(initDir, "blockchain")
 	if err := os.MkdirAll(blockchainDirectory, 0755); err != nil {
 		return err
 	}
-	if err := os.WriteFile(filepath.Join(initDir, "blockchain", "password"), []byte(keyPassword), 0755); err != nil {
+	if err := os.WriteFile(filepath.Join(initDir, "blockchain", "password"), []byte(p.stack.KeystorePassword()), 0600); err != nil {
 		return err
 	}
 
-	signerConfigPath := filepath.Join(initDir, "config", "ethsigner.yaml")
+	signerConfigPath := filepath.Join(initDir, "config", fmt.Sprintf("%s.yaml", p.name))
 	if err := GenerateSignerConfig(options.ChainID, rpcURL).WriteConfig(signerConfigPath); err != nil {
 		return nil
 	}
@@ -70,7 +99,7 @@ func (p *EthSignerProvider) WriteConfig(options *types.InitOptions, rpcURL strin
 }
 
 func (p *EthSignerProvider) FirstTimeSetup() error {
-	ethsignerVolumeName := fmt.Sprintf("%s_ethsigner", p.stack.Name)
+	ethsignerVolumeName := fmt.Sprintf("%s_%s", p.stack.Name, p.name)
 	blockchainDir := filepath.Join(p.stack.RuntimeDir, "blockchain")
 	contractsDir := filepath.Join(p.stack.RuntimeDir, "contracts")
 
@@ -83,14 +112,14 @@ func (p *EthSignerProvider) FirstTimeSetup() error {
 	}
 
 	// Copy the signer config to the volume
-	signerConfigPath := filepath.Join(p.stack.StackDir, "runtime", "config", "ethsigner.yaml")
-	signerConfigVolumeName := fmt.Sprintf("%s_ethsigner_config", p.stack.Name)
+	signerConfigPath := filepath.Join(p.stack.RuntimeDir, "config", fmt.Sprintf("%s.yaml", p.name))
+	signerConfigVolumeName := fmt.Sprintf("%s_%s_config", p.stack.Name, p.name)
 	if err := docker.CopyFileToVolume(p.ctx, signerConfigVolumeName, signerConfigPath, "firefly.ffsigner"); err != nil {
 		return err
 	}
 
 	// Copy the wallet files all members to the blockchain volume
-	if err := docker.CopyFileToVolume(p.ctx, ethsignerVolumeName, filepath.Join(blockchainDir, "keystore"), "/"); err != nil {
+	if err := docker.CopyFileToVolume(p.ctx, ethsignerVolumeName, p.keystoreDirectory(p.stack.RuntimeDir), "/"); err != nil {
 		return err
 	}
 
@@ -134,15 +163,15 @@ func (p *EthSignerProvider) getCommand(rpcURL string) string {
 
 func (p *EthSignerProvider) GetDockerServiceDefinition(rpcURL string) *docker.ServiceDefinition {
 	return &docker.ServiceDefinition{
-		ServiceName: "ethsigner",
+		ServiceName: p.name,
 		Service: &docker.Service{
 			Image:         p.stack.VersionManifest.Signer.GetDockerImageString(),
-			ContainerName: fmt.Sprintf("%s_ethsigner", p.stack.Name),
+			ContainerName: fmt.Sprintf("%s_%s", p.stack.Name, p.name),
 			User:          "root",
 			Command:       p.getCommand(rpcURL),
 			Volumes: []string{
-				"ethsigner:/data",
-				"ethsigner_config:/etc/firefly",
+				fmt.Sprintf("%s:/data", p.name),
+				fmt.Sprintf("%s_config:/etc/firefly", p.name),
 			},
 			Logging: docker.StandardLogOptions,
 			HealthCheck: &docker.HealthCheck{
@@ -160,18 +189,18 @@ func (p *EthSignerProvider) GetDockerServiceDefinition(rpcURL string) *docker.Se
 				Interval: "15s", // 6000 requests in a day
 				Retries:  60,
 			},
-			Ports:       []string{fmt.Sprintf("%d:8545", p.stack.ExposedBlockchainPort)},
+			Ports:       []string{fmt.Sprintf("%d:8545", p.exposedPort())},
 			Environment: p.stack.EnvironmentVars,
 		},
 		VolumeNames: []string{
-			"ethsigner",
-			"ethsigner_config",
+			p.name,
+			fmt.Sprintf("%s_config", p.name),
 		},
 	}
 }
 
 func (p *EthSignerProvider) CreateAccount(args []string) (interface{}, error) {
-	ethsignerVolumeName := fmt.Sprintf("%s_ethsigner", p.stack.Name)
+	ethsignerVolumeName := fmt.Sprintf("%s_%s", p.stack.Name, p.name)
 	var directory string
 	stackHasRunBefore, err := p.stack.HasRunBefore()
 	if err != nil {
@@ -183,8 +212,7 @@ func (p *EthSignerProvider) CreateAccount(args []string) (interface{}, error) {
 		directory = p.stack.InitDir
 	}
 
-	outputDirectory := filepath.Join(directory, "blockchain", "keystore")
-	keyPair, walletFilePath, err := ethereum.CreateWalletFile(outputDirectory, "", keyPassword)
+	keyPair, walletFilePath, err := ethereum.CreateWalletFile(p.keystoreDirectory(directory), "", p.stack.KeystorePassword())
 	if err != nil {
 		return nil, err
 	}
@@ -1,20 +1,31 @@
 package ethsigner
 
 import (
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/hyperledger/firefly-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestWriteSignerConfig(t *testing.T) {
 	options := &types.InitOptions{ChainID: int64(689)}
-	stack := &types.Stack{Name: "firefly_eth"}
+	stack := &types.Stack{Name: "firefly_eth", InitDir: t.TempDir(), RuntimeDir: t.TempDir()}
 	rpcURL := "http://localhost:9583"
 	e := EthSignerProvider{
 		stack: stack,
 	}
 	err := e.WriteConfig(options, rpcURL)
-	if err != nil {
-		t.Logf("unable to write config :%v", err)
+	assert.NoError(t, err)
+	passwordFile := filepath.Join(stack.InitDir, "blockchain", "password")
+	password, err := os.ReadFile(passwordFile)
+	assert.NoError(t, err)
+	assert.Equal(t, stack.KeystorePassword(), string(password))
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(passwordFile)
+		assert.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
 	}
 }
@@ -19,6 +19,7 @@ package geth
 import (
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path"
@@ -30,6 +31,7 @@ import (
 	"github.com/hyperledger/firefly-cli/internal/blockchain/ethereum/connector"
 	"github.com/hyperledger/firefly-cli/internal/blockchain/ethereum/connector/ethconnect"
 	"github.com/hyperledger/firefly-cli/internal/blockchain/ethereum/connector/evmconnect"
+	"github.com/hyperledger/firefly-cli/internal/blockchain/ethereum/ethsigner"
 	"github.com/hyperledger/firefly-cli/internal/constants"
 	"github.com/hyperledger/firefly-cli/internal/docker"
 	"github.com/hyperledger/firefly-cli/internal/log"
@@ -37,42 +39,66 @@ import (
 )
 
 var gethImage = "ethereum/client-go:release-1.10"
+var gethP2PPort = 30311
 
-// TODO: Probably randomize this and make it different per member?
-var keyPassword = "correcthorsebatterystaple"
+// The image of a current geth release, which is used in dev mode. Current releases cannot seal Clique blocks or unlock
+// accounts, so in dev mode geth produces blocks with --dev and transactions are signed by ethsigner.
+var gethDevImage = "ethereum/client-go:v1.14.12"
+
+// DevChainID is the chain ID of every geth --dev chain, which cannot be changed
+const DevChainID = 1337
+
+// The ether each member account is funded with from the dev account of a geth --dev chain
+const devAccountFunds = 1000
 
 type GethProvider struct {
 	ctx       context.Context
 	stack     *types.Stack
 	connector connector.Connector
+	signer    *ethsigner.EthSignerProvider
 }
 
 func NewGethProvider(ctx context.Context, stack *types.Stack) *GethProvider {
 	var connector connector.Connector
 	switch stack.BlockchainConnector {
 	case types.BlockchainConnectorEthconnect:
-		connector = ethconnect.NewEthconnect(ctx)
+		connector = ethconnect.NewEthconnect(ctx, stack)
 	case types.BlockchainConnectorEvmconnect:
-		connector = evmconnect.NewEvmconnect(ctx)
+		connector = evmconnect.NewEvmconnect(ctx, stack)
+	}
+
+	var signer *ethsigner.EthSignerProvider
+	if stack.GethMode.Equals(types.GethModeDev) {
+		signer = ethsigner.NewEthSignerProvider(ctx, stack)
 	}
 
 	return &GethProvider{
 		ctx:       ctx,
 		stack:     stack,
 		connector: connector,
+		signer:    signer,
 	}
 }
 
 func (p *GethProvider) WriteConfig(options *types.InitOptions) error {
-	initDir := filepath.Join(constants.StacksDir, p.stack.Name, "init")
+	initDir := p.stack.InitDir
+	// In dev mode the connectors send transactions to ethsigner, which signs them and passes them on to geth
 	for i, member := range p.stack.Members {
+		rpcServiceName := p.stack.ServiceName(p.nodeName(i))
+		if p.signer != nil {
+			rpcServiceName = p.stack.ServiceName("ethsigner")
+		}
 		// Generate the connector config for each member
 		connectorConfigPath := filepath.Join(initDir, "config", fmt.Sprintf("%s_%v.yaml", p.connector.Name(), i))
-		if err := p.connector.GenerateConfig(p.stack, member, "geth").WriteConfig(connectorConfigPath, options.ExtraConnectorConfigPath); err != nil {
+		if err := p.connector.GenerateConfig(p.stack, member, rpcServiceName).WriteConfig(connectorConfigPath, options.ConnectorConfigPaths(i)...); err != nil {
 			return nil
 		}
 	}
 
+	if p.signer != nil {
+		return p.signer.WriteConfig(options, fmt.Sprintf("http://%s:8545", p.stack.ServiceName("geth")))
+	}
+
 	// Create genesis.json
 	addresses := make([]string, len(p.stack.Members))
 	for i, member := range p.stack.Members {
@@ -81,18 +107,74 @@ func (p *GethProvider) WriteConfig(options *types.InitOptions) error {
 		addresses[i] = address[2:]
 	}
 	genesis := CreateGenesis(addresses, options.BlockPeriod, p.stack.ChainID())
+	if p.stack.NodePerMember {
+		// Every member's node seals blocks with their own account
+		genesis.ExtraData = ethereum.CreateCliqueExtraData(addresses)
+	}
 	if err := genesis.WriteGenesisJSON(filepath.Join(initDir, "blockchain", "genesis.json")); err != nil {
 		return err
 	}
 
+	if p.stack.NodePerMember {
+		return p.writeNodeKeys(initDir)
+	}
 	return nil
 }
 
+// nodeName is the service name of the node that serves a member, which is the shared geth node unless
+// the stack runs a node per member
+func (p *GethProvider) nodeName(memberIndex int) string {
+	if p.stack.NodePerMember {
+		return fmt.Sprintf("geth_%d", memberIndex)
+	}
+	return "geth"
+}
+
+func (p *GethProvider) keystoreDirectory(directory string, memberIndex int) string {
+	if p.stack.NodePerMember {
+		return filepath.Join(directory, "blockchain", p.nodeName(memberIndex), "keystore")
+	}
+	return filepath.Join(directory, "blockchain", "keystore")
+}
+
+func (p *GethProvider) exposedPort(memberIndex int) int {
+	if p.stack.NodePerMember {
+		return p.stack.Members[memberIndex].ExposedBlockchainPort
+	}
+	return p.stack.ExposedBlockchainPort
+}
+
+// writeNodeKeys generates a node key for each member's node, and the static nodes file the nodes peer with
+// as discovery is disabled
+func (p *GethProvider) writeNodeKeys(initDir string) error {
+	staticNodes := make([]string, len(p.stack.Members))
+	for i := range p.stack.Members {
+		nodeKey := ethereum.GenerateNodeKey()
+		nodeDir := filepath.Join(initDir, "blockchain", p.nodeName(i))
+		if err := os.MkdirAll(nodeDir, 0755); err != nil {
+			return err
+		}
+		// geth expects the node key without the 0x prefix
+		if err := os.WriteFile(filepath.Join(nodeDir, "nodekey"), []byte(nodeKey.PrivateKey[2:]), 0755); err != nil {
+			return err
+		}
+		staticNodes[i] = fmt.Sprintf("enode://%s@%s:%d", nodeKey.PublicKey, p.stack.ServiceName(p.nodeName(i)), gethP2PPort)
+	}
+	staticNodesBytes, _ := json.MarshalIndent(staticNodes, "", " ")
+	return os.WriteFile(filepath.Join(initDir, "blockchain", "static-nodes.json"), staticNodesBytes, 0755)
+}
+
 func (p *GethProvider) FirstTimeSetup() error {
 	gethVolumeName := fmt.Sprintf("%s_geth", p.stack.Name)
 	blockchainDir := path.Join(p.stack.RuntimeDir, "blockchain")
 	contractsDir := path.Join(p.stack.RuntimeDir, "contracts")
 
+	if p.signer != nil {
+		if err := p.signer.FirstTimeSetup(); err != nil {
+			return err
+		}
+	}
+
 	if err := p.connector.FirstTimeSetup(p.stack); err != nil {
 		return err
 	}
@@ -103,26 +185,55 @@ func (p *GethProvider) FirstTimeSetup() error {
 
 	for i := range p.stack.Members {
 		// Copy connector config to each member's volume
-		connectorConfigPath := filepath.Join(p.stack.StackDir, "runtime", "config", fmt.Sprintf("%s_%v.yaml", p.connector.Name(), i))
+		connectorConfigPath := filepath.Join(p.stack.RuntimeDir, "config", fmt.Sprintf("%s_%v.yaml", p.connector.Name(), i))
 		connectorConfigVolumeName := fmt.Sprintf("%s_%s_config_%v", p.stack.Name, p.connector.Name(), i)
 		if err := docker.CopyFileToVolume(p.ctx, connectorConfigVolumeName, connectorConfigPath, "config.yaml"); err != nil {
 			return err
 		}
 	}
 
+	if p.signer != nil {
+		// The keys are in the ethsigner volume, and geth --dev creates its own genesis block
+		return nil
+	}
+
+	if p.stack.NodePerMember {
+		for i := range p.stack.Members {
+			nodeVolumeName := fmt.Sprintf("%s_%s", p.stack.Name, p.nodeName(i))
+			// Copy the node key to where geth looks for it, and the static nodes that the nodes peer with
+			if err := docker.MkdirInVolume(p.ctx, nodeVolumeName, "geth"); err != nil {
+				return err
+			}
+			if err := docker.CopyFileToVolume(p.ctx, nodeVolumeName, path.Join(blockchainDir, p.nodeName(i), "nodekey"), "geth/nodekey"); err != nil {
+				return err
+			}
+			if err := docker.CopyFileToVolume(p.ctx, nodeVolumeName, path.Join(blockchainDir, "static-nodes.json"), "static-nodes.json"); err != nil {
+				return err
+			}
+			if err := p.initNodeVolume(nodeVolumeName, p.keystoreDirectory(p.stack.RuntimeDir, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
 	// Copy the wallet files all members to the blockchain volume
-	keystoreDirectory := filepath.Join(blockchainDir, "keystore")
-	if err := docker.CopyFileToVolume(p.ctx, gethVolumeName, keystoreDirectory, "/"); err != nil {
+	return p.initNodeVolume(gethVolumeName, p.keystoreDirectory(p.stack.RuntimeDir, 0))
+}
+
+func (p *GethProvider) initNodeVolume(volumeName, keystoreDirectory string) error {
+	blockchainDir := path.Join(p.stack.RuntimeDir, "blockchain")
+	if err := docker.CopyFileToVolume(p.ctx, volumeName, keystoreDirectory, "/"); err != nil {
 		return err
 	}
 
 	// Copy the genesis block information
-	if err := docker.CopyFileToVolume(p.ctx, gethVolumeName, path.Join(blockchainDir, "genesis.json"), "genesis.json"); err != nil {
+	if err := docker.CopyFileToVolume(p.ctx, volumeName, path.Join(blockchainDir, "genesis.json"), "genesis.json"); err != nil {
 		return err
 	}
 
 	// Initialize the genesis block
-	if err := docker.RunDockerCommand(p.ctx, p.stack.StackDir, "run", "--rm", "-v", fmt.Sprintf("%s:/data", gethVolumeName), gethImage, "--datadir", "/data", "init", "/data/genesis.json"); err != nil {
+	if err := docker.RunDockerCommand(p.ctx, p.stack.StackDir, "run", "--rm", "-v", fmt.Sprintf("%s:/data", volumeName), gethImage, "--datadir", "/data", "init", "/data/genesis.json"); err != nil {
 		return err
 	}
 
@@ -135,11 +246,25 @@ func (p *GethProvider) PreStart() error {
 
 func (p *GethProvider) PostStart(firstTimeSetup bool) error {
 	l := log.LoggerFromContext(p.ctx)
+	if p.signer != nil {
+		if !firstTimeSetup {
+			return nil
+		}
+		for _, account := range p.stack.State.Accounts {
+			address := account.(*ethereum.Account).Address
+			l.Info(fmt.Sprintf("funding account %s", address))
+			if err := p.fundAccount(address); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
 	// Unlock accounts
 	for _, account := range p.stack.State.Accounts {
 		address := account.(*ethereum.Account).Address
 		l.Info(fmt.Sprintf("unlocking account %s", address))
-		if err := p.unlockAccount(address, keyPassword); err != nil {
+		if err := p.unlockAccount(address, p.stack.KeystorePassword(), p.accountMemberIndex(account.(*ethereum.Account))); err != nil {
 			return err
 		}
 	}
@@ -147,10 +272,24 @@ func (p *GethProvider) PostStart(firstTimeSetup bool) error {
 	return nil
 }
 
-func (p *GethProvider) unlockAccount(address, password string) error {
+// accountMemberIndex returns the index of the member whose node holds the key of an account, as each member's
+// accounts are on their own node if the stack runs a node per member
+func (p *GethProvider) accountMemberIndex(account *ethereum.Account) int {
+	if account.MemberIndex != nil {
+		return *account.MemberIndex
+	}
+	for _, member := range p.stack.Members {
+		if memberAccount, ok := member.Account.(*ethereum.Account); ok && memberAccount.Address == account.Address {
+			return *member.Index
+		}
+	}
+	return 0
+}
+
+func (p *GethProvider) unlockAccount(address, password string, memberIndex int) error {
 	l := log.LoggerFromContext(p.ctx)
 	verbose := log.VerbosityFromContext(p.ctx)
-	gethClient := NewGethClient(fmt.Sprintf("http://127.0.0.1:%v", p.stack.ExposedBlockchainPort))
+	gethClient := NewGethClient(fmt.Sprintf("http://127.0.0.1:%v", p.exposedPort(memberIndex)))
 	retries := 10
 	for {
 		if err := gethClient.UnlockAccount(address, password); err != nil {
@@ -169,6 +308,30 @@ func (p *GethProvider) unlockAccount(address, password string) error {
 	return nil
 }
 
+// fundAccount sends ether to an account from the dev account of a geth --dev chain, which is the only account with
+// funds. The dev account is unlocked inside geth, so the transaction is sent over IPC rather than through ethsigner.
+func (p *GethProvider) fundAccount(address string) error {
+	l := log.LoggerFromContext(p.ctx)
+	verbose := log.VerbosityFromContext(p.ctx)
+	script := fmt.Sprintf(`eth.sendTransaction({from: eth.accounts[0], to: "%s", value: web3.toWei(%d, "ether")})`, address, devAccountFunds)
+	retries := 10
+	for {
+		if _, err := docker.RunDockerCommandBuffered(p.ctx, p.stack.StackDir, "exec", fmt.Sprintf("%s_geth", p.stack.Name), "geth", "attach", "--exec", script, "/data/geth.ipc"); err != nil {
+			if verbose {
+				l.Debug(err.Error())
+			}
+			if retries == 0 {
+				return fmt.Errorf("unable to fund account %s", address)
+			}
+			time.Sleep(time.Second * 1)
+			retries--
+		} else {
+			break
+		}
+	}
+	return nil
+}
+
 func (p *GethProvider) DeployFireFlyContract() (*types.ContractDeploymentResult, error) {
 	contract, err := ethereum.ReadFireFlyContract(p.ctx, p.stack)
 	if err != nil {
@@ -178,23 +341,72 @@ func (p *GethProvider) DeployFireFlyContract() (*types.ContractDeploymentResult,
 }
 
 func (p *GethProvider) GetDockerServiceDefinitions() []*docker.ServiceDefinition {
-	gethCommand := fmt.Sprintf(`--datadir /data --syncmode 'full' --port 30311 --http --http.addr "0.0.0.0" --http.corsdomain="*"  -http.port 8545 --http.vhosts "*" --http.api 'admin,personal,eth,net,web3,txpool,miner,clique,debug' --networkid %d --miner.gasprice 0 --password /data/password --mine --allow-insecure-unlock --nodiscover --verbosity 4 --miner.gaslimit 16777215`, p.stack.ChainID())
+	if p.signer != nil {
+		return p.getDevServiceDefinitions()
+	}
+	gethCommand := fmt.Sprintf(`--datadir /data --syncmode 'full' --port %d --http --http.addr "0.0.0.0" --http.corsdomain="*"  -http.port 8545 --http.vhosts "*" --http.api 'admin,personal,eth,net,web3,txpool,miner,clique,debug' --networkid %d --miner.gasprice 0 --password /data/password --mine --allow-insecure-unlock --nodiscover --verbosity 4 --miner.gaslimit 16777215`, gethP2PPort, p.stack.ChainID())
+	if p.stack.PrometheusEnabled {
+		gethCommand += fmt.Sprintf(" --metrics --metrics.addr 0.0.0.0 --metrics.port %d", constants.GethMetricsPort)
+	}
 
-	serviceDefinitions := make([]*docker.ServiceDefinition, 1)
-	serviceDefinitions[0] = &docker.ServiceDefinition{
-		ServiceName: "geth",
+	if !p.stack.NodePerMember {
+		serviceDefinitions := []*docker.ServiceDefinition{p.getNodeServiceDefinition("geth", gethCommand, p.stack.ExposedBlockchainPort)}
+		return append(serviceDefinitions, p.connector.GetServiceDefinitions(p.stack, map[string]string{"geth": "service_started"})...)
+	}
+
+	// Each node seals Clique blocks with its member's account, and peers with the others through the static nodes
+	serviceDefinitions := make([]*docker.ServiceDefinition, 0, len(p.stack.Members))
+	connectorDependents := map[string]string{}
+	for i, member := range p.stack.Members {
+		memberCommand := fmt.Sprintf("%s --miner.etherbase %s", gethCommand, member.Account.(*ethereum.Account).Address)
+		serviceDefinitions = append(serviceDefinitions, p.getNodeServiceDefinition(p.nodeName(i), memberCommand, member.ExposedBlockchainPort))
+		connectorDependents[p.nodeName(i)] = "service_started"
+	}
+	return append(serviceDefinitions, p.connector.GetServiceDefinitions(p.stack, connectorDependents)...)
+}
+
+func (p *GethProvider) getNodeServiceDefinition(serviceName, gethCommand string, exposedPort int) *docker.ServiceDefinition {
+	return &docker.ServiceDefinition{
+		ServiceName: serviceName,
 		Service: &docker.Service{
 			Image:         gethImage,
-			ContainerName: fmt.Sprintf("%s_geth", p.stack.Name),
+			ContainerName: fmt.Sprintf("%s_%s", p.stack.Name, serviceName),
 			Command:       gethCommand,
-			Volumes:       []string{"geth:/data"},
+			Volumes:       []string{fmt.Sprintf("%s:/data", serviceName)},
 			Logging:       docker.StandardLogOptions,
-			Ports:         []string{fmt.Sprintf("%d:8545", p.stack.ExposedBlockchainPort)},
+			Ports:         []string{fmt.Sprintf("%d:8545", exposedPort)},
 			Environment:   p.stack.EnvironmentVars,
 		},
-		VolumeNames: []string{"geth"},
+		VolumeNames: []string{serviceName},
 	}
-	serviceDefinitions = append(serviceDefinitions, p.connector.GetServiceDefinitions(p.stack, map[string]string{"geth": "service_started"})...)
+}
+
+// getDevServiceDefinitions returns geth running a --dev chain, which mines a block whenever there is a transaction
+// unless a block period is set, with ethsigner in front of it on the blockchain port
+func (p *GethProvider) getDevServiceDefinitions() []*docker.ServiceDefinition {
+	gethCommand := fmt.Sprintf(`--dev --dev.period %d --datadir /data --http --http.addr "0.0.0.0" --http.corsdomain="*" --http.port 8545 --http.vhosts "*" --http.api 'eth,net,web3,txpool,debug' --miner.gasprice 0 --nodiscover --verbosity 3`, max(p.stack.BlockPeriod, 0))
+	if p.stack.PrometheusEnabled {
+		gethCommand += fmt.Sprintf(" --metrics --metrics.addr 0.0.0.0 --metrics.port %d", constants.GethMetricsPort)
+	}
+
+	signerServiceDefinition := p.signer.GetDockerServiceDefinition(fmt.Sprintf("http://%s:8545", p.stack.ServiceName("geth")))
+	signerServiceDefinition.Service.DependsOn = map[string]map[string]string{"geth": {"condition": "service_started"}}
+	serviceDefinitions := []*docker.ServiceDefinition{
+		{
+			ServiceName: "geth",
+			Service: &docker.Service{
+				Image:         gethDevImage,
+				ContainerName: fmt.Sprintf("%s_geth", p.stack.Name),
+				Command:       gethCommand,
+				Volumes:       []string{"geth:/data"},
+				Logging:       docker.StandardLogOptions,
+				Environment:   p.stack.EnvironmentVars,
+			},
+			VolumeNames: []string{"geth"},
+		},
+		signerServiceDefinition,
+	}
+	serviceDefinitions = append(serviceDefinitions, p.connector.GetServiceDefinitions(p.stack, map[string]string{"ethsigner": "service_healthy"})...)
 	return serviceDefinitions
 }
 
@@ -254,7 +466,19 @@ func (p *GethProvider) DeployContract(filename, contractName, instanceName strin
 }
 
 func (p *GethProvider) CreateAccount(args []string) (interface{}, error) {
-	gethVolumeName := fmt.Sprintf("%s_geth", p.stack.Name)
+	if p.signer != nil {
+		return p.createSignerAccount(args)
+	}
+	// The member index is only needed when each member's accounts are on their own node
+	memberIndex := 0
+	if p.stack.NodePerMember && len(args) > 2 {
+		i, err := strconv.Atoi(args[2])
+		if err != nil || i < 0 || i >= len(p.stack.Members) {
+			return nil, fmt.Errorf("invalid member index '%s'", args[2])
+		}
+		memberIndex = i
+	}
+	gethVolumeName := fmt.Sprintf("%s_%s", p.stack.Name, p.nodeName(memberIndex))
 	var directory string
 	stackHasRunBefore, err := p.stack.HasRunBefore()
 	if err != nil {
@@ -267,8 +491,7 @@ func (p *GethProvider) CreateAccount(args []string) (interface{}, error) {
 	}
 
 	prefix := strconv.FormatInt(time.Now().UnixNano(), 10)
-	outputDirectory := filepath.Join(directory, "blockchain", "keystore")
-	keyPair, walletFilePath, err := ethereum.CreateWalletFile(outputDirectory, prefix, keyPassword)
+	keyPair, walletFilePath, err := ethereum.CreateWalletFile(p.keystoreDirectory(directory, memberIndex), prefix, p.stack.KeystorePassword())
 	if err != nil {
 		return nil, err
 	}
@@ -277,23 +500,50 @@ func (p *GethProvider) CreateAccount(args []string) (interface{}, error) {
 		if err := ethereum.CopyWalletFileToVolume(p.ctx, walletFilePath, gethVolumeName); err != nil {
 			return nil, err
 		}
-		if err := p.unlockAccount(keyPair.Address.String(), keyPassword); err != nil {
+		if err := p.unlockAccount(keyPair.Address.String(), p.stack.KeystorePassword(), memberIndex); err != nil {
 			return nil, err
 		}
 	}
 
-	return &ethereum.Account{
+	account := &ethereum.Account{
 		Address:    keyPair.Address.String(),
 		PrivateKey: hex.EncodeToString(keyPair.PrivateKeyBytes()),
-	}, nil
+	}
+	if p.stack.NodePerMember {
+		account.MemberIndex = &memberIndex
+	}
+	return account, nil
+}
+
+// createSignerAccount creates a key in the ethsigner keystore, and funds it straight away if the chain is running
+func (p *GethProvider) createSignerAccount(args []string) (interface{}, error) {
+	account, err := p.signer.CreateAccount(args)
+	if err != nil {
+		return nil, err
+	}
+	stackHasRunBefore, err := p.stack.HasRunBefore()
+	if err != nil {
+		return nil, err
+	}
+	if stackHasRunBefore {
+		if err := p.fundAccount(account.(*ethereum.Account).Address); err != nil {
+			return nil, err
+		}
+	}
+	return account, nil
 }
 
 func (p *GethProvider) ParseAccount(account interface{}) interface{} {
 	accountMap := account.(map[string]interface{})
-	return &ethereum.Account{
+	parsedAccount := &ethereum.Account{
 		Address:    accountMap["address"].(string),
 		PrivateKey: accountMap["privateKey"].(string),
 	}
+	if memberIndex, ok := accountMap["memberIndex"].(float64); ok {
+		index := int(memberIndex)
+		parsedAccount.MemberIndex = &index
+	}
+	return parsedAccount
 }
 
 func (p *GethProvider) GetConnectorName() string {
@@ -301,9 +551,9 @@ func (p *GethProvider) GetConnectorName() string {
 }
 
 func (p *GethProvider) GetConnectorURL(org *types.Organization) string {
-	return fmt.Sprintf("http://%s_%s:%v", p.connector.Name(), org.ID, p.connector.Port())
+	return fmt.Sprintf("%s://%s:%v", p.stack.HTTPScheme(), p.stack.ServiceName(fmt.Sprintf("%s_%s", p.connector.Name(), org.ID)), p.connector.Port())
 }
 
 func (p *GethProvider) GetConnectorExternalURL(org *types.Organization) string {
-	return fmt.Sprintf("http://127.0.0.1:%v", org.ExposedConnectorPort)
+	return fmt.Sprintf("%s://127.0.0.1:%v", p.stack.HTTPScheme(), org.ExposedConnectorPort)
 }
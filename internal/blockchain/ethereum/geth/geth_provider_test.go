@@ -3,6 +3,7 @@ package geth
 import (
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"os"
 	"path/filepath"
 
@@ -107,6 +108,19 @@ func TestParseAccount(t *testing.T) {
 				PrivateKey: "112233445566778899aabbccddeeff00112233445566778899aabbccddeeff00",
 			},
 		},
+		{
+			Name: "Account on a member's node",
+			Address: map[string]interface{}{
+				"address":     "0x549b5f43a40e1a0522864a004cfff2b0ca473a65",
+				"privateKey":  "112233445566778899aabbccddeeff00112233445566778899aabbccddeeff00",
+				"memberIndex": float64(1),
+			},
+			ExpectedAccount: &ethereum.Account{
+				Address:     "0x549b5f43a40e1a0522864a004cfff2b0ca473a65",
+				PrivateKey:  "112233445566778899aabbccddeeff00112233445566778899aabbccddeeff00",
+				MemberIndex: &[]int{1}[0],
+			},
+		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.Name, func(t *testing.T) {
@@ -231,6 +245,7 @@ func TestGetConnectorExternal(t *testing.T) {
 	testcase := []struct {
 		Name         string
 		Org          *types.Organization
+		TLSEnabled   bool
 		ExpectedPort string
 	}{
 		{
@@ -259,9 +274,23 @@ func TestGetConnectorExternal(t *testing.T) {
 			},
 			ExpectedPort: "http://127.0.0.1:8000",
 		},
+		{
+			Name: "testcase3",
+			Org: &types.Organization{
+				OrgName:  "Org-3",
+				NodeName: "geth",
+				Account: &ethereum.Account{
+					Address:    "0xabcdeffedcba9876543210abcdeffedc00000000",
+					PrivateKey: "aabbccddeeff0011223344556677889900112233445566778899aabbccddeeff",
+				},
+				ExposedConnectorPort: 8000,
+			},
+			TLSEnabled:   true,
+			ExpectedPort: "https://127.0.0.1:8000",
+		},
 	}
 	for _, tc := range testcase {
-		p := &GethProvider{}
+		p := &GethProvider{stack: &types.Stack{TLSEnabled: tc.TLSEnabled}}
 		result := p.GetConnectorExternalURL(tc.Org)
 		assert.Equal(t, tc.ExpectedPort, result)
 	}
@@ -321,3 +350,153 @@ func TestCreateAccount(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateAccountNodePerMember(t *testing.T) {
+	p := &GethProvider{
+		stack: &types.Stack{
+			Name:                   "TestGethNodePerMember",
+			Members:                []*types.Organization{{ID: "0"}, {ID: "1"}},
+			BlockchainProvider:     types.BlockchainProviderEthereum,
+			BlockchainNodeProvider: types.BlockchainNodeProviderGeth,
+			NodePerMember:          true,
+			InitDir:                t.TempDir(),
+			RuntimeDir:             t.TempDir(),
+		},
+	}
+	account, err := p.CreateAccount([]string{"org_1", "account_1", "1"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *account.(*ethereum.Account).MemberIndex)
+	assert.Equal(t, 1, p.accountMemberIndex(account.(*ethereum.Account)))
+
+	_, err = p.CreateAccount([]string{"org_1", "account_1", "2"})
+	assert.Regexp(t, "invalid member index '2'", err)
+}
+
+func TestAccountMemberIndex(t *testing.T) {
+	p := &GethProvider{
+		stack: &types.Stack{
+			Members: []*types.Organization{
+				{ID: "0", Index: &[]int{0}[0], Account: &ethereum.Account{Address: "0x0"}},
+				{ID: "1", Index: &[]int{1}[0], Account: &ethereum.Account{Address: "0x1"}},
+			},
+			NodePerMember: true,
+		},
+	}
+	testCases := []struct {
+		Name        string
+		Account     *ethereum.Account
+		MemberIndex int
+	}{
+		{Name: "member account", Account: &ethereum.Account{Address: "0x1"}, MemberIndex: 1},
+		{Name: "account created on a member's node", Account: &ethereum.Account{Address: "0x2", MemberIndex: &[]int{1}[0]}, MemberIndex: 1},
+		{Name: "account without a member", Account: &ethereum.Account{Address: "0x3"}, MemberIndex: 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.MemberIndex, p.accountMemberIndex(tc.Account))
+		})
+	}
+}
+
+func TestGetDevServiceDefinitions(t *testing.T) {
+	stack := &types.Stack{
+		Name: "TestGethDev",
+		Members: []*types.Organization{
+			{
+				ID:      "0",
+				OrgName: "Org1",
+				Account: &ethereum.Account{
+					Address:    "0x1234567890abcdef0123456789abcdef6789abcd",
+					PrivateKey: "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff",
+				},
+			},
+		},
+		BlockchainProvider:     types.BlockchainProviderEthereum,
+		BlockchainConnector:    types.BlockchainConnectorEvmconnect,
+		BlockchainNodeProvider: types.BlockchainNodeProviderGeth,
+		GethMode:               types.GethModeDev,
+		ExposedBlockchainPort:  5100,
+		BlockPeriod:            5,
+		VersionManifest: &types.VersionManifest{
+			Evmconnect: &types.ManifestEntry{Image: "ghcr.io/hyperledger/firefly-evmconnect", Tag: "v1.3.0"},
+			Signer:     &types.ManifestEntry{Image: "ghcr.io/hyperledger/firefly-signer", Tag: "v1.1.0"},
+		},
+	}
+	p := NewGethProvider(context.Background(), stack)
+	serviceDefinitions := p.GetDockerServiceDefinitions()
+
+	assert.Equal(t, "geth", serviceDefinitions[0].ServiceName)
+	assert.Equal(t, gethDevImage, serviceDefinitions[0].Service.Image)
+	assert.Contains(t, serviceDefinitions[0].Service.Command, "--dev --dev.period 5")
+	assert.NotContains(t, serviceDefinitions[0].Service.Command, "personal")
+	assert.Empty(t, serviceDefinitions[0].Service.Ports)
+
+	assert.Equal(t, "ethsigner", serviceDefinitions[1].ServiceName)
+	assert.Equal(t, []string{"5100:8545"}, serviceDefinitions[1].Service.Ports)
+	assert.Equal(t, map[string]string{"condition": "service_started"}, serviceDefinitions[1].Service.DependsOn["geth"])
+
+	assert.Equal(t, "evmconnect_0", serviceDefinitions[2].ServiceName)
+	assert.Equal(t, map[string]string{"condition": "service_healthy"}, serviceDefinitions[2].Service.DependsOn["ethsigner"])
+}
+
+func TestGetNodePerMemberServiceDefinitions(t *testing.T) {
+	stack := &types.Stack{
+		Name: "TestGethNodePerMember",
+		Members: []*types.Organization{
+			{
+				ID:                    "0",
+				OrgName:               "Org1",
+				Account:               &ethereum.Account{Address: "0x1234567890abcdef0123456789abcdef6789abcd"},
+				ExposedBlockchainPort: 5100,
+			},
+			{
+				ID:                    "1",
+				OrgName:               "Org2",
+				Account:               &ethereum.Account{Address: "0x618E98197aF52F44D1B05Af0952a59b9f702dea4"},
+				ExposedBlockchainPort: 5200,
+			},
+		},
+		BlockchainProvider:     types.BlockchainProviderEthereum,
+		BlockchainConnector:    types.BlockchainConnectorEvmconnect,
+		BlockchainNodeProvider: types.BlockchainNodeProviderGeth,
+		NodePerMember:          true,
+		ExposedBlockchainPort:  5100,
+		VersionManifest: &types.VersionManifest{
+			Evmconnect: &types.ManifestEntry{Image: "ghcr.io/hyperledger/firefly-evmconnect", Tag: "v1.3.0"},
+		},
+	}
+	p := NewGethProvider(context.Background(), stack)
+	serviceDefinitions := p.GetDockerServiceDefinitions()
+
+	assert.Equal(t, "geth_0", serviceDefinitions[0].ServiceName)
+	assert.Equal(t, []string{"5100:8545"}, serviceDefinitions[0].Service.Ports)
+	assert.Contains(t, serviceDefinitions[0].Service.Command, "--miner.etherbase 0x1234567890abcdef0123456789abcdef6789abcd")
+	assert.Equal(t, "geth_1", serviceDefinitions[1].ServiceName)
+	assert.Equal(t, []string{"5200:8545"}, serviceDefinitions[1].Service.Ports)
+	assert.Equal(t, []string{"geth_1:/data"}, serviceDefinitions[1].Service.Volumes)
+
+	assert.Equal(t, "evmconnect_1", serviceDefinitions[3].ServiceName)
+	assert.Equal(t, map[string]string{"condition": "service_started"}, serviceDefinitions[3].Service.DependsOn["geth_1"])
+}
+
+func TestWriteNodeKeys(t *testing.T) {
+	initDir := t.TempDir()
+	stack := &types.Stack{
+		Name:          "TestGethNodePerMember",
+		Members:       []*types.Organization{{ID: "0"}, {ID: "1"}},
+		NodePerMember: true,
+	}
+	p := &GethProvider{stack: stack}
+	assert.NoError(t, p.writeNodeKeys(initDir))
+
+	var staticNodes []string
+	staticNodesBytes, err := os.ReadFile(filepath.Join(initDir, "blockchain", "static-nodes.json"))
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(staticNodesBytes, &staticNodes))
+	assert.Regexp(t, "^enode://[0-9a-f]{128}@geth_1:30311$", staticNodes[1])
+
+	nodeKey, err := os.ReadFile(filepath.Join(initDir, "blockchain", "geth_0", "nodekey"))
+	assert.NoError(t, err)
+	assert.Len(t, nodeKey, 64)
+	assert.Equal(t, filepath.Join(initDir, "blockchain", "geth_1", "keystore"), p.keystoreDirectory(initDir, 1))
+}
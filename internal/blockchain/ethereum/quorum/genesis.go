@@ -22,6 +22,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/hyperledger/firefly-cli/internal/blockchain/ethereum"
 )
 
 type Genesis struct {
@@ -50,7 +52,9 @@ type GenesisConfig struct {
 	ConstantinopleBlock int           `json:"constantinopleBlock"`
 	PetersburgBlock     int           `json:"petersburgBlock"`
 	IstanbulBlock       int           `json:"istanbulBlock"`
-	Clique              *CliqueConfig `json:"clique"`
+	IsQuorum            bool          `json:"isQuorum,omitempty"`
+	Clique              *CliqueConfig `json:"clique,omitempty"`
+	Qbft                *QBFTConfig   `json:"qbft,omitempty"`
 }
 
 type CliqueConfig struct {
@@ -58,6 +62,14 @@ type CliqueConfig struct {
 	Epoch  int `json:"epoch"`
 }
 
+type QBFTConfig struct {
+	BlockPeriodSeconds    int `json:"blockperiodseconds"`
+	EpochLength           int `json:"epochlength"`
+	RequestTimeoutSeconds int `json:"requesttimeoutseconds"`
+	Policy                int `json:"policy"`
+	Ceil2Nby3Block        int `json:"ceil2Nby3Block"`
+}
+
 type Alloc struct {
 	Balance string `json:"balance"`
 }
@@ -106,6 +118,37 @@ func CreateGenesis(addresses []string, blockPeriod int, chainID int64) *Genesis
 	}
 }
 
+// CreateQBFTGenesis creates the genesis of a QBFT chain, where the validators are the addresses of the node keys
+// and the addresses are the member accounts to fund
+func CreateQBFTGenesis(validators, addresses []string, blockPeriod int, chainID int64) *Genesis {
+	genesis := CreateGenesis(addresses, blockPeriod, chainID)
+	blockPeriod = genesis.Config.Clique.Period
+	// A round must be able to outlast a block period before a round change is requested
+	requestTimeout := 10
+	if blockPeriod*2 > requestTimeout {
+		requestTimeout = blockPeriod * 2
+	}
+	genesis.Config.Clique = nil
+	genesis.Config.Qbft = &QBFTConfig{
+		BlockPeriodSeconds:    blockPeriod,
+		EpochLength:           30000,
+		RequestTimeoutSeconds: requestTimeout,
+	}
+	genesis.ExtraData = ethereum.CreateQBFTExtraData(validators)
+	genesis.MixHash = ethereum.BFTMixHash
+	return genesis
+}
+
+// CreateRaftGenesis creates the genesis of a Raft chain. Raft has no sealers, the cluster is the static nodes of each node
+func CreateRaftGenesis(addresses []string, chainID int64) *Genesis {
+	genesis := CreateGenesis(addresses, -1, chainID)
+	genesis.Config.Clique = nil
+	genesis.Config.IsQuorum = true
+	genesis.ExtraData = "0x0000000000000000000000000000000000000000000000000000000000000000"
+	genesis.Difficulty = "0x0"
+	return genesis
+}
+
 func (g *Genesis) WriteGenesisJSON(filename string) error {
 	genesisJSONBytes, _ := json.MarshalIndent(g, "", " ")
 	basedir := filepath.Dir(filename)
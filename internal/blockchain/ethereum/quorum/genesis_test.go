@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/hyperledger/firefly-cli/internal/blockchain/ethereum"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -93,6 +94,31 @@ func TestCreateGenesis(t *testing.T) {
 	}
 }
 
+func TestCreateQBFTGenesis(t *testing.T) {
+	validators := []string{"c2ab482b506de561668e07f04547232a72897daf"}
+	addresses := []string{"1234567890abcdef0123456789abcdef6789abcd"}
+
+	genesis := CreateQBFTGenesis(validators, addresses, -1, 1337)
+	assert.Nil(t, genesis.Config.Clique)
+	assert.False(t, genesis.Config.IsQuorum)
+	assert.Equal(t, &QBFTConfig{BlockPeriodSeconds: 5, EpochLength: 30000, RequestTimeoutSeconds: 10}, genesis.Config.Qbft)
+	assert.Equal(t, ethereum.CreateQBFTExtraData(validators), genesis.ExtraData)
+	assert.Equal(t, ethereum.BFTMixHash, genesis.MixHash)
+	assert.Contains(t, genesis.Alloc, addresses[0])
+}
+
+func TestCreateRaftGenesis(t *testing.T) {
+	addresses := []string{"1234567890abcdef0123456789abcdef6789abcd"}
+
+	genesis := CreateRaftGenesis(addresses, 1337)
+	assert.Nil(t, genesis.Config.Clique)
+	assert.Nil(t, genesis.Config.Qbft)
+	assert.True(t, genesis.Config.IsQuorum)
+	assert.Equal(t, "0x0", genesis.Difficulty)
+	assert.Len(t, genesis.ExtraData, 66)
+	assert.Contains(t, genesis.Alloc, addresses[0])
+}
+
 func TestWriteGenesisJSON(t *testing.T) {
 	filepath := t.TempDir()
 
@@ -30,6 +30,8 @@ import (
 
 var DockerEntrypoint = "docker-entrypoint.sh"
 var QuorumPort = "8545"
+var QuorumP2PPort = 30311
+var RaftPort = 53000
 
 func CreateQuorumEntrypoint(ctx context.Context, outputDirectory, consensus, stackName string, memberIndex, chainID, blockPeriodInSeconds int, privateTransactionManager fftypes.FFEnum) error {
 	var discoveryCmd string
@@ -60,7 +62,7 @@ echo ""
 	if blockPeriodInSeconds == -1 {
 		blockPeriod = 5
 	}
-	blockPeriodInMs := blockPeriod * 60
+	blockPeriodInMs := blockPeriod * 1000
 
 	content := fmt.Sprintf(`#!/bin/sh
 
@@ -83,12 +85,12 @@ then
 elif [ "raft" == "$GOQUORUM_CONS_ALGO" ];
 then
     echo "Using raft for consensus algorithm..."
-    export CONSENSUS_ARGS="--raft --raftblocktime %[7]d --raftport 53000"
+    export CONSENSUS_ARGS="--raft --raftblocktime %[7]d --raftport %[9]d --raftdnsenable"
     export QUORUM_API="raft"
 elif [ "clique" == "$GOQUORUM_CONS_ALGO" ];
 then
 	echo "Using clique for consensus algorithm..."
-	export CONSENSUS_ARGS=""
+	export CONSENSUS_ARGS="--mine"
 	export QUORUM_API="clique"
 fi
 
@@ -100,7 +102,7 @@ ADDITIONAL_ARGS=${ADDITIONAL_ARGS:-}
 echo "bootnode discovery command :: $BOOTNODE_CMD"
 IP_ADDR=$(cat /etc/hosts | tail -n 1 | awk '{print $1}')
 
-exec geth --datadir /data --nat extip:$IP_ADDR --syncmode 'full' --revertreason --port 30311 --http --http.addr "0.0.0.0" --http.corsdomain="*" -http.port %[4]s --http.vhosts "*" --http.api admin,personal,eth,net,web3,txpool,miner,debug,$QUORUM_API --networkid %[5]d --miner.gasprice 0 --password /data/password --mine --allow-insecure-unlock --verbosity 4 $CONSENSUS_ARGS $BOOTNODE_CMD $ADDITIONAL_ARGS`, consensus, tesseraCmd, discoveryCmd, QuorumPort, chainID, blockPeriod, blockPeriodInMs)
+exec geth --datadir /data --nat extip:$IP_ADDR --syncmode 'full' --revertreason --port %[8]d --http --http.addr "0.0.0.0" --http.corsdomain="*" -http.port %[4]s --http.vhosts "*" --http.api admin,personal,eth,net,web3,txpool,miner,debug,$QUORUM_API --networkid %[5]d --miner.gasprice 0 --password /data/password --allow-insecure-unlock --verbosity 4 $CONSENSUS_ARGS $BOOTNODE_CMD $ADDITIONAL_ARGS`, consensus, tesseraCmd, discoveryCmd, QuorumPort, chainID, blockPeriod, blockPeriodInMs, QuorumP2PPort, RaftPort)
 	filename := filepath.Join(outputDirectory, DockerEntrypoint)
 	if err := os.MkdirAll(outputDirectory, 0755); err != nil {
 		return err
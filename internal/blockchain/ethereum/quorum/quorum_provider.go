@@ -19,6 +19,7 @@ package quorum
 import (
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path"
@@ -32,6 +33,7 @@ import (
 	"github.com/hyperledger/firefly-cli/internal/blockchain/ethereum/connector/ethconnect"
 	"github.com/hyperledger/firefly-cli/internal/blockchain/ethereum/connector/evmconnect"
 	"github.com/hyperledger/firefly-cli/internal/blockchain/ethereum/tessera"
+	"github.com/hyperledger/firefly-cli/internal/constants"
 	"github.com/hyperledger/firefly-cli/internal/docker"
 	"github.com/hyperledger/firefly-cli/internal/log"
 	"github.com/hyperledger/firefly-cli/pkg/types"
@@ -41,9 +43,6 @@ var quorumImage = "quorumengineering/quorum:24.4"
 var tesseraImage = "quorumengineering/tessera:24.4"
 var ExposedBlockchainPortMultiplier = 10
 
-// TODO: Probably randomize this and make it different per member?
-var keyPassword = "correcthorsebatterystaple"
-
 type QuorumProvider struct {
 	ctx       context.Context
 	stack     *types.Stack
@@ -55,9 +54,9 @@ func NewQuorumProvider(ctx context.Context, stack *types.Stack) *QuorumProvider
 	var connector connector.Connector
 	switch stack.BlockchainConnector {
 	case types.BlockchainConnectorEthconnect:
-		connector = ethconnect.NewEthconnect(ctx)
+		connector = ethconnect.NewEthconnect(ctx, stack)
 	case types.BlockchainConnectorEvmconnect:
-		connector = evmconnect.NewEvmconnect(ctx)
+		connector = evmconnect.NewEvmconnect(ctx, stack)
 	}
 
 	return &QuorumProvider{
@@ -74,7 +73,7 @@ func (p *QuorumProvider) WriteConfig(options *types.InitOptions) error {
 	for i, member := range p.stack.Members {
 		// Generate the connector config for each member
 		connectorConfigPath := filepath.Join(initDir, "config", fmt.Sprintf("%s_%v.yaml", p.connector.Name(), i))
-		if err := p.connector.GenerateConfig(p.stack, member, fmt.Sprintf("quorum_%d", i)).WriteConfig(connectorConfigPath, options.ExtraConnectorConfigPath); err != nil {
+		if err := p.connector.GenerateConfig(p.stack, member, fmt.Sprintf("quorum_%d", i)).WriteConfig(connectorConfigPath, options.ConnectorConfigPaths(i)...); err != nil {
 			return nil
 		}
 
@@ -102,7 +101,22 @@ func (p *QuorumProvider) WriteConfig(options *types.InitOptions) error {
 		// Drop the 0x on the front of the address here because that's what quorum is expecting in the genesis.json
 		addresses[i] = address[2:]
 	}
-	genesis := CreateGenesis(addresses, options.BlockPeriod, p.stack.ChainID())
+	var genesis *Genesis
+	switch {
+	case p.stack.Consensus.Equals(types.ConsensusQbft):
+		validators, err := p.writeNodeKeys(initDir)
+		if err != nil {
+			return err
+		}
+		genesis = CreateQBFTGenesis(validators, addresses, options.BlockPeriod, p.stack.ChainID())
+	case p.stack.Consensus.Equals(types.ConsensusRaft):
+		if _, err := p.writeNodeKeys(initDir); err != nil {
+			return err
+		}
+		genesis = CreateRaftGenesis(addresses, p.stack.ChainID())
+	default:
+		genesis = CreateGenesis(addresses, options.BlockPeriod, p.stack.ChainID())
+	}
 	if err := genesis.WriteGenesisJSON(filepath.Join(initDir, "blockchain", "genesis.json")); err != nil {
 		return err
 	}
@@ -110,6 +124,34 @@ func (p *QuorumProvider) WriteConfig(options *types.InitOptions) error {
 	return nil
 }
 
+// usesNodeKeys is true for the consensus algorithms where the identity of each node is part of the chain config,
+// so the node keys are generated up front rather than by geth on first start
+func (p *QuorumProvider) usesNodeKeys() bool {
+	return p.stack.Consensus.Equals(types.ConsensusQbft) || p.stack.Consensus.Equals(types.ConsensusRaft)
+}
+
+// writeNodeKeys generates a node key for each member's node, and the static nodes file the nodes peer with, which
+// is also the Raft cluster. It returns the node addresses, which are the QBFT validators.
+func (p *QuorumProvider) writeNodeKeys(initDir string) ([]string, error) {
+	validators := make([]string, len(p.stack.Members))
+	staticNodes := make([]string, len(p.stack.Members))
+	for i := range p.stack.Members {
+		nodeKey := ethereum.GenerateNodeKey()
+		// geth expects the node key without the 0x prefix
+		if err := os.WriteFile(filepath.Join(initDir, "blockchain", fmt.Sprintf("quorum_%d", i), "nodekey"), []byte(nodeKey.PrivateKey[2:]), 0755); err != nil {
+			return nil, err
+		}
+		validators[i] = nodeKey.Address[2:]
+		staticNodes[i] = fmt.Sprintf("enode://%s@%s:%d?discport=0&raftport=%d", nodeKey.PublicKey, p.stack.ServiceName(fmt.Sprintf("quorum_%d", i)), QuorumP2PPort, RaftPort)
+	}
+
+	staticNodesBytes, _ := json.MarshalIndent(staticNodes, "", " ")
+	if err := os.WriteFile(filepath.Join(initDir, "blockchain", "static-nodes.json"), staticNodesBytes, 0755); err != nil {
+		return nil, err
+	}
+	return validators, nil
+}
+
 func (p *QuorumProvider) FirstTimeSetup() error {
 	quorumVolumeName := fmt.Sprintf("%s_quorum", p.stack.Name)
 	tesseraVolumeName := fmt.Sprintf("%s_tessera", p.stack.Name)
@@ -171,6 +213,19 @@ func (p *QuorumProvider) FirstTimeSetup() error {
 			return err
 		}
 
+		if p.usesNodeKeys() {
+			// Copy the node key to where geth looks for it, and the static nodes that the nodes peer with
+			if err := p.dockerMgr.MkdirInVolume(p.ctx, quorumVolumeNameMember, "geth"); err != nil {
+				return err
+			}
+			if err := p.dockerMgr.CopyFileToVolume(p.ctx, quorumVolumeNameMember, path.Join(blockchainDir, fmt.Sprintf("quorum_%d", i), "nodekey"), "geth/nodekey"); err != nil {
+				return err
+			}
+			if err := p.dockerMgr.CopyFileToVolume(p.ctx, quorumVolumeNameMember, path.Join(blockchainDir, "static-nodes.json"), "static-nodes.json"); err != nil {
+				return err
+			}
+		}
+
 		// Initialize the genesis block
 		if err := p.dockerMgr.RunDockerCommand(p.ctx, p.stack.StackDir, "run", "--rm", "-v", fmt.Sprintf("%s:/data", quorumVolumeNameMember), quorumImage, "--datadir", "/data", "init", "/data/genesis.json"); err != nil {
 			return err
@@ -198,7 +253,7 @@ func (p *QuorumProvider) PostStart(firstTimeSetup bool) error {
 				break
 			}
 		}
-		if err := p.unlockAccount(address, keyPassword, memberIndex); err != nil {
+		if err := p.unlockAccount(address, p.stack.KeystorePassword(), memberIndex); err != nil {
 			return err
 		}
 	}
@@ -272,6 +327,13 @@ func (p *QuorumProvider) GetDockerServiceDefinitions() []*docker.ServiceDefiniti
 	}
 	serviceDefinitions := make([]*docker.ServiceDefinition, serviceDefinitionsCount)
 	connectorDependents := map[string]string{}
+	environment := p.stack.EnvironmentVars
+	if p.stack.PrometheusEnabled {
+		// The entrypoint passes ADDITIONAL_ARGS on to geth
+		environment = p.stack.ConcatenateWithProvidedEnvironmentVars(map[string]interface{}{
+			"ADDITIONAL_ARGS": fmt.Sprintf("--metrics --metrics.addr 0.0.0.0 --metrics.port %d", constants.GethMetricsPort),
+		})
+	}
 	for i := 0; i < memberCount; i++ {
 		var quorumDependsOn map[string]map[string]string
 		if p.stack.PrivateTransactionManager.Equals(types.PrivateTransactionManagerTessera) {
@@ -290,7 +352,7 @@ func (p *QuorumProvider) GetDockerServiceDefinitions() []*docker.ServiceDefiniti
 				Volumes:       []string{fmt.Sprintf("quorum_%d:/data", i)},
 				Logging:       docker.StandardLogOptions,
 				Ports:         []string{fmt.Sprintf("%d:8545", p.stack.ExposedBlockchainPort+(i*ExposedBlockchainPortMultiplier))}, // defaults 5100, 5110, 5120, 5130
-				Environment:   p.stack.EnvironmentVars,
+				Environment:   environment,
 				EntryPoint:    []string{"/bin/sh", "-c", "/data/docker-entrypoint.sh"},
 				DependsOn:     quorumDependsOn,
 			},
@@ -374,7 +436,7 @@ func (p *QuorumProvider) CreateAccount(args []string) (interface{}, error) {
 
 	prefix := strconv.FormatInt(time.Now().UnixNano(), 10)
 	outputDirectory := filepath.Join(directory, "blockchain", fmt.Sprintf("quorum_%s", memberIndex), "keystore")
-	keyPair, walletFilePath, err := ethereum.CreateWalletFile(outputDirectory, prefix, keyPassword)
+	keyPair, walletFilePath, err := ethereum.CreateWalletFile(outputDirectory, prefix, p.stack.KeystorePassword())
 	if err != nil {
 		return nil, err
 	}
@@ -397,7 +459,7 @@ func (p *QuorumProvider) CreateAccount(args []string) (interface{}, error) {
 		if memberIndexInt, err := strconv.Atoi(memberIndex); err != nil {
 			return nil, err
 		} else {
-			if err := p.unlockAccount(keyPair.Address.String(), keyPassword, memberIndexInt); err != nil {
+			if err := p.unlockAccount(keyPair.Address.String(), p.stack.KeystorePassword(), memberIndexInt); err != nil {
 				return nil, err
 			}
 		}
@@ -430,9 +492,9 @@ func (p *QuorumProvider) GetConnectorName() string {
 }
 
 func (p *QuorumProvider) GetConnectorURL(org *types.Organization) string {
-	return fmt.Sprintf("http://%s_%s:%v", p.connector.Name(), org.ID, p.connector.Port())
+	return fmt.Sprintf("%s://%s_%s:%v", p.stack.HTTPScheme(), p.connector.Name(), org.ID, p.connector.Port())
 }
 
 func (p *QuorumProvider) GetConnectorExternalURL(org *types.Organization) string {
-	return fmt.Sprintf("http://127.0.0.1:%v", org.ExposedConnectorPort)
+	return fmt.Sprintf("%s://127.0.0.1:%v", p.stack.HTTPScheme(), org.ExposedConnectorPort)
 }
@@ -3,6 +3,7 @@ package quorum
 import (
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -281,7 +282,7 @@ func TestGetConnectorExternal(t *testing.T) {
 		},
 	}
 	for _, tc := range testcase {
-		p := &QuorumProvider{}
+		p := &QuorumProvider{stack: &types.Stack{}}
 		result := p.GetConnectorExternalURL(tc.Org)
 		assert.Equal(t, tc.ExpectedPort, result)
 	}
@@ -638,3 +639,51 @@ func TestWriteConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteConfigNodeKeys(t *testing.T) {
+	ctx := log.WithVerbosity(log.WithLogger(context.Background(), &log.StdoutLogger{}), false)
+	for _, consensus := range []fftypes.FFEnum{types.ConsensusQbft, types.ConsensusRaft} {
+		t.Run(consensus.String(), func(t *testing.T) {
+			stack := &types.Stack{
+				Name:                      "Org-1_quorum",
+				BlockchainProvider:        types.BlockchainProviderEthereum,
+				BlockchainConnector:       types.BlockchainConnectorEvmconnect,
+				BlockchainNodeProvider:    types.BlockchainNodeProviderQuorum,
+				Consensus:                 consensus,
+				InitDir:                   t.TempDir(),
+				PrivateTransactionManager: types.PrivateTransactionManagerNone,
+				Members: []*types.Organization{
+					{
+						Index:   &[]int{0}[0],
+						Account: &ethereum.Account{Address: "0x1234567890abcdef0123456789abcdef6789abcd"},
+					},
+					{
+						Index:   &[]int{1}[0],
+						Account: &ethereum.Account{Address: "0x618E98197aF52F44D1B05Af0952a59b9f702dea4"},
+					},
+				},
+			}
+			p := NewQuorumProvider(ctx, stack)
+			err := p.WriteConfig(&types.InitOptions{BlockPeriod: 5})
+			assert.NoError(t, err)
+
+			var staticNodes []string
+			staticNodesBytes, err := os.ReadFile(filepath.Join(stack.InitDir, "blockchain", "static-nodes.json"))
+			assert.NoError(t, err)
+			assert.NoError(t, json.Unmarshal(staticNodesBytes, &staticNodes))
+			assert.Regexp(t, "^enode://[0-9a-f]{128}@quorum_1:30311\\?discport=0&raftport=53000$", staticNodes[1])
+
+			nodeKey, err := os.ReadFile(filepath.Join(stack.InitDir, "blockchain", "quorum_0", "nodekey"))
+			assert.NoError(t, err)
+			assert.Len(t, nodeKey, 64)
+
+			var genesis Genesis
+			genesisBytes, err := os.ReadFile(filepath.Join(stack.InitDir, "blockchain", "genesis.json"))
+			assert.NoError(t, err)
+			assert.NoError(t, json.Unmarshal(genesisBytes, &genesis))
+			assert.Nil(t, genesis.Config.Clique)
+			assert.Equal(t, consensus.Equals(types.ConsensusQbft), genesis.Config.Qbft != nil)
+			assert.Equal(t, consensus.Equals(types.ConsensusRaft), genesis.Config.IsQuorum)
+		})
+	}
+}
@@ -26,7 +26,6 @@ import (
 	"github.com/hyperledger/firefly-cli/internal/blockchain/ethereum/connector/ethconnect"
 	"github.com/hyperledger/firefly-cli/internal/blockchain/ethereum/connector/evmconnect"
 	"github.com/hyperledger/firefly-cli/internal/blockchain/ethereum/ethsigner"
-	"github.com/hyperledger/firefly-cli/internal/constants"
 	"github.com/hyperledger/firefly-cli/internal/docker"
 	"github.com/hyperledger/firefly-cli/pkg/types"
 )
@@ -42,9 +41,9 @@ func NewRemoteRPCProvider(ctx context.Context, stack *types.Stack) *RemoteRPCPro
 	var connector connector.Connector
 	switch stack.BlockchainConnector {
 	case types.BlockchainConnectorEthconnect:
-		connector = ethconnect.NewEthconnect(ctx)
+		connector = ethconnect.NewEthconnect(ctx, stack)
 	case types.BlockchainConnectorEvmconnect:
-		connector = evmconnect.NewEvmconnect(ctx)
+		connector = evmconnect.NewEvmconnect(ctx, stack)
 	}
 
 	return &RemoteRPCProvider{
@@ -56,12 +55,12 @@ func NewRemoteRPCProvider(ctx context.Context, stack *types.Stack) *RemoteRPCPro
 }
 
 func (p *RemoteRPCProvider) WriteConfig(options *types.InitOptions) error {
-	initDir := filepath.Join(constants.StacksDir, p.stack.Name, "init")
+	initDir := p.stack.InitDir
 	for i, member := range p.stack.Members {
 
 		// Generate the connector config for each member
 		connectorConfigPath := filepath.Join(initDir, "config", fmt.Sprintf("%s_%v.yaml", p.connector.Name(), i))
-		if err := p.connector.GenerateConfig(p.stack, member, "ethsigner").WriteConfig(connectorConfigPath, options.ExtraConnectorConfigPath); err != nil {
+		if err := p.connector.GenerateConfig(p.stack, member, p.stack.ServiceName("ethsigner")).WriteConfig(connectorConfigPath, options.ConnectorConfigPaths(i)...); err != nil {
 			return err
 		}
 
@@ -81,7 +80,7 @@ func (p *RemoteRPCProvider) FirstTimeSetup() error {
 
 	for i := range p.stack.Members {
 		// Copy connector config to each member's volume
-		connectorConfigPath := filepath.Join(p.stack.StackDir, "runtime", "config", fmt.Sprintf("%s_%v.yaml", p.connector.Name(), i))
+		connectorConfigPath := filepath.Join(p.stack.RuntimeDir, "config", fmt.Sprintf("%s_%v.yaml", p.connector.Name(), i))
 		connectorConfigVolumeName := fmt.Sprintf("%s_%s_config_%v", p.stack.Name, p.connector.Name(), i)
 		if err := docker.CopyFileToVolume(p.ctx, connectorConfigVolumeName, connectorConfigPath, "config.yaml"); err != nil {
 			return err
@@ -182,11 +181,11 @@ func (p *RemoteRPCProvider) GetConnectorName() string {
 }
 
 func (p *RemoteRPCProvider) GetConnectorURL(org *types.Organization) string {
-	return fmt.Sprintf("http://%s_%s:%v", p.connector.Name(), org.ID, p.connector.Port())
+	return fmt.Sprintf("%s://%s:%v", p.stack.HTTPScheme(), p.stack.ServiceName(fmt.Sprintf("%s_%s", p.connector.Name(), org.ID)), p.connector.Port())
 }
 
 func (p *RemoteRPCProvider) GetConnectorExternalURL(org *types.Organization) string {
-	return fmt.Sprintf("http://127.0.0.1:%v", org.ExposedConnectorPort)
+	return fmt.Sprintf("%s://127.0.0.1:%v", p.stack.HTTPScheme(), org.ExposedConnectorPort)
 }
 
 func (p *RemoteRPCProvider) ParseAccount(account interface{}) interface{} {
@@ -43,7 +43,7 @@ func GenerateDockerServiceDefinitions(s *types.Stack) []*docker.ServiceDefinitio
 					"7054:7054",
 					"17054:17054",
 				},
-				Command: "sh -c 'fabric-ca-server start -b admin:adminpw'",
+				Command: fmt.Sprintf("sh -c 'fabric-ca-server start -b admin:%s'", s.FabricCAAdminPassword()),
 				Volumes: []string{
 					"firefly_fabric:/etc/firefly",
 				},
@@ -82,7 +82,7 @@ func (p *FabricProvider) WriteConfig(options *types.InitOptions) error {
 		if err := WriteCryptogenConfig(len(p.stack.Members), cryptogenYamlPath); err != nil {
 			return err
 		}
-		if err := WriteNetworkConfig(path.Join(blockchainDirectory, "ccp.yaml")); err != nil {
+		if err := WriteNetworkConfig(path.Join(blockchainDirectory, "ccp.yaml"), p.stack.FabricCAAdminPassword()); err != nil {
 			return err
 		}
 		if err := p.writeConfigtxYaml(); err != nil {
@@ -347,6 +347,23 @@ func (p *FabricProvider) createChannel() error {
 	)
 }
 
+// PeerCLIDockerArgs returns the arguments for "docker run" to run a fabric-tools container with the peer CLI set up
+// to act as the admin of the stack's organization, in the same way as the CLI does when it deploys chaincode. The
+// stack may be the copy for an additional blockchain, whose services are on the network of the stack it belongs to.
+func PeerCLIDockerArgs(stack *types.Stack) []string {
+	return []string{
+		"--rm",
+		fmt.Sprintf("--network=%s_default", stack.Root().Name),
+		"-e", fmt.Sprintf("CORE_PEER_ADDRESS=%s:7051", stack.ServiceName("fabric_peer")),
+		"-e", "CORE_PEER_TLS_ENABLED=true",
+		"-e", "CORE_PEER_TLS_ROOTCERT_FILE=/etc/firefly/organizations/peerOrganizations/org1.example.com/peers/fabric_peer.org1.example.com/tls/ca.crt",
+		"-e", "CORE_PEER_LOCALMSPID=Org1MSP",
+		"-e", "CORE_PEER_MSPCONFIGPATH=/etc/firefly/organizations/peerOrganizations/org1.example.com/users/Admin@org1.example.com/msp",
+		"-v", fmt.Sprintf("%s_firefly_fabric:/etc/firefly", stack.Name),
+		FabricToolsImageName,
+	}
+}
+
 func (p *FabricProvider) joinChannel() error {
 	p.log.Info("joining channel")
 	stackDir := p.stack.StackDir
@@ -404,3 +404,24 @@ func TestRegisterIdentity(t *testing.T) {
 	})
 
 }
+
+func TestPeerCLIDockerArgs(t *testing.T) {
+	stack := &types.Stack{
+		Name:                  "dev",
+		BlockchainProvider:    types.BlockchainProviderEthereum,
+		AdditionalBlockchains: []*types.AdditionalBlockchain{{BlockchainProvider: types.BlockchainProviderFabric}},
+	}
+	args := PeerCLIDockerArgs(stack)
+	assert.Contains(t, args, "--network=dev_default")
+	assert.Contains(t, args, "CORE_PEER_ADDRESS=fabric_peer:7051")
+	assert.Contains(t, args, "dev_firefly_fabric:/etc/firefly")
+
+	// The services of a prefixed copy for an additional blockchain are on the network of the stack it belongs to
+	chainStack := stack.AdditionalBlockchainStack(1)
+	chainStack.Name = "dev_chain1"
+	chainStack.ServicePrefix = "chain1_"
+	args = PeerCLIDockerArgs(chainStack)
+	assert.Contains(t, args, "--network=dev_default")
+	assert.Contains(t, args, "CORE_PEER_ADDRESS=chain1_fabric_peer:7051")
+	assert.Contains(t, args, "dev_chain1_firefly_fabric:/etc/firefly")
+}
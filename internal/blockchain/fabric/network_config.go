@@ -110,7 +110,7 @@ type FabricNetworkConfig struct {
 	Version                string                    `yaml:"version,omitempty"`
 }
 
-func WriteNetworkConfig(outputPath string) error {
+func WriteNetworkConfig(outputPath, caAdminPassword string) error {
 	networkConfig := &FabricNetworkConfig{
 		CertificateAuthorities: map[string]*NetworkEntity{
 			"org1.example.com": {
@@ -120,7 +120,7 @@ func WriteNetworkConfig(outputPath string) error {
 				URL: "http://fabric_ca:7054",
 				Registrar: &Registrar{
 					EnrollID:     "admin",
-					EnrollSecret: "adminpw",
+					EnrollSecret: caAdminPassword,
 				},
 			},
 		},
@@ -0,0 +1,326 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly-cli/internal/blockchain"
+	"github.com/hyperledger/firefly-cli/internal/docker"
+	"github.com/hyperledger/firefly-cli/internal/log"
+	"github.com/hyperledger/firefly-cli/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// ExecutablePrefix is the start of the name of the executable of a blockchain plugin, which is followed by the name
+// of the blockchain provider it adds, such as ff-blockchain-mychain
+const ExecutablePrefix = "ff-blockchain-"
+
+// defaultCallTimeout is how long a blockchain plugin has to respond to a request before it is killed. It allows for
+// methods such as firstTimeSetup, which may deploy contracts.
+const defaultCallTimeout = 10 * time.Minute
+
+// PluginProvider is a blockchain provider that is implemented by an external process. The CLI starts the process
+// for each method of IBlockchainProvider it calls, and sends it a JSON-RPC 2.0 request as a line on its stdin,
+// which is then closed. The process writes the response as a line on its stdout and exits. Anything the process
+// writes to its stderr is passed through to the user, and included in the error if the process fails.
+//
+// The method names are the IBlockchainProvider methods starting with a lower case letter, such as writeConfig and
+// getDockerServiceDefinitions. The params of every request are an object with the stack, its stackDir, initDir and
+// runtimeDir, and the arguments of the method, so the process does not need to keep any state between requests.
+// The process is killed if it has not exited within the timeout of the call.
+type PluginProvider struct {
+	ctx        context.Context
+	stack      *types.Stack
+	executable string
+	timeout    time.Duration
+	mux        sync.Mutex
+	nextID     int
+}
+
+type rpcRequest struct {
+	JSONRPC string                 `json:"jsonrpc"`
+	ID      int                    `json:"id"`
+	Method  string                 `json:"method"`
+	Params  map[string]interface{} `json:"params"`
+}
+
+type rpcResponse struct {
+	ID     int             `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// pluginServiceDefinition is how a plugin returns the docker compose services of a stack, where the service has
+// the same keys as a service in a docker compose file
+type pluginServiceDefinition struct {
+	ServiceName string                 `json:"serviceName"`
+	Service     map[string]interface{} `json:"service"`
+	VolumeNames []string               `json:"volumeNames"`
+}
+
+func NewPluginProvider(ctx context.Context, stack *types.Stack, executable string) *PluginProvider {
+	return &PluginProvider{
+		ctx:        ctx,
+		stack:      stack,
+		executable: executable,
+		timeout:    defaultCallTimeout,
+	}
+}
+
+// Discover returns the blockchain plugins in a directory, by the name of the blockchain provider each one adds
+func Discover(dir string) map[string]string {
+	plugins := make(map[string]string)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return plugins
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, ExecutablePrefix) {
+			continue
+		}
+		if runtime.GOOS == "windows" {
+			if !strings.HasSuffix(name, ".exe") {
+				continue
+			}
+			name = strings.TrimSuffix(name, ".exe")
+		} else if info, err := entry.Info(); err != nil || info.Mode()&0111 == 0 {
+			continue
+		}
+		plugins[strings.TrimPrefix(name, ExecutablePrefix)] = filepath.Join(dir, entry.Name())
+	}
+	return plugins
+}
+
+// call runs the plugin to send it a request for a method, with the stack and the arguments of the method as its
+// params, and decodes the result of the response into result, unless it is nil
+func (p *PluginProvider) call(method string, args map[string]interface{}, stack *types.Stack, result interface{}) error {
+	params := map[string]interface{}{
+		"stack":      stack,
+		"stackDir":   stack.StackDir,
+		"initDir":    stack.InitDir,
+		"runtimeDir": stack.RuntimeDir,
+	}
+	for k, v := range args {
+		params[k] = v
+	}
+	p.mux.Lock()
+	p.nextID++
+	id := p.nextID
+	p.mux.Unlock()
+	request, err := json.Marshal(&rpcRequest{JSONRPC: "2.0", ID: id, Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+	log.LoggerFromContext(p.ctx).Trace(fmt.Sprintf("blockchain plugin request: %s", request))
+
+	ctx, cancel := context.WithTimeout(p.ctx, p.timeout)
+	defer cancel()
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.executable)
+	cmd.Stdin = bytes.NewReader(append(request, '\n'))
+	cmd.Stdout = &stdout
+	cmd.Stderr = io.MultiWriter(os.Stderr, &stderr)
+	// The process is killed when the context is done, after which its output is only waited for briefly in case
+	// it has started child processes that still hold its stdout or stderr open
+	cmd.WaitDelay = 5 * time.Second
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start blockchain plugin %s: %s", p.executable, err)
+	}
+	if err := cmd.Wait(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			err = fmt.Errorf("timed out after %s", p.timeout)
+		}
+		return fmt.Errorf("%s failed in blockchain plugin %s: %s%s", method, p.executable, err, stderrDetail(stderr.String()))
+	}
+
+	line, err := bufio.NewReader(&stdout).ReadBytes('\n')
+	if err != nil && err != io.EOF {
+		return fmt.Errorf("failed to read the response to %s from blockchain plugin %s: %s", method, p.executable, err)
+	}
+	log.LoggerFromContext(p.ctx).Trace(fmt.Sprintf("blockchain plugin response: %s", line))
+	var response rpcResponse
+	if err := json.Unmarshal(line, &response); err != nil {
+		return fmt.Errorf("invalid response to %s from blockchain plugin %s: %s%s", method, p.executable, err, stderrDetail(stderr.String()))
+	}
+	if response.ID != id {
+		return fmt.Errorf("response from blockchain plugin %s has id %d, expected %d", p.executable, response.ID, id)
+	}
+	if response.Error != nil {
+		return fmt.Errorf("%s failed in blockchain plugin %s: %s", method, p.executable, response.Error.Message)
+	}
+	if result == nil || len(response.Result) == 0 {
+		return nil
+	}
+	return json.Unmarshal(response.Result, result)
+}
+
+// stderrDetail formats what a plugin wrote to its stderr to be appended to an error
+func stderrDetail(stderr string) string {
+	stderr = strings.TrimSpace(stderr)
+	if stderr == "" {
+		return ""
+	}
+	return fmt.Sprintf(": %s", stderr)
+}
+
+// callNoError is for the methods of IBlockchainProvider that cannot return an error, which log it instead
+func (p *PluginProvider) callNoError(method string, args map[string]interface{}, stack *types.Stack, result interface{}) {
+	if err := p.call(method, args, stack, result); err != nil {
+		log.LoggerFromContext(p.ctx).Error(err)
+	}
+}
+
+func (p *PluginProvider) WriteConfig(options *types.InitOptions) error {
+	return p.call("writeConfig", map[string]interface{}{"options": options}, p.stack, nil)
+}
+
+func (p *PluginProvider) FirstTimeSetup() error {
+	return p.call("firstTimeSetup", nil, p.stack, nil)
+}
+
+func (p *PluginProvider) DeployFireFlyContract() (*types.ContractDeploymentResult, error) {
+	var result *types.ContractDeploymentResult
+	err := p.call("deployFireFlyContract", nil, p.stack, &result)
+	return result, err
+}
+
+func (p *PluginProvider) PreStart() error {
+	return p.call("preStart", nil, p.stack, nil)
+}
+
+func (p *PluginProvider) PostStart(firstTimeSetup bool) error {
+	return p.call("postStart", map[string]interface{}{"firstTimeSetup": firstTimeSetup}, p.stack, nil)
+}
+
+func (p *PluginProvider) GetDockerServiceDefinitions() []*docker.ServiceDefinition {
+	var definitions []*pluginServiceDefinition
+	p.callNoError("getDockerServiceDefinitions", nil, p.stack, &definitions)
+	serviceDefinitions := make([]*docker.ServiceDefinition, 0, len(definitions))
+	for _, definition := range definitions {
+		// Convert the service through YAML so it is read with the same keys as in a docker compose file
+		serviceBytes, err := yaml.Marshal(definition.Service)
+		if err != nil {
+			log.LoggerFromContext(p.ctx).Error(err)
+			continue
+		}
+		var service docker.Service
+		if err := yaml.Unmarshal(serviceBytes, &service); err != nil {
+			log.LoggerFromContext(p.ctx).Error(fmt.Errorf("invalid service '%s' from blockchain plugin %s: %s", definition.ServiceName, p.executable, err))
+			continue
+		}
+		serviceDefinitions = append(serviceDefinitions, &docker.ServiceDefinition{
+			ServiceName: definition.ServiceName,
+			Service:     &service,
+			VolumeNames: definition.VolumeNames,
+		})
+	}
+	return serviceDefinitions
+}
+
+func (p *PluginProvider) GetBlockchainPluginConfig(stack *types.Stack, org *types.Organization) (blockchainConfig *types.BlockchainConfig) {
+	p.callNoError("getBlockchainPluginConfig", map[string]interface{}{"org": org}, stack, &blockchainConfig)
+	return blockchainConfig
+}
+
+func (p *PluginProvider) GetOrgConfig(stack *types.Stack, org *types.Organization) (coreConfig *types.OrgConfig) {
+	p.callNoError("getOrgConfig", map[string]interface{}{"org": org}, stack, &coreConfig)
+	return coreConfig
+}
+
+func (p *PluginProvider) Reset() error {
+	return p.call("reset", nil, p.stack, nil)
+}
+
+func (p *PluginProvider) GetContracts(filename string, extraArgs []string) ([]string, error) {
+	var contracts []string
+	err := p.call("getContracts", map[string]interface{}{"filename": filename, "extraArgs": extraArgs}, p.stack, &contracts)
+	return contracts, err
+}
+
+func (p *PluginProvider) DeployContract(filename, contractName, instanceName string, member *types.Organization, extraArgs []string) (*types.ContractDeploymentResult, error) {
+	var result *types.ContractDeploymentResult
+	err := p.call("deployContract", map[string]interface{}{
+		"filename":     filename,
+		"contractName": contractName,
+		"instanceName": instanceName,
+		"member":       member,
+		"extraArgs":    extraArgs,
+	}, p.stack, &result)
+	return result, err
+}
+
+func (p *PluginProvider) CreateAccount(args []string) (interface{}, error) {
+	var account interface{}
+	err := p.call("createAccount", map[string]interface{}{"args": args}, p.stack, &account)
+	return account, err
+}
+
+func (p *PluginProvider) ParseAccount(account interface{}) interface{} {
+	var parsed interface{}
+	if err := p.call("parseAccount", map[string]interface{}{"account": account}, p.stack, &parsed); err != nil {
+		log.LoggerFromContext(p.ctx).Error(err)
+		return account
+	}
+	return parsed
+}
+
+func (p *PluginProvider) GetConnectorName() string {
+	var name string
+	p.callNoError("getConnectorName", nil, p.stack, &name)
+	return name
+}
+
+func (p *PluginProvider) GetConnectorURL(org *types.Organization) string {
+	var url string
+	p.callNoError("getConnectorURL", map[string]interface{}{"org": org}, p.stack, &url)
+	return url
+}
+
+func (p *PluginProvider) GetConnectorExternalURL(org *types.Organization) string {
+	var url string
+	p.callNoError("getConnectorExternalURL", map[string]interface{}{"org": org}, p.stack, &url)
+	return url
+}
+
+// Register registers each of the plugins in a directory as the blockchain provider it is named after
+func Register(dir string) {
+	for name, executable := range Discover(dir) {
+		executable := executable
+		blockchain.Register(name, func(ctx context.Context, stack *types.Stack) blockchain.IBlockchainProvider {
+			return NewPluginProvider(ctx, stack, executable)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package plugin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-cli/internal/log"
+	"github.com/hyperledger/firefly-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMain runs the test binary as a fake plugin when it is started by a PluginProvider in one of the tests
+func TestMain(m *testing.M) {
+	if os.Getenv("FF_TEST_PLUGIN") == "1" {
+		runFakePlugin()
+		return
+	}
+	os.Exit(m.Run())
+}
+
+func runFakePlugin() {
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		var request rpcRequest
+		if err := json.Unmarshal(scanner.Bytes(), &request); err != nil {
+			os.Exit(1)
+		}
+		response := map[string]interface{}{"jsonrpc": "2.0", "id": request.ID}
+		switch request.Method {
+		case "getConnectorName":
+			response["result"] = "testconnect"
+		case "getConnectorURL":
+			org := request.Params["org"].(map[string]interface{})
+			response["result"] = fmt.Sprintf("http://testconnect_%s:5102", org["id"])
+		case "getDockerServiceDefinitions":
+			response["result"] = []interface{}{map[string]interface{}{
+				"serviceName": "testchain",
+				"service":     map[string]interface{}{"image": "testchain:latest", "container_name": "test_testchain"},
+				"volumeNames": []string{"testchain_data"},
+			}}
+		case "writeConfig":
+			response["result"] = request.Params["initDir"]
+		case "reset":
+			fmt.Fprintln(os.Stderr, "cannot remove volumes")
+			os.Exit(3)
+		case "firstTimeSetup":
+			time.Sleep(time.Minute)
+		default:
+			response["error"] = map[string]interface{}{"code": -32601, "message": "method not found"}
+		}
+		responseBytes, _ := json.Marshal(response)
+		fmt.Println(string(responseBytes))
+	}
+}
+
+func newTestPluginProvider(t *testing.T) *PluginProvider {
+	executable, err := os.Executable()
+	assert.NoError(t, err)
+	t.Setenv("FF_TEST_PLUGIN", "1")
+	ctx := log.WithLogger(context.Background(), &log.StdoutLogger{})
+	stack := &types.Stack{Name: "test", InitDir: "/stacks/test/init"}
+	return NewPluginProvider(ctx, stack, executable)
+}
+
+func TestPluginProvider(t *testing.T) {
+	p := newTestPluginProvider(t)
+
+	assert.Equal(t, "testconnect", p.GetConnectorName())
+	assert.Equal(t, "http://testconnect_0:5102", p.GetConnectorURL(&types.Organization{ID: "0"}))
+
+	serviceDefinitions := p.GetDockerServiceDefinitions()
+	assert.Len(t, serviceDefinitions, 1)
+	assert.Equal(t, "testchain", serviceDefinitions[0].ServiceName)
+	assert.Equal(t, "testchain:latest", serviceDefinitions[0].Service.Image)
+	assert.Equal(t, "test_testchain", serviceDefinitions[0].Service.ContainerName)
+	assert.Equal(t, []string{"testchain_data"}, serviceDefinitions[0].VolumeNames)
+
+	var initDir string
+	assert.NoError(t, p.call("writeConfig", nil, p.stack, &initDir))
+	assert.Equal(t, "/stacks/test/init", initDir)
+
+	assert.Regexp(t, "unknownMethod failed in blockchain plugin .*: method not found", p.call("unknownMethod", nil, p.stack, nil))
+}
+
+func TestPluginProviderErrors(t *testing.T) {
+	p := newTestPluginProvider(t)
+	p.timeout = 500 * time.Millisecond
+
+	testCases := []struct {
+		Method string
+		Error  string
+	}{
+		{Method: "reset", Error: "reset failed in blockchain plugin .*: exit status 3: cannot remove volumes"},
+		{Method: "firstTimeSetup", Error: "firstTimeSetup failed in blockchain plugin .*: timed out after 500ms"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Method, func(t *testing.T) {
+			start := time.Now()
+			assert.Regexp(t, tc.Error, p.call(tc.Method, nil, p.stack, nil))
+			assert.Less(t, time.Since(start), 10*time.Second)
+		})
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "ff-blockchain-testchain"), []byte("#!/bin/sh\n"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "ff-blockchain-notexecutable"), []byte(""), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "other"), []byte("#!/bin/sh\n"), 0755))
+
+	plugins := Discover(dir)
+	assert.Equal(t, map[string]string{"testchain": filepath.Join(dir, "ff-blockchain-testchain")}, plugins)
+	assert.Empty(t, Discover(filepath.Join(dir, "missing")))
+}
@@ -0,0 +1,117 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/firefly-cli/pkg/types"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+// ProviderFactory creates the blockchain provider for a stack, or for the copy of a stack that is used for one of its
+// additional blockchains
+type ProviderFactory func(ctx context.Context, stack *types.Stack) IBlockchainProvider
+
+type registration struct {
+	factory ProviderFactory
+	nodes   map[string]ProviderFactory
+}
+
+var registry = make(map[string]*registration)
+
+// Register adds a blockchain provider, such as fabric, which is created for every stack whose blockchain provider
+// has its name. Its name becomes a valid --blockchain-provider. Registering a name again replaces the provider, so a
+// provider that is compiled in with a build tag, or loaded as a plugin, can replace a built in one.
+func Register(name string, factory ProviderFactory) {
+	getRegistration(name).factory = factory
+}
+
+// RegisterNode adds a node provider of a blockchain provider, such as geth for ethereum, which is created for every
+// stack with both the blockchain provider and the node provider. Its name becomes a valid --blockchain-node.
+func RegisterNode(name, node string, factory ProviderFactory) {
+	node = strings.ToLower(node)
+	addEnumValue(types.BlockchainNodeProvider, node)
+	getRegistration(name).nodes[node] = factory
+}
+
+func getRegistration(name string) *registration {
+	name = strings.ToLower(name)
+	addEnumValue(types.BlockchainProvider, name)
+	r, ok := registry[name]
+	if !ok {
+		r = &registration{nodes: make(map[string]ProviderFactory)}
+		registry[name] = r
+	}
+	return r
+}
+
+func addEnumValue(enum, value string) {
+	if _, err := fftypes.FFEnumParseString(context.Background(), enum, value); err != nil {
+		fftypes.FFEnumValue(enum, value)
+	}
+}
+
+// lookup returns the factory for a blockchain provider and node provider. A node provider that is registered for
+// the blockchain provider takes precedence, and otherwise the blockchain provider works with any node provider.
+func lookup(provider, node fftypes.FFEnum) (ProviderFactory, error) {
+	r, ok := registry[provider.String()]
+	if !ok {
+		return nil, fmt.Errorf("blockchain provider '%s' is not registered. Options are: %s", provider, strings.Join(Providers(), ", "))
+	}
+	if factory, ok := r.nodes[node.String()]; ok {
+		return factory, nil
+	}
+	if r.factory == nil {
+		nodes := make([]string, 0, len(r.nodes))
+		for n := range r.nodes {
+			nodes = append(nodes, n)
+		}
+		sort.Strings(nodes)
+		return nil, fmt.Errorf("blockchain node '%s' is not registered for blockchain provider '%s'. Options are: %s", node, provider, strings.Join(nodes, ", "))
+	}
+	return r.factory, nil
+}
+
+// Validate returns an error if there is no provider registered for a blockchain provider and node provider
+func Validate(provider, node string) error {
+	_, err := lookup(fftypes.FFEnum(provider), fftypes.FFEnum(node))
+	return err
+}
+
+// NewProvider creates the registered provider for the blockchain provider and node provider of a stack, or returns
+// nil if there is not one
+func NewProvider(ctx context.Context, stack *types.Stack) IBlockchainProvider {
+	factory, err := lookup(stack.BlockchainProvider, stack.BlockchainNodeProvider)
+	if err != nil {
+		return nil
+	}
+	return factory(ctx, stack)
+}
+
+// Providers returns the names of the registered blockchain providers
+func Providers() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,46 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/firefly-cli/pkg/types"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+	defer delete(registry, "testchain")
+	var created *types.Stack
+	Register("testchain", func(ctx context.Context, stack *types.Stack) IBlockchainProvider {
+		created = stack
+		return nil
+	})
+	RegisterNode("testchain", "testnode", func(ctx context.Context, stack *types.Stack) IBlockchainProvider {
+		return nil
+	})
+
+	_, err := fftypes.FFEnumParseString(context.Background(), types.BlockchainProvider, "testchain")
+	assert.NoError(t, err)
+	_, err = fftypes.FFEnumParseString(context.Background(), types.BlockchainNodeProvider, "testnode")
+	assert.NoError(t, err)
+	assert.Contains(t, Providers(), "testchain")
+
+	assert.NoError(t, Validate("testchain", "testnode"))
+	assert.NoError(t, Validate("testchain", "any"))
+	stack := &types.Stack{BlockchainProvider: "testchain"}
+	NewProvider(context.Background(), stack)
+	assert.Equal(t, stack, created)
+
+	assert.Regexp(t, "blockchain provider 'unknown' is not registered", Validate("unknown", ""))
+}
+
+func TestRegisterNodeOnly(t *testing.T) {
+	defer delete(registry, "nodechain")
+	RegisterNode("nodechain", "nodea", func(ctx context.Context, stack *types.Stack) IBlockchainProvider {
+		return nil
+	})
+	assert.NoError(t, Validate("nodechain", "nodea"))
+	assert.Regexp(t, "blockchain node 'nodeb' is not registered for blockchain provider 'nodechain'. Options are: nodea", Validate("nodechain", "nodeb"))
+	assert.Nil(t, NewProvider(context.Background(), &types.Stack{BlockchainProvider: "nodechain", BlockchainNodeProvider: "nodeb"}))
+}
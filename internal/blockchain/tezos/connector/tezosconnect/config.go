@@ -71,13 +71,13 @@ type ConfirmationsConfig struct {
 	FetchReceiptUponEntry bool `yaml:"fetchReceiptUponEntry,omitempty"`
 }
 
-func (c *Config) WriteConfig(filename string, extraTezosconnectConfigPath string) error {
+func (c *Config) WriteConfig(filename string, extraTezosconnectConfigPaths ...string) error {
 	configYamlBytes, _ := yaml.Marshal(c)
 	if err := os.WriteFile(filename, configYamlBytes, 0755); err != nil {
 		return err
 	}
-	if extraTezosconnectConfigPath != "" {
-		c, err := conflate.FromFiles(filename, extraTezosconnectConfigPath)
+	if len(extraTezosconnectConfigPaths) > 0 {
+		c, err := conflate.FromFiles(append([]string{filename}, extraTezosconnectConfigPaths...)...)
 		if err != nil {
 			return err
 		}
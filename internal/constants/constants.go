@@ -22,11 +22,41 @@ import (
 )
 
 var StacksDir = checkHome()
+
+// PluginsDir is where the executables of blockchain plugins are found, next to the stacks directory
+var PluginsDir = filepath.Join(filepath.Dir(StacksDir), "plugins")
+
 var FireFlyCoreImageName = "ghcr.io/hyperledger/firefly"
 var IPFSImageName = "ipfs/go-ipfs:v0.10.0"
 var PostgresImageName = "postgres"
 var PrometheusImageName = "prom/prometheus"
+var PostgresExporterImageName = "prometheuscommunity/postgres-exporter"
+var BlackboxExporterImageName = "prom/blackbox-exporter"
+var GrafanaImageName = "grafana/grafana"
+var OTelCollectorImageName = "otel/opentelemetry-collector"
+var JaegerImageName = "jaegertracing/all-in-one"
+var LokiImageName = "grafana/loki"
+var PromtailImageName = "grafana/promtail"
+
+// DoctorImageName is the small image that ff doctor runs to look at docker from inside a container
+var DoctorImageName = "busybox"
 var SandboxImageName = "ghcr.io/hyperledger/firefly-sandbox:latest"
+var TLSProxyImageName = "ghostunnel/ghostunnel"
+
+// TLSCertsDir is where each service's TLS certificate, key and the stack CA are mounted inside its container
+var TLSCertsDir = "/etc/firefly/tls"
+
+// HtpasswdFile is where a member's htpasswd file is mounted inside the containers that check its credentials
+var HtpasswdFile = "/etc/firefly/htpasswd"
+
+// GethMetricsPort is where geth and quorum nodes serve their metrics inside their containers when Prometheus is enabled
+var GethMetricsPort = 6060
+
+// BesuMetricsPort is where besu nodes serve their metrics inside their containers when Prometheus is enabled
+var BesuMetricsPort = 9545
+
+// OTelCollectorEndpoint is where the besu nodes of a stack with besu tracing enabled send their traces, using OTLP over gRPC
+var OTelCollectorEndpoint = "http://otel_collector:4317"
 
 func checkHome() string {
 	var homeDir, _ = os.UserHomeDir()
@@ -19,26 +19,66 @@ package core
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"time"
 
 	"github.com/hyperledger/firefly-cli/internal/log"
+	"github.com/hyperledger/firefly-cli/pkg/types"
 )
 
 var requestTimeout int = -1
 
+var tlsClientConfig *tls.Config
+
 func SetRequestTimeout(customRequestTimeoutSecs int) {
 	requestTimeout = customRequestTimeoutSecs
 }
 
+// SetTLSCACert adds the CA certificate in caFile to the set of CAs trusted for requests to HTTPS endpoints
+func SetTLSCACert(caFile string) error {
+	caBytes, err := os.ReadFile(caFile)
+	if err != nil {
+		return err
+	}
+	rootCAs, err := x509.SystemCertPool()
+	if err != nil {
+		rootCAs = x509.NewCertPool()
+	}
+	if !rootCAs.AppendCertsFromPEM(caBytes) {
+		return fmt.Errorf("no certificates found in %s", caFile)
+	}
+	tlsClientConfig = &tls.Config{
+		RootCAs:    rootCAs,
+		MinVersion: tls.VersionTLS12,
+	}
+	return nil
+}
+
+func newHTTPClient() *http.Client {
+	if tlsClientConfig == nil {
+		return &http.Client{}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsClientConfig
+	return &http.Client{Transport: transport}
+}
+
 func RequestWithRetry(ctx context.Context, method, url string, body, result interface{}) (err error) {
+	return RequestWithRetryAndAuth(ctx, method, url, nil, body, result)
+}
+
+// RequestWithRetryAndAuth is the same as RequestWithRetry, but sends the given credentials if they are not nil
+func RequestWithRetryAndAuth(ctx context.Context, method, url string, auth *types.BasicAuth, body, result interface{}) (err error) {
 	verbose := log.VerbosityFromContext(ctx)
 	retries := 30
 	for {
-		if err := request(method, url, body, result); err != nil {
+		if err := request(method, url, auth, body, result); err != nil {
 			if retries > 0 {
 				if verbose {
 					fmt.Printf("%s - retrying request...", err.Error())
@@ -54,7 +94,7 @@ func RequestWithRetry(ctx context.Context, method, url string, body, result inte
 	}
 }
 
-func request(method, url string, body, result interface{}) (err error) {
+func request(method, url string, auth *types.BasicAuth, body, result interface{}) (err error) {
 	if body == nil {
 		body = make(map[string]interface{})
 	}
@@ -76,10 +116,13 @@ func request(method, url string, body, result interface{}) (err error) {
 		req.Header.Set("Request-Timeout", fmt.Sprintf("%d", requestTimeout))
 	}
 	req.Header.Set("Content-Type", "application/json")
+	if auth != nil {
+		req.SetBasicAuth(auth.Username, auth.Password)
+	}
 	if err != nil {
 		return err
 	}
-	client := &http.Client{}
+	client := newHTTPClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return err
@@ -99,3 +142,35 @@ func request(method, url string, body, result interface{}) (err error) {
 
 	return json.NewDecoder(resp.Body).Decode(&result)
 }
+
+// Request sends a single request with an optional JSON body, and returns the status code and body of the response
+// whatever the status code is. It sends the given credentials if they are not nil.
+func Request(method, url string, auth *types.BasicAuth, body []byte) (int, []byte, error) {
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, url, bodyReader)
+	if err != nil {
+		return 0, nil, err
+	}
+	if requestTimeout > 0 {
+		req.Header.Set("Request-Timeout", fmt.Sprintf("%d", requestTimeout))
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if auth != nil {
+		req.SetBasicAuth(auth.Username, auth.Password)
+	}
+	resp, err := newHTTPClient().Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	responseBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, nil, err
+	}
+	return resp.StatusCode, responseBytes, nil
+}
@@ -94,7 +94,7 @@ func GetManifestForRelease(version string) (*types.VersionManifest, error) {
 
 func getManifest(version string) (*types.VersionManifest, error) {
 	manifest := &types.VersionManifest{}
-	if err := request("GET", fmt.Sprintf("https://raw.githubusercontent.com/hyperledger/firefly/%s/manifest.json", version), nil, &manifest); err != nil {
+	if err := request("GET", fmt.Sprintf("https://raw.githubusercontent.com/hyperledger/firefly/%s/manifest.json", version), nil, nil, &manifest); err != nil {
 		return nil, err
 	}
 	return manifest, nil
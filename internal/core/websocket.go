@@ -0,0 +1,48 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/websocket"
+	"github.com/hyperledger/firefly-cli/pkg/types"
+)
+
+// DialWebSocket opens a websocket connection to url, sending the given credentials if they are not nil,
+// and trusting the same CAs as the other requests to the stack
+func DialWebSocket(ctx context.Context, url string, auth *types.BasicAuth) (*websocket.Conn, error) {
+	dialer := *websocket.DefaultDialer
+	dialer.TLSClientConfig = tlsClientConfig
+
+	header := http.Header{}
+	if auth != nil {
+		req := &http.Request{Header: header}
+		req.SetBasicAuth(auth.Username, auth.Password)
+	}
+
+	conn, resp, err := dialer.DialContext(ctx, url, header)
+	if err != nil {
+		if resp != nil {
+			return nil, fmt.Errorf("%s [%d] %w", url, resp.StatusCode, err)
+		}
+		return nil, err
+	}
+	return conn, nil
+}
@@ -0,0 +1,61 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/hyperledger/firefly-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDialWebSocketWithAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "user" || password != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, payload, err := conn.ReadMessage()
+		if err == nil {
+			_ = conn.WriteMessage(websocket.TextMessage, payload)
+		}
+	}))
+	defer server.Close()
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	conn, err := DialWebSocket(context.Background(), wsURL, &types.BasicAuth{Username: "user", Password: "pass"})
+	assert.NoError(t, err)
+	defer conn.Close()
+	assert.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"start"}`)))
+	_, payload, err := conn.ReadMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"type":"start"}`, string(payload))
+
+	_, err = DialWebSocket(context.Background(), wsURL, nil)
+	assert.Regexp(t, "\\[401\\]", err)
+}
@@ -22,12 +22,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
 	"path"
 	"strings"
 
 	"github.com/google/go-containerregistry/pkg/crane"
 	"github.com/hyperledger/firefly-cli/internal/log"
+	"github.com/mattn/go-isatty"
 )
 
 type (
@@ -126,6 +128,73 @@ func RunDockerComposeCommand(ctx context.Context, workingDir string, command ...
 	}
 }
 
+// IsInteractive returns true if the CLI is attached to a terminal, so that interactive sessions can allocate a TTY
+func IsInteractive() bool {
+	return isatty.IsTerminal(os.Stdin.Fd()) || isatty.IsCygwinTerminal(os.Stdin.Fd())
+}
+
+// RunDockerCommandInteractive runs a docker command attached to the terminal of the CLI, for interactive sessions
+func RunDockerCommandInteractive(workingDir string, command ...string) error {
+	//nolint:gosec
+	dockerCmd := exec.Command("docker", command...)
+	dockerCmd.Dir = workingDir
+	return runInteractiveCommand(dockerCmd)
+}
+
+// RunDockerComposeCommandInteractive runs a docker compose command attached to the terminal of the CLI, for
+// interactive sessions
+func RunDockerComposeCommandInteractive(ctx context.Context, workingDir string, command ...string) error {
+	dockerCmd, err := dockerComposeCommand(ctx, workingDir, command...)
+	if err != nil {
+		return err
+	}
+	return runInteractiveCommand(dockerCmd)
+}
+
+// StartDockerComposeCommand starts a docker compose command, returning its output for the caller to read. The
+// caller must read the output to the end and then wait for the command. The command is killed if ctx is cancelled.
+func StartDockerComposeCommand(ctx context.Context, workingDir string, command ...string) (*exec.Cmd, io.ReadCloser, error) {
+	dockerCmd, err := dockerComposeCommand(ctx, workingDir, command...)
+	if err != nil {
+		return nil, nil, err
+	}
+	if log.VerbosityFromContext(ctx) {
+		fmt.Println(dockerCmd.String())
+	}
+	dockerCmd.Stderr = os.Stderr
+	stdout, err := dockerCmd.StdoutPipe()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := dockerCmd.Start(); err != nil {
+		return nil, nil, err
+	}
+	return dockerCmd, stdout, nil
+}
+
+func dockerComposeCommand(ctx context.Context, workingDir string, command ...string) (*exec.Cmd, error) {
+	var dockerCmd *exec.Cmd
+	switch ctx.Value(CtxComposeVersionKey{}) {
+	case ComposeV1:
+		//nolint:gosec
+		dockerCmd = exec.CommandContext(ctx, "docker-compose", command...)
+	case ComposeV2:
+		//nolint:gosec
+		dockerCmd = exec.CommandContext(ctx, "docker", append([]string{"compose"}, command...)...)
+	default:
+		return nil, fmt.Errorf("no version for docker-compose has been detected")
+	}
+	dockerCmd.Dir = workingDir
+	return dockerCmd, nil
+}
+
+func runInteractiveCommand(cmd *exec.Cmd) error {
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
 func RunDockerCommandBuffered(ctx context.Context, workingDir string, command ...string) (string, error) {
 	//nolint:gosec
 	dockerCmd := exec.Command("docker", command...)
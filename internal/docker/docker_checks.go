@@ -19,8 +19,17 @@ package docker
 import (
 	"fmt"
 	"os/exec"
+	"strconv"
+	"strings"
 )
 
+// MinimumDockerVersion is the oldest docker engine that supports everything a stack uses, including the host-gateway
+// address that lets containers reach members that run outside of docker
+const MinimumDockerVersion = "20.10.0"
+
+// MinimumComposeVersion is the oldest docker compose V2 that supports everything in the docker compose file of a stack
+const MinimumComposeVersion = "2.0.0"
+
 func CheckDockerConfig() (DockerComposeVersion, error) {
 
 	dockerCmd := exec.Command("docker", "-v")
@@ -51,3 +60,81 @@ func CheckDockerConfig() (DockerComposeVersion, error) {
 
 	return None, fmt.Errorf("an error occurred while running docker-compose. Is docker-compose installed on your computer?")
 }
+
+// GetDockerClientVersion returns the version of the docker CLI, which does not need the docker daemon to be running
+func GetDockerClientVersion() (string, error) {
+	// docker version exits with an error if the daemon is not reachable, even though it prints the client version
+	out, err := dockerOutput("docker", "version", "--format", "{{.Client.Version}}")
+	if out == "" {
+		if err == nil {
+			err = fmt.Errorf("docker did not report a client version")
+		}
+		return "", err
+	}
+	return out, nil
+}
+
+// GetDockerServerVersion returns the version of the docker daemon, which fails if the daemon is not reachable
+func GetDockerServerVersion() (string, error) {
+	return dockerOutput("docker", "info", "--format", "{{.ServerVersion}}")
+}
+
+// GetDockerRootDir returns the data root of the docker daemon, where it stores its images, containers and volumes
+func GetDockerRootDir() (string, error) {
+	return dockerOutput("docker", "info", "--format", "{{.DockerRootDir}}")
+}
+
+// GetDockerComposeVersion returns the version of docker compose that CheckDockerConfig found
+func GetDockerComposeVersion(composeVersion DockerComposeVersion) (string, error) {
+	switch composeVersion {
+	case ComposeV1:
+		return dockerOutput("docker-compose", "version", "--short")
+	case ComposeV2:
+		return dockerOutput("docker", "compose", "version", "--short")
+	default:
+		return "", fmt.Errorf("docker compose is not installed")
+	}
+}
+
+func dockerOutput(command string, args ...string) (string, error) {
+	out, err := exec.Command(command, args...).Output()
+	return strings.TrimSpace(string(out)), err
+}
+
+// CompareVersions compares two versions such as "v2.24.6-desktop.1" or "20.10.24+dfsg1" by their major, minor and
+// patch numbers, and returns -1, 0 or 1 if the first version is older, the same or newer than the second
+func CompareVersions(a, b string) (int, error) {
+	aParts, err := parseVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	bParts, err := parseVersion(b)
+	if err != nil {
+		return 0, err
+	}
+	for i := range aParts {
+		if aParts[i] < bParts[i] {
+			return -1, nil
+		}
+		if aParts[i] > bParts[i] {
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func parseVersion(version string) ([3]int, error) {
+	var parts [3]int
+	trimmed := strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if end := strings.IndexAny(trimmed, "-+ "); end >= 0 {
+		trimmed = trimmed[:end]
+	}
+	for i, part := range strings.SplitN(trimmed, ".", 3) {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return parts, fmt.Errorf("invalid version '%s'", version)
+		}
+		parts[i] = n
+	}
+	return parts, nil
+}
@@ -0,0 +1,24 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareVersions(t *testing.T) {
+	cmp, err := CompareVersions("20.10.24+dfsg1", MinimumDockerVersion)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, cmp)
+
+	cmp, err = CompareVersions("v2.24.6-desktop.1", "2.24.6")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, cmp)
+
+	cmp, err = CompareVersions("1.29", MinimumComposeVersion)
+	assert.NoError(t, err)
+	assert.Equal(t, -1, cmp)
+
+	_, err = CompareVersions("unknown", MinimumComposeVersion)
+	assert.Regexp(t, "invalid version 'unknown'", err)
+}
@@ -18,7 +18,11 @@ package docker
 
 import (
 	"fmt"
+	"net/url"
+	"path"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/hyperledger/firefly-cli/internal/constants"
 	"github.com/hyperledger/firefly-cli/pkg/types"
@@ -61,7 +65,12 @@ type Service struct {
 	EnvFile       string                       `yaml:"env_file,omitempty"`
 	Expose        []int                        `yaml:"expose,omitempty"`
 	Deploy        map[string]interface{}       `yaml:"deploy,omitempty"`
+	CPUs          string                       `yaml:"cpus,omitempty"`
+	MemLimit      string                       `yaml:"mem_limit,omitempty"`
+	Restart       string                       `yaml:"restart,omitempty"`
+	Ulimits       map[string]*types.Ulimit     `yaml:"ulimits,omitempty"`
 	Platform      string                       `yaml:"platform,omitempty"`
+	Labels        map[string]string            `yaml:"labels,omitempty"`
 	ExtraHosts    []string                     `yaml:"extra_hosts,omitempty"`
 }
 
@@ -79,6 +88,74 @@ var StandardLogOptions = &LoggingConfig{
 	},
 }
 
+// TLSCertsVolume returns the bind mount for the TLS certificates that were generated for a service
+func TLSCertsVolume(s *types.Stack, serviceName string) string {
+	return fmt.Sprintf("%s:%s:ro", filepath.Join(s.RuntimeDir, "config", "tls", serviceName), constants.TLSCertsDir)
+}
+
+// HtpasswdVolume returns the bind mount for the htpasswd file of a member when basic auth is enabled
+func HtpasswdVolume(s *types.Stack, member *types.Organization) string {
+	return fmt.Sprintf("%s:%s:ro", filepath.Join(s.RuntimeDir, "config", "auth", fmt.Sprintf("htpasswd_%s", member.ID)), constants.HtpasswdFile)
+}
+
+// TLSProxyService returns a TLS terminating proxy for a service that cannot serve its API over HTTPS itself. The
+// proxy serves HTTPS on the port of the service with the certificates generated for the proxy, and takes over the
+// exposed port of the service.
+func TLSProxyService(s *types.Stack, proxyName, target string, port, exposedPort int) *Service {
+	return &Service{
+		Image:         constants.TLSProxyImageName,
+		ContainerName: fmt.Sprintf("%s_%s", s.Name, proxyName),
+		Command: fmt.Sprintf("server --listen 0.0.0.0:%[1]d --target %[2]s:%[1]d --unsafe-target --cert %[3]s --key %[4]s --disable-authentication",
+			port, target, path.Join(constants.TLSCertsDir, "cert.pem"), path.Join(constants.TLSCertsDir, "key.pem")),
+		Ports:   []string{fmt.Sprintf("%d:%d", exposedPort, port)},
+		Volumes: []string{TLSCertsVolume(s, proxyName)},
+		DependsOn: map[string]map[string]string{
+			target: {"condition": "service_healthy"},
+		},
+		Logging:     StandardLogOptions,
+		Environment: s.EnvironmentVars,
+	}
+}
+
+// AuthEnvFile returns the path of the env file containing the credentials of a member when basic auth is enabled
+func AuthEnvFile(s *types.Stack, member *types.Organization) string {
+	return filepath.Join(s.RuntimeDir, "config", "auth", fmt.Sprintf("auth_%s.env", member.ID))
+}
+
+// SandboxEnvFile returns the path of the env file that passes the FireFly endpoint of a member, including the
+// member's credentials, to its sandbox when basic auth is enabled
+func SandboxEnvFile(s *types.Stack, member *types.Organization) string {
+	return filepath.Join(s.RuntimeDir, "config", "auth", fmt.Sprintf("sandbox_%s.env", member.ID))
+}
+
+// SandboxFireFlyEndpoint returns the URL the sandbox of a member uses to reach the member's FireFly core,
+// with the credentials in the URL when auth is set
+func SandboxFireFlyEndpoint(s *types.Stack, member *types.Organization, auth *types.BasicAuth) string {
+	endpoint := &url.URL{
+		Scheme: s.HTTPScheme(),
+		Host:   fmt.Sprintf("firefly_core_%d:%d", *member.Index, member.ExposedFireflyPort),
+	}
+	if auth != nil {
+		endpoint.User = url.UserPassword(auth.Username, auth.Password)
+	}
+	return endpoint.String()
+}
+
+// CurlHealthCheckTest returns the healthcheck test for a service that calls a path on its own API with curl,
+// trusting the stack CA and sending the member's credentials from the container environment when needed
+func CurlHealthCheckTest(s *types.Stack, port int, urlPath string, curlArgs ...string) []string {
+	url := fmt.Sprintf("%s://localhost:%d%s", s.HTTPScheme(), port, urlPath)
+	if s.TLSEnabled {
+		curlArgs = append(curlArgs, "--cacert", path.Join(constants.TLSCertsDir, "ca.pem"))
+	}
+	if !s.AuthMode.Equals(types.AuthModeBasic) {
+		return append(append([]string{"CMD", "curl"}, curlArgs...), url)
+	}
+	// Run through a shell so the credentials come from the container's env file, rather than the compose file
+	curlArgs = append(curlArgs, "-u", `"$$BASIC_AUTH_USERNAME:$$BASIC_AUTH_PASSWORD"`, url)
+	return []string{"CMD-SHELL", "curl " + strings.Join(curlArgs, " ")}
+}
+
 func CreateDockerCompose(s *types.Stack) *DockerComposeConfig {
 	compose := &DockerComposeConfig{
 		Version:  "2.4",
@@ -106,12 +183,7 @@ func CreateDockerCompose(s *types.Stack) *DockerComposeConfig {
 				Logging:     StandardLogOptions,
 				Environment: s.EnvironmentVars,
 				HealthCheck: &HealthCheck{
-					Test: []string{
-						"CMD",
-						"curl",
-						"--fail",
-						fmt.Sprintf("http://localhost:%d/api/v1/status", member.ExposedFireflyPort),
-					},
+					Test:     CurlHealthCheckTest(s, member.ExposedFireflyPort, "/api/v1/status", "--fail"),
 					Interval: "15s", // 6000 requests in a day
 					Retries:  30,
 				},
@@ -119,6 +191,16 @@ func CreateDockerCompose(s *types.Stack) *DockerComposeConfig {
 			compose.Volumes[fmt.Sprintf("%s_data_%s", fireflyCore, member.ID)] = struct{}{}
 			compose.Services[fireflyCore+"_"+member.ID].DependsOn["dataexchange_"+member.ID] = map[string]string{"condition": "service_started"}
 			compose.Services[fireflyCore+"_"+member.ID].DependsOn["ipfs_"+member.ID] = map[string]string{"condition": "service_healthy"}
+			if s.TLSEnabled {
+				service := compose.Services[fireflyCore+"_"+member.ID]
+				service.Volumes = append(service.Volumes, TLSCertsVolume(s, fireflyCore+"_"+member.ID))
+				service.DependsOn["ipfs_api_"+member.ID] = map[string]string{"condition": "service_started"}
+			}
+			if s.AuthMode.Equals(types.AuthModeBasic) {
+				service := compose.Services[fireflyCore+"_"+member.ID]
+				service.Volumes = append(service.Volumes, HtpasswdVolume(s, member))
+				service.EnvFile = AuthEnvFile(s, member)
+			}
 		}
 		if s.Database == "postgres" {
 			compose.Services["postgres_"+member.ID] = &Service{
@@ -126,7 +208,7 @@ func CreateDockerCompose(s *types.Stack) *DockerComposeConfig {
 				ContainerName: fmt.Sprintf("%s_postgres_%s", s.Name, member.ID),
 				Ports:         []string{fmt.Sprintf("%d:5432", member.ExposedDatabasePort)},
 				Environment: s.ConcatenateWithProvidedEnvironmentVars(map[string]interface{}{
-					"POSTGRES_PASSWORD": "f1refly",
+					"POSTGRES_PASSWORD": s.PostgresPassword(),
 					"PGDATA":            "/var/lib/postgresql/data/pgdata"}),
 				Volumes: []string{fmt.Sprintf("postgres_%s:/var/lib/postgresql/data", member.ID)},
 				HealthCheck: &HealthCheck{
@@ -138,6 +220,20 @@ func CreateDockerCompose(s *types.Stack) *DockerComposeConfig {
 				Logging: StandardLogOptions,
 			}
 			compose.Volumes[fmt.Sprintf("postgres_%s", member.ID)] = struct{}{}
+			if s.PrometheusEnabled {
+				// Postgres does not serve metrics itself, so an exporter collects them for Prometheus
+				compose.Services["pgexporter_"+member.ID] = &Service{
+					Image:         constants.PostgresExporterImageName,
+					ContainerName: fmt.Sprintf("%s_pgexporter_%s", s.Name, member.ID),
+					Environment: s.ConcatenateWithProvidedEnvironmentVars(map[string]interface{}{
+						"DATA_SOURCE_NAME": fmt.Sprintf("postgres://postgres:%s@postgres_%s:5432/postgres?sslmode=disable", s.PostgresPassword(), member.ID),
+					}),
+					DependsOn: map[string]map[string]string{
+						"postgres_" + member.ID: {"condition": "service_healthy"},
+					},
+					Logging: StandardLogOptions,
+				}
+			}
 			if service, ok := compose.Services[fmt.Sprintf("%s_%s", fireflyCore, member.ID)]; ok {
 				service.DependsOn["postgres_"+member.ID] = map[string]string{"condition": "service_healthy"}
 			}
@@ -173,7 +269,13 @@ func CreateDockerCompose(s *types.Stack) *DockerComposeConfig {
 		compose.Services["ipfs_"+member.ID] = sharedStorage
 		compose.Volumes[fmt.Sprintf("ipfs_staging_%s", member.ID)] = struct{}{}
 		compose.Volumes[fmt.Sprintf("ipfs_data_%s", member.ID)] = struct{}{}
-		compose.Services["dataexchange_"+member.ID] = &Service{
+		if s.TLSEnabled {
+			// IPFS cannot serve its API over HTTPS itself, so a TLS terminating proxy is put in front of it
+			// that takes over the exposed API port
+			sharedStorage.Ports = []string{fmt.Sprintf("%d:8080", member.ExposedIPFSGWPort)}
+			compose.Services["ipfs_api_"+member.ID] = TLSProxyService(s, "ipfs_api_"+member.ID, "ipfs_"+member.ID, 5001, member.ExposedIPFSApiPort)
+		}
+		dataExchange := &Service{
 			Image:         s.VersionManifest.DataExchange.GetDockerImageString(),
 			ContainerName: fmt.Sprintf("%s_dataexchange_%s", s.Name, member.ID),
 			Ports:         []string{fmt.Sprintf("%d:3000", member.ExposedDataexchangePort)},
@@ -181,16 +283,32 @@ func CreateDockerCompose(s *types.Stack) *DockerComposeConfig {
 			Logging:       StandardLogOptions,
 			Environment:   s.EnvironmentVars,
 		}
+		if s.TLSEnabled {
+			dataExchange.Volumes = append(dataExchange.Volumes, TLSCertsVolume(s, "dataexchange_"+member.ID))
+		}
+		compose.Services["dataexchange_"+member.ID] = dataExchange
 		compose.Volumes[fmt.Sprintf("dataexchange_%s", member.ID)] = struct{}{}
 		if s.SandboxEnabled {
-			compose.Services["sandbox_"+member.ID] = &Service{
+			sandbox := &Service{
 				Image:         constants.SandboxImageName,
 				ContainerName: fmt.Sprintf("%s_sandbox_%s", s.Name, member.ID),
 				Ports:         []string{fmt.Sprintf("%d:3001", member.ExposedSandboxPort)},
-				Environment: s.ConcatenateWithProvidedEnvironmentVars(map[string]interface{}{
-					"FF_ENDPOINT": fmt.Sprintf("http://firefly_core_%d:%d", *member.Index, member.ExposedFireflyPort),
-				}),
 			}
+			if s.AuthMode.Equals(types.AuthModeBasic) {
+				// The endpoint contains the member's credentials, so it is kept out of the compose file
+				sandbox.Environment = s.ConcatenateWithProvidedEnvironmentVars(map[string]interface{}{})
+				sandbox.EnvFile = SandboxEnvFile(s, member)
+			} else {
+				sandbox.Environment = s.ConcatenateWithProvidedEnvironmentVars(map[string]interface{}{
+					"FF_ENDPOINT": SandboxFireFlyEndpoint(s, member, nil),
+				})
+			}
+			if s.TLSEnabled {
+				// The sandbox only needs the CA from the core's certs to trust the FireFly API
+				sandbox.Volumes = []string{TLSCertsVolume(s, fmt.Sprintf("firefly_core_%s", member.ID))}
+				sandbox.Environment["NODE_EXTRA_CA_CERTS"] = path.Join(constants.TLSCertsDir, "ca.pem")
+			}
+			compose.Services["sandbox_"+member.ID] = sandbox
 		}
 	}
 
@@ -203,9 +321,190 @@ func CreateDockerCompose(s *types.Stack) *DockerComposeConfig {
 			Logging:       StandardLogOptions,
 			Environment:   s.EnvironmentVars,
 		}
+		// The blackbox exporter checks that the services without a metrics endpoint are accepting connections
+		compose.Services["blackbox"] = &Service{
+			Image:         constants.BlackboxExporterImageName,
+			ContainerName: fmt.Sprintf("%s_blackbox", s.Name),
+			Logging:       StandardLogOptions,
+			Environment:   s.EnvironmentVars,
+		}
 		compose.Volumes["prometheus_data"] = struct{}{}
 		compose.Volumes["prometheus_config"] = struct{}{}
+
+		if s.GrafanaEnabled {
+			// Grafana reads the datasource and dashboards that are copied into its provisioning volume when it starts.
+			// Anyone can view the dashboards, and the admin can edit them.
+			compose.Services["grafana"] = &Service{
+				Image:         constants.GrafanaImageName,
+				ContainerName: fmt.Sprintf("%s_grafana", s.Name),
+				Ports:         []string{fmt.Sprintf("%d:3000", s.ExposedGrafanaPort)},
+				Volumes:       []string{"grafana_data:/var/lib/grafana", "grafana_provisioning:/etc/grafana/provisioning"},
+				Environment: s.ConcatenateWithProvidedEnvironmentVars(map[string]interface{}{
+					"GF_SECURITY_ADMIN_PASSWORD": s.GrafanaAdminPassword(),
+					"GF_AUTH_ANONYMOUS_ENABLED":  "true",
+					"GF_AUTH_ANONYMOUS_ORG_ROLE": "Viewer",
+				}),
+				DependsOn: map[string]map[string]string{
+					"prometheus": {"condition": "service_started"},
+				},
+				Logging: StandardLogOptions,
+			}
+			compose.Volumes["grafana_data"] = struct{}{}
+			compose.Volumes["grafana_provisioning"] = struct{}{}
+		}
+	}
+
+	if s.BesuTracingEnabled {
+		// The besu nodes send their traces to the collector, which forwards them to Jaeger
+		compose.Services["otel_collector"] = &Service{
+			Image:         constants.OTelCollectorImageName,
+			ContainerName: fmt.Sprintf("%s_otel_collector", s.Name),
+			Volumes:       []string{"otel_collector_config:/etc/otelcol"},
+			DependsOn: map[string]map[string]string{
+				"jaeger": {"condition": "service_started"},
+			},
+			Logging:     StandardLogOptions,
+			Environment: s.EnvironmentVars,
+		}
+		compose.Services["jaeger"] = &Service{
+			Image:         constants.JaegerImageName,
+			ContainerName: fmt.Sprintf("%s_jaeger", s.Name),
+			Ports:         []string{fmt.Sprintf("%d:16686", s.ExposedJaegerPort)},
+			Environment: s.ConcatenateWithProvidedEnvironmentVars(map[string]interface{}{
+				"COLLECTOR_OTLP_ENABLED": "true",
+			}),
+			Logging: StandardLogOptions,
+		}
+		compose.Volumes["otel_collector_config"] = struct{}{}
+	}
+
+	if s.LogAggregation.Equals(types.LogAggregationLoki) {
+		// Promtail reads the logs of the stack's containers through the docker API and ships them to Loki, so the
+		// logs are kept in Loki after the json-file logs of the containers have been rotated
+		compose.Services["loki"] = &Service{
+			Image:         constants.LokiImageName,
+			ContainerName: fmt.Sprintf("%s_loki", s.Name),
+			Ports:         []string{fmt.Sprintf("%d:3100", s.ExposedLokiPort)},
+			Volumes:       []string{"loki_data:/loki"},
+			Logging:       StandardLogOptions,
+			Environment:   s.EnvironmentVars,
+		}
+		compose.Services["promtail"] = &Service{
+			Image:         constants.PromtailImageName,
+			ContainerName: fmt.Sprintf("%s_promtail", s.Name),
+			Command:       "-config.file=/etc/promtail/config.yml",
+			Volumes: []string{
+				"promtail_config:/etc/promtail",
+				"promtail_data:/var/lib/promtail",
+				"/var/run/docker.sock:/var/run/docker.sock:ro",
+			},
+			DependsOn: map[string]map[string]string{
+				"loki": {"condition": "service_started"},
+			},
+			Logging:     StandardLogOptions,
+			Environment: s.EnvironmentVars,
+		}
+		compose.Volumes["loki_data"] = struct{}{}
+		compose.Volumes["promtail_config"] = struct{}{}
+		compose.Volumes["promtail_data"] = struct{}{}
 	}
 
 	return compose
 }
+
+// PrefixServiceDefinitions renames the services and named volumes in a set of service definitions, along with the
+// references between them, so that they do not clash with the services of another blockchain in the same stack
+func PrefixServiceDefinitions(serviceDefinitions []*ServiceDefinition, prefix string) {
+	serviceNames := make(map[string]bool)
+	volumeNames := make(map[string]bool)
+	for _, serviceDefinition := range serviceDefinitions {
+		serviceNames[serviceDefinition.ServiceName] = true
+		for _, volumeName := range serviceDefinition.VolumeNames {
+			volumeNames[volumeName] = true
+		}
+	}
+
+	for _, serviceDefinition := range serviceDefinitions {
+		serviceDefinition.ServiceName = prefix + serviceDefinition.ServiceName
+		for i, volumeName := range serviceDefinition.VolumeNames {
+			serviceDefinition.VolumeNames[i] = prefix + volumeName
+		}
+		service := serviceDefinition.Service
+		for i, volume := range service.Volumes {
+			// Only named volumes are renamed - bind mounts start with a path
+			if source, target, found := strings.Cut(volume, ":"); found && volumeNames[source] {
+				service.Volumes[i] = prefix + source + ":" + target
+			}
+		}
+		if service.DependsOn != nil {
+			dependsOn := make(map[string]map[string]string, len(service.DependsOn))
+			for name, condition := range service.DependsOn {
+				if serviceNames[name] {
+					name = prefix + name
+				}
+				dependsOn[name] = condition
+			}
+			service.DependsOn = dependsOn
+		}
+	}
+}
+
+// ApplyServiceEnvironmentVars adds environment variables to selected services in a compose config. A selector
+// matches the service with that name, and every service whose name starts with the selector followed by an
+// underscore, so "evmconnect" selects the connector of every member and "evmconnect_1" only the one of member 1.
+// Longer selectors are applied last, so that they take precedence over the shorter ones that also match.
+func ApplyServiceEnvironmentVars(compose *DockerComposeConfig, serviceEnvironmentVars map[string]map[string]interface{}) {
+	selectors := make([]string, 0, len(serviceEnvironmentVars))
+	for selector := range serviceEnvironmentVars {
+		selectors = append(selectors, selector)
+	}
+	sort.Slice(selectors, func(i, j int) bool {
+		if len(selectors[i]) != len(selectors[j]) {
+			return len(selectors[i]) < len(selectors[j])
+		}
+		return selectors[i] < selectors[j]
+	})
+
+	for serviceName, service := range compose.Services {
+		var environment map[string]interface{}
+		for _, selector := range selectors {
+			if serviceName != selector && !strings.HasPrefix(serviceName, selector+"_") {
+				continue
+			}
+			if environment == nil {
+				// The environment map can be shared between services, so it is copied before changing it
+				environment = make(map[string]interface{}, len(service.Environment))
+				for key, value := range service.Environment {
+					environment[key] = value
+				}
+			}
+			for key, value := range serviceEnvironmentVars[selector] {
+				environment[key] = value
+			}
+		}
+		if environment != nil {
+			service.Environment = environment
+		}
+	}
+}
+
+// ApplyServiceResources sets the resource limits, restart policy and ulimits of a service. The limits are set with
+// the service level cpus and mem_limit keys, as docker-compose v1 ignores deploy.resources.limits in a version 2.4
+// compose file unless it is run with --compatibility, whereas docker compose v2 honors both.
+func ApplyServiceResources(service *Service, resources *types.ServiceResources) {
+	if resources == nil {
+		return
+	}
+	if resources.CPUs != "" {
+		service.CPUs = resources.CPUs
+	}
+	if resources.Memory != "" {
+		service.MemLimit = resources.Memory
+	}
+	if resources.Restart != "" {
+		service.Restart = resources.Restart
+	}
+	if len(resources.Ulimits) > 0 {
+		service.Ulimits = resources.Ulimits
+	}
+}
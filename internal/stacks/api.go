@@ -0,0 +1,210 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stacks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/hyperledger/firefly-cli/internal/core"
+	"github.com/hyperledger/firefly-cli/pkg/types"
+)
+
+type apiMessageHeader struct {
+	Tag    string   `json:"tag,omitempty"`
+	Topics []string `json:"topics,omitempty"`
+}
+
+type apiMessageData struct {
+	Value interface{} `json:"value"`
+}
+
+type apiGroupMember struct {
+	Identity string `json:"identity"`
+}
+
+type apiGroup struct {
+	Members []*apiGroupMember `json:"members"`
+}
+
+type apiMessage struct {
+	Header *apiMessageHeader `json:"header,omitempty"`
+	Group  *apiGroup         `json:"group,omitempty"`
+	Data   []*apiMessageData `json:"data"`
+}
+
+type apiTokenMint struct {
+	Pool   string `json:"pool,omitempty"`
+	Amount string `json:"amount"`
+	To     string `json:"to,omitempty"`
+}
+
+// CallAPI sends a request to the API of the FireFly core of a member, or to its SPI, and prints the response. A path
+// that does not start with "/" is relative to /api/v1 or /spi/v1. The body of the request is the Data of the options,
+// which is either JSON, or the name of a file containing JSON after an "@", or "@-" to read it from stdin.
+func (s *StackManager) CallAPI(options *types.APIOptions, method, path string) error {
+	body, err := readAPIData(options.Data)
+	if err != nil {
+		return err
+	}
+	return s.sendAPIRequest(options, strings.ToUpper(method), path, body)
+}
+
+// Broadcast broadcasts a message with a single value to every member of the network
+func (s *StackManager) Broadcast(options *types.APIOptions, value string) error {
+	message, err := s.apiMessage(options, value)
+	if err != nil {
+		return err
+	}
+	return s.sendAPIObject(options, "messages/broadcast", message)
+}
+
+// SendPrivate sends a private message with a single value to the organizations in the Recipients of the options,
+// or to every member of the stack if there are none
+func (s *StackManager) SendPrivate(options *types.APIOptions, value string) error {
+	message, err := s.apiMessage(options, value)
+	if err != nil {
+		return err
+	}
+	recipients := options.Recipients
+	if len(recipients) == 0 {
+		for _, member := range s.Stack.Members {
+			recipients = append(recipients, member.OrgName)
+		}
+	}
+	message.Group = &apiGroup{}
+	for _, recipient := range recipients {
+		message.Group.Members = append(message.Group.Members, &apiGroupMember{Identity: recipient})
+	}
+	return s.sendAPIObject(options, "messages/private", message)
+}
+
+// Mint mints tokens in a token pool, which can be left out if the namespace only has one pool. The tokens go to
+// the signing key of the member unless another key is given.
+func (s *StackManager) Mint(options *types.APIOptions) error {
+	if options.Amount == "" {
+		return fmt.Errorf("an amount to mint is required")
+	}
+	return s.sendAPIObject(options, "tokens/mint", &apiTokenMint{
+		Pool:   options.Pool,
+		Amount: options.Amount,
+		To:     options.To,
+	})
+}
+
+func (s *StackManager) apiMessage(options *types.APIOptions, value string) (*apiMessage, error) {
+	if value == "" {
+		data, err := readAPIData(options.Data)
+		if err != nil {
+			return nil, err
+		}
+		if data == nil {
+			return nil, fmt.Errorf("a message value is required")
+		}
+		value = string(data)
+	}
+	message := &apiMessage{
+		Data: []*apiMessageData{{Value: value}},
+	}
+	// Values that are valid JSON are sent as JSON rather than as a string
+	if json.Valid([]byte(value)) {
+		message.Data[0].Value = json.RawMessage(value)
+	}
+	if options.Tag != "" || len(options.Topics) > 0 {
+		message.Header = &apiMessageHeader{
+			Tag:    options.Tag,
+			Topics: options.Topics,
+		}
+	}
+	return message, nil
+}
+
+// sendAPIObject posts an object to a path in the namespace of the options
+func (s *StackManager) sendAPIObject(options *types.APIOptions, path string, object interface{}) error {
+	body, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+	namespace := options.Namespace
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+	path = fmt.Sprintf("namespaces/%s/%s", namespace, path)
+	if options.Confirm {
+		path += "?confirm=true"
+	}
+	return s.sendAPIRequest(options, http.MethodPost, path, body)
+}
+
+func (s *StackManager) sendAPIRequest(options *types.APIOptions, method, path string, body []byte) error {
+	if options.Member < 0 || options.Member >= len(s.Stack.Members) {
+		return fmt.Errorf("stack '%s' does not have a member %d", s.Stack.Name, options.Member)
+	}
+	member := s.Stack.Members[options.Member]
+
+	// Only the API has authentication, the SPI is not exposed outside of the stack
+	port, prefix, auth := member.ExposedFireflyPort, "/api/v1", s.Stack.MemberBasicAuth(member)
+	if options.SPI {
+		port, prefix, auth = member.ExposedFireflyAdminSPIPort, "/spi/v1", nil
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = fmt.Sprintf("%s/%s", prefix, path)
+	}
+	url := fmt.Sprintf("%s://127.0.0.1:%d%s", s.Stack.HTTPScheme(), port, path)
+	s.Log.Debug(fmt.Sprintf("%s %s", method, url))
+
+	statusCode, response, err := core.Request(method, url, auth, body)
+	if err != nil {
+		return err
+	}
+	var indented bytes.Buffer
+	if json.Indent(&indented, response, "", "  ") == nil {
+		response = indented.Bytes()
+	}
+	if len(response) > 0 {
+		fmt.Println(string(response))
+	}
+	if statusCode < 200 || statusCode >= 300 {
+		return fmt.Errorf("%s %s returned %d", method, url, statusCode)
+	}
+	return nil
+}
+
+// readAPIData returns the body of a request from the command line, which is nil if there is none
+func readAPIData(data string) ([]byte, error) {
+	var body []byte
+	var err error
+	switch {
+	case data == "":
+		return nil, nil
+	case data == "@-":
+		body, err = io.ReadAll(os.Stdin)
+	case strings.HasPrefix(data, "@"):
+		body, err = os.ReadFile(strings.TrimPrefix(data, "@"))
+	default:
+		body = []byte(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return body, nil
+}
@@ -0,0 +1,168 @@
+package stacks
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/hyperledger/firefly-cli/internal/log"
+	"github.com/hyperledger/firefly-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadAPIData(t *testing.T) {
+	dir := t.TempDir()
+	dataFile := filepath.Join(dir, "data.json")
+	assert.NoError(t, os.WriteFile(dataFile, []byte(`{"from":"file"}`), 0644))
+	stdinFile := filepath.Join(dir, "stdin")
+	assert.NoError(t, os.WriteFile(stdinFile, []byte(`{"from":"stdin"}`), 0644))
+
+	testCases := []struct {
+		Name  string
+		Data  string
+		Body  []byte
+		Error string
+	}{
+		{Name: "none", Data: "", Body: nil},
+		{Name: "inline", Data: `{"from":"inline"}`, Body: []byte(`{"from":"inline"}`)},
+		{Name: "file", Data: "@" + dataFile, Body: []byte(`{"from":"file"}`)},
+		{Name: "missing file", Data: "@" + filepath.Join(dir, "missing.json"), Error: "missing.json"},
+		{Name: "stdin", Data: "@-", Body: []byte(`{"from":"stdin"}`)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			stdin, err := os.Open(stdinFile)
+			assert.NoError(t, err)
+			defer stdin.Close()
+			originalStdin := os.Stdin
+			os.Stdin = stdin
+			defer func() { os.Stdin = originalStdin }()
+
+			body, err := readAPIData(tc.Data)
+			if tc.Error != "" {
+				assert.ErrorContains(t, err, tc.Error)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Body, body)
+		})
+	}
+}
+
+func TestAPIMessage(t *testing.T) {
+	s := &StackManager{Stack: &types.Stack{Name: "stack"}}
+
+	testCases := []struct {
+		Name    string
+		Value   string
+		Options *types.APIOptions
+		JSON    string
+		Error   string
+	}{
+		{
+			Name:    "json object",
+			Value:   `{"a":1}`,
+			Options: &types.APIOptions{},
+			JSON:    `{"data":[{"value":{"a":1}}]}`,
+		},
+		{
+			Name:    "json number",
+			Value:   `42`,
+			Options: &types.APIOptions{},
+			JSON:    `{"data":[{"value":42}]}`,
+		},
+		{
+			Name:    "string",
+			Value:   "hello world",
+			Options: &types.APIOptions{},
+			JSON:    `{"data":[{"value":"hello world"}]}`,
+		},
+		{
+			Name:    "invalid json",
+			Value:   `{"a":`,
+			Options: &types.APIOptions{},
+			JSON:    `{"data":[{"value":"{\"a\":"}]}`,
+		},
+		{
+			Name:    "inline data with header",
+			Options: &types.APIOptions{Data: `["x","y"]`, Tag: "new", Topics: []string{"orders"}},
+			JSON:    `{"header":{"tag":"new","topics":["orders"]},"data":[{"value":["x","y"]}]}`,
+		},
+		{
+			Name:    "no value",
+			Options: &types.APIOptions{},
+			Error:   "a message value is required",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			message, err := s.apiMessage(tc.Options, tc.Value)
+			if tc.Error != "" {
+				assert.Regexp(t, tc.Error, err)
+				return
+			}
+			assert.NoError(t, err)
+			b, err := json.Marshal(message)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.JSON, string(b))
+		})
+	}
+}
+
+func TestSendAPIRequestMember(t *testing.T) {
+	var requestPath string
+	var requestBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestPath = r.URL.Path
+		requestBody, _ = io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	assert.NoError(t, err)
+	port, err := strconv.Atoi(serverURL.Port())
+	assert.NoError(t, err)
+
+	s := &StackManager{
+		Log: &log.StdoutLogger{},
+		Stack: &types.Stack{
+			Name: "stack",
+			Members: []*types.Organization{
+				{ID: "0", ExposedFireflyPort: port},
+				{ID: "1", ExposedFireflyPort: port},
+			},
+		},
+	}
+
+	testCases := []struct {
+		Name   string
+		Member int
+		Error  string
+	}{
+		{Name: "first member", Member: 0},
+		{Name: "last member", Member: 1},
+		{Name: "negative index", Member: -1, Error: "stack 'stack' does not have a member -1"},
+		{Name: "index too large", Member: 2, Error: "stack 'stack' does not have a member 2"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			requestPath, requestBody = "", nil
+			err := s.sendAPIRequest(&types.APIOptions{Member: tc.Member}, http.MethodPost, "status", []byte(`{"a":1}`))
+			if tc.Error != "" {
+				assert.EqualError(t, err, tc.Error)
+				assert.Empty(t, requestPath)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "/api/v1/status", requestPath)
+			assert.JSONEq(t, `{"a":1}`, string(requestBody))
+		})
+	}
+}
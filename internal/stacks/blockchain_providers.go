@@ -0,0 +1,68 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stacks
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-cli/internal/blockchain"
+	cardanoremoterpc "github.com/hyperledger/firefly-cli/internal/blockchain/cardano/remoterpc"
+	"github.com/hyperledger/firefly-cli/internal/blockchain/ethereum/anvil"
+	"github.com/hyperledger/firefly-cli/internal/blockchain/ethereum/besu"
+	"github.com/hyperledger/firefly-cli/internal/blockchain/ethereum/geth"
+	"github.com/hyperledger/firefly-cli/internal/blockchain/ethereum/quorum"
+	ethremoterpc "github.com/hyperledger/firefly-cli/internal/blockchain/ethereum/remoterpc"
+	"github.com/hyperledger/firefly-cli/internal/blockchain/fabric"
+	"github.com/hyperledger/firefly-cli/internal/blockchain/plugin"
+	tezosremoterpc "github.com/hyperledger/firefly-cli/internal/blockchain/tezos/remoterpc"
+	"github.com/hyperledger/firefly-cli/internal/constants"
+	"github.com/hyperledger/firefly-cli/pkg/types"
+)
+
+// The built in blockchain providers are registered here. Others can be added by a file in this package with a build
+// tag that registers them in its own init function, or as a plugin executable in the plugins directory of FIREFLY_HOME.
+func init() {
+	blockchain.RegisterNode(types.BlockchainProviderEthereum.String(), types.BlockchainNodeProviderGeth.String(), func(ctx context.Context, stack *types.Stack) blockchain.IBlockchainProvider {
+		return geth.NewGethProvider(ctx, stack)
+	})
+	blockchain.RegisterNode(types.BlockchainProviderEthereum.String(), types.BlockchainNodeProviderBesu.String(), func(ctx context.Context, stack *types.Stack) blockchain.IBlockchainProvider {
+		return besu.NewBesuProvider(ctx, stack)
+	})
+	blockchain.RegisterNode(types.BlockchainProviderEthereum.String(), types.BlockchainNodeProviderQuorum.String(), func(ctx context.Context, stack *types.Stack) blockchain.IBlockchainProvider {
+		return quorum.NewQuorumProvider(ctx, stack)
+	})
+	blockchain.RegisterNode(types.BlockchainProviderEthereum.String(), types.BlockchainNodeProviderAnvil.String(), func(ctx context.Context, stack *types.Stack) blockchain.IBlockchainProvider {
+		return anvil.NewAnvilProvider(ctx, stack)
+	})
+	blockchain.RegisterNode(types.BlockchainProviderEthereum.String(), types.BlockchainNodeProviderRemoteRPC.String(), func(ctx context.Context, stack *types.Stack) blockchain.IBlockchainProvider {
+		stack.DisableTokenFactories = true
+		return ethremoterpc.NewRemoteRPCProvider(ctx, stack)
+	})
+	blockchain.Register(types.BlockchainProviderCardano.String(), func(ctx context.Context, stack *types.Stack) blockchain.IBlockchainProvider {
+		stack.DisableTokenFactories = true
+		return cardanoremoterpc.NewRemoteRPCProvider(ctx, stack)
+	})
+	blockchain.Register(types.BlockchainProviderTezos.String(), func(ctx context.Context, stack *types.Stack) blockchain.IBlockchainProvider {
+		stack.DisableTokenFactories = true
+		return tezosremoterpc.NewRemoteRPCProvider(ctx, stack)
+	})
+	blockchain.Register(types.BlockchainProviderFabric.String(), func(ctx context.Context, stack *types.Stack) blockchain.IBlockchainProvider {
+		stack.DisableTokenFactories = true
+		return fabric.NewFabricProvider(ctx, stack)
+	})
+	plugin.Register(constants.PluginsDir)
+}
@@ -0,0 +1,162 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stacks
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hyperledger/firefly-cli/internal/blockchain"
+	"github.com/hyperledger/firefly-cli/internal/docker"
+	"github.com/hyperledger/firefly-cli/pkg/types"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+// additionalBlockchain is a blockchain in the stack other than the first one, along with the copy of the stack
+// that its provider works with
+type additionalBlockchain struct {
+	stack    *types.Stack
+	provider blockchain.IBlockchainProvider
+}
+
+// createAdditionalBlockchains adds the additional blockchains selected at init to the stack, allocating ports for
+// them after the ports of the first blockchain, and creates an account for each member on each of them
+func (s *StackManager) createAdditionalBlockchains(options *types.InitOptions) error {
+	for i, chainOptions := range options.AdditionalBlockchains {
+		// Each blockchain gets a block of 5 ports for each member, starting 50 ports after the member's service base port.
+		// The blockchain node of the stack takes the first port in the block of the first member.
+		portOffset := 50 + (i * 5)
+		chainID := chainOptions.ChainID
+		chain := &types.AdditionalBlockchain{
+			BlockchainProvider:     fftypes.FFEnum(chainOptions.BlockchainProvider),
+			BlockchainConnector:    fftypes.FFEnum(chainOptions.BlockchainConnector),
+			BlockchainNodeProvider: fftypes.FFEnum(chainOptions.BlockchainNodeProvider),
+			ChainIDPtr:             &chainID,
+			RemoteNodeURL:          chainOptions.RemoteNodeURL,
+			ExposedBlockchainPort:  options.ServicesBasePort + portOffset,
+			Members:                make([]*types.AdditionalBlockchainMember, len(s.Stack.Members)),
+		}
+		for j := range s.Stack.Members {
+			serviceBase := options.ServicesBasePort + (j * 100)
+			chain.Members[j] = &types.AdditionalBlockchainMember{
+				ExposedConnectorPort: serviceBase + portOffset + 1,
+			}
+			if options.PrometheusEnabled {
+				chain.Members[j].ExposedConnectorMetricsPort = serviceBase + portOffset + 2
+			}
+		}
+		s.Stack.AdditionalBlockchains = append(s.Stack.AdditionalBlockchains, chain)
+	}
+
+	if err := s.loadAdditionalBlockchains(); err != nil {
+		return err
+	}
+
+	for i, chain := range s.additionalBlockchains {
+		for j, member := range chain.stack.Members {
+			account, err := chain.provider.CreateAccount([]string{member.OrgName, member.OrgName, strconv.Itoa(j)})
+			if err != nil {
+				return err
+			}
+			member.Account = account
+			chain.stack.State.Accounts = append(chain.stack.State.Accounts, account)
+			s.Stack.AdditionalBlockchains[i].Members[j].Account = account
+		}
+	}
+	return nil
+}
+
+// loadAdditionalBlockchains creates the providers for the additional blockchains in the stack
+func (s *StackManager) loadAdditionalBlockchains() error {
+	s.additionalBlockchains = make([]*additionalBlockchain, len(s.Stack.AdditionalBlockchains))
+	for i, chain := range s.Stack.AdditionalBlockchains {
+		chainStack := s.Stack.AdditionalBlockchainStack(i + 1)
+		provider := s.newBlockchainProvider(chainStack)
+		if provider == nil {
+			return fmt.Errorf("unsupported blockchain provider '%s' for blockchain %d", chain.BlockchainProvider, i+1)
+		}
+		// Accounts read from stack.json need converting to the provider's account type
+		for j, member := range chainStack.Members {
+			if member.Account != nil {
+				member.Account = provider.ParseAccount(member.Account)
+				chain.Members[j].Account = member.Account
+			}
+		}
+		chainStack.State.Accounts = chainStack.State.Accounts[:0]
+		for _, member := range chainStack.Members {
+			if member.Account != nil {
+				chainStack.State.Accounts = append(chainStack.State.Accounts, member.Account)
+			}
+		}
+		s.additionalBlockchains[i] = &additionalBlockchain{
+			stack:    chainStack,
+			provider: provider,
+		}
+	}
+	return nil
+}
+
+// blockchainAt returns the stack and provider for the blockchain at the given index in the stack, where 0 is
+// the blockchain the stack was created with
+func (s *StackManager) blockchainAt(index int) (*types.Stack, blockchain.IBlockchainProvider, error) {
+	if index == 0 {
+		return s.Stack, s.blockchainProvider, nil
+	}
+	if index < 0 || index > len(s.additionalBlockchains) {
+		return nil, nil, fmt.Errorf("stack '%s' does not have a blockchain %d", s.Stack.Name, index)
+	}
+	chain := s.additionalBlockchains[index-1]
+	return chain.stack, chain.provider, nil
+}
+
+// blockchainServiceDefinitions returns the docker services of every blockchain in the stack
+func (s *StackManager) blockchainServiceDefinitions() []*docker.ServiceDefinition {
+	serviceDefinitions := s.blockchainProvider.GetDockerServiceDefinitions()
+	for _, chain := range s.additionalBlockchains {
+		chainServiceDefinitions := chain.provider.GetDockerServiceDefinitions()
+		if chain.stack.ServicePrefix != "" {
+			docker.PrefixServiceDefinitions(chainServiceDefinitions, chain.stack.ServicePrefix)
+		}
+		serviceDefinitions = append(serviceDefinitions, chainServiceDefinitions...)
+	}
+	return serviceDefinitions
+}
+
+// additionalBlockchainInitOptions returns the init options for writing the config of an additional blockchain,
+// replacing the options that only apply to the first blockchain in the stack
+func additionalBlockchainInitOptions(options *types.InitOptions, chainStack *types.Stack) *types.InitOptions {
+	chainOptions := *options
+	chainOptions.BlockchainProvider = chainStack.BlockchainProvider.String()
+	chainOptions.BlockchainConnector = chainStack.BlockchainConnector.String()
+	chainOptions.BlockchainNodeProvider = chainStack.BlockchainNodeProvider.String()
+	chainOptions.ChainID = chainStack.ChainID()
+	chainOptions.RemoteNodeURL = chainStack.RemoteNodeURL
+	chainOptions.CCPYAMLPaths = nil
+	chainOptions.MSPPaths = nil
+	chainOptions.ContractAddress = ""
+	return &chainOptions
+}
+
+// findMember returns the member of a stack, or of a copy of the stack for an additional blockchain, with the given ID
+func findMember(stack *types.Stack, id string) *types.Organization {
+	for _, member := range stack.Members {
+		if member.ID == id {
+			return member
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stacks
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/hyperledger/firefly-cli/internal/blockchain/ethereum/anvil"
+	"github.com/hyperledger/firefly-cli/pkg/types"
+)
+
+// chainClient returns a client for the dev RPC methods of the blockchain node of the stack, which is only available
+// for nodes that have them
+func (s *StackManager) chainClient() (*anvil.AnvilClient, error) {
+	if !s.Stack.BlockchainNodeProvider.Equals(types.BlockchainNodeProviderAnvil) {
+		return nil, fmt.Errorf("the blockchain node of stack '%s' is %s. Chain commands require the %s node", s.Stack.Name, s.Stack.BlockchainNodeProvider, types.BlockchainNodeProviderAnvil)
+	}
+	return anvil.NewAnvilClient(fmt.Sprintf("http://127.0.0.1:%v", s.Stack.ExposedBlockchainPort)), nil
+}
+
+// MineBlocks mines a number of blocks on the blockchain of the stack straight away
+func (s *StackManager) MineBlocks(blocks int) error {
+	client, err := s.chainClient()
+	if err != nil {
+		return err
+	}
+	return client.Mine(blocks)
+}
+
+// IncreaseTime moves the time of the blockchain of the stack forward, and returns how far it has been moved forward
+// in total
+func (s *StackManager) IncreaseTime(duration time.Duration) (time.Duration, error) {
+	client, err := s.chainClient()
+	if err != nil {
+		return 0, err
+	}
+	offset, err := client.IncreaseTime(int64(duration.Seconds()))
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(offset) * time.Second, nil
+}
+
+// SetBalance sets the balance of an account on the blockchain of the stack in wei
+func (s *StackManager) SetBalance(address string, wei *big.Int) error {
+	client, err := s.chainClient()
+	if err != nil {
+		return err
+	}
+	return client.SetBalance(address, wei)
+}
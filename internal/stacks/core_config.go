@@ -0,0 +1,232 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stacks
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/firefly-cli/pkg/types"
+	"github.com/miracl/conflate"
+	"gopkg.in/yaml.v3"
+)
+
+// coreConfigFile returns the FireFly core config file of a member that is loaded when the member's core starts. Once
+// the stack has been started that is the copy in the runtime directory, before then it is the one in the init directory.
+func (s *StackManager) coreConfigFile(memberIndex int) (*types.Organization, string, error) {
+	if memberIndex < 0 || memberIndex >= len(s.Stack.Members) {
+		return nil, "", fmt.Errorf("stack '%s' does not have a member %d", s.Stack.Name, memberIndex)
+	}
+	member := s.Stack.Members[memberIndex]
+	hasRunBefore, err := s.Stack.HasRunBefore()
+	if err != nil {
+		return nil, "", err
+	}
+	dir := s.Stack.InitDir
+	if hasRunBefore {
+		dir = s.Stack.RuntimeDir
+	}
+	return member, filepath.Join(dir, "config", fmt.Sprintf("firefly_core_%s.yml", member.ID)), nil
+}
+
+// SetCoreConfig sets a dotted key, such as "log.level", in the FireFly core config of a member and restarts the
+// member's core so that it picks up the change. The value is parsed as YAML, so numbers and booleans keep their type.
+func (s *StackManager) SetCoreConfig(memberIndex int, key, value string) (messages []string, err error) {
+	member, configFile, err := s.coreConfigFile(memberIndex)
+	if err != nil {
+		return nil, err
+	}
+	path, err := splitConfigKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsedValue interface{}
+	if err := yaml.Unmarshal([]byte(value), &parsedValue); err != nil || parsedValue == nil {
+		parsedValue = value
+	}
+	var patch interface{} = parsedValue
+	for i := len(path) - 1; i >= 0; i-- {
+		patch = map[string]interface{}{path[i]: patch}
+	}
+	patchBytes, err := yaml.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+
+	merger := conflate.New()
+	if err := merger.AddFiles(configFile); err != nil {
+		return nil, fmt.Errorf("failed merging config %s", configFile)
+	}
+	if err := merger.AddData(patchBytes); err != nil {
+		return nil, fmt.Errorf("failed merging '%s' into config: %s", key, err)
+	}
+	configBytes, err := merger.MarshalYAML()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(configFile, configBytes, 0755); err != nil {
+		return nil, err
+	}
+	return s.restartCore(member, configFile)
+}
+
+// UnsetCoreConfig removes a dotted key from the FireFly core config of a member, so that FireFly core falls back
+// to its default, and restarts the member's core so that it picks up the change
+func (s *StackManager) UnsetCoreConfig(memberIndex int, key string) (messages []string, err error) {
+	member, configFile, err := s.coreConfigFile(memberIndex)
+	if err != nil {
+		return nil, err
+	}
+	path, err := splitConfigKey(key)
+	if err != nil {
+		return nil, err
+	}
+	config, err := readYAMLNode(configFile)
+	if err != nil {
+		return nil, err
+	}
+
+	parent := configNodeAt(config, path[:len(path)-1])
+	removed := false
+	if parent != nil && parent.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(parent.Content); i += 2 {
+			if parent.Content[i].Value == path[len(path)-1] {
+				parent.Content = append(parent.Content[:i], parent.Content[i+2:]...)
+				removed = true
+				break
+			}
+		}
+	}
+	if !removed {
+		return nil, fmt.Errorf("key '%s' is not set in %s", key, configFile)
+	}
+
+	if err := writeYAMLNode(configFile, config); err != nil {
+		return nil, err
+	}
+	return s.restartCore(member, configFile)
+}
+
+// GetCoreConfigFile returns the value of a dotted key in the generated FireFly core config file of a member, or the
+// whole file if the key is empty. Numeric parts of the key select an entry in a list, for example
+// "namespaces.predefined.0.name". The defaults that FireFly core uses for keys missing from the file are not included.
+func (s *StackManager) GetCoreConfigFile(memberIndex int, key string) (interface{}, error) {
+	_, configFile, err := s.coreConfigFile(memberIndex)
+	if err != nil {
+		return nil, err
+	}
+	config, err := readYAMLNode(configFile)
+	if err != nil {
+		return nil, err
+	}
+	var path []string
+	if key != "" {
+		if path, err = splitConfigKey(key); err != nil {
+			return nil, err
+		}
+	}
+	node := configNodeAt(config, path)
+	if node == nil {
+		return nil, fmt.Errorf("key '%s' is not set in %s", key, configFile)
+	}
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// restartCore restarts the FireFly core of a member after its config has changed. Nothing needs restarting before
+// the stack has been started, and the cores of external members have to be restarted by hand.
+func (s *StackManager) restartCore(member *types.Organization, configFile string) (messages []string, err error) {
+	hasRunBefore, err := s.Stack.HasRunBefore()
+	if err != nil || !hasRunBefore {
+		return nil, err
+	}
+	if member.External {
+		return []string{fmt.Sprintf("please restart your firefly core for member %s to load the new config from %s", member.ID, configFile)}, nil
+	}
+	s.Log.Info(fmt.Sprintf("restarting FireFly core for member %s", member.ID))
+	return nil, s.runDockerComposeCommand("restart", fmt.Sprintf("firefly_core_%s", member.ID))
+}
+
+func splitConfigKey(key string) ([]string, error) {
+	path := strings.Split(key, ".")
+	for _, part := range path {
+		if part == "" {
+			return nil, fmt.Errorf("invalid config key '%s'", key)
+		}
+	}
+	return path, nil
+}
+
+func readYAMLNode(filename string) (*yaml.Node, error) {
+	configBytes, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var config yaml.Node
+	if err := yaml.Unmarshal(configBytes, &config); err != nil {
+		return nil, err
+	}
+	if len(config.Content) == 0 {
+		return nil, fmt.Errorf("%s is empty", filename)
+	}
+	return &config, nil
+}
+
+// writeYAMLNode writes a config file with the same two space indentation as the config files generated by the CLI
+func writeYAMLNode(filename string, config *yaml.Node) error {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(config); err != nil {
+		return err
+	}
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+	return os.WriteFile(filename, buf.Bytes(), 0755)
+}
+
+// configNodeAt walks a dotted key path from the document node of a config file, returning nil if a key is not set
+func configNodeAt(config *yaml.Node, path []string) *yaml.Node {
+	node := config.Content[0]
+	for _, part := range path {
+		switch node.Kind {
+		case yaml.MappingNode:
+			node = yamlMappingValue(node, part)
+		case yaml.SequenceNode:
+			index, err := strconv.Atoi(part)
+			if err != nil || index < 0 || index >= len(node.Content) {
+				return nil
+			}
+			node = node.Content[index]
+		default:
+			return nil
+		}
+		if node == nil {
+			return nil
+		}
+	}
+	return node
+}
@@ -0,0 +1,257 @@
+package stacks
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/firefly-cli/internal/constants"
+	"github.com/hyperledger/firefly-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+const testMemberCoreConfig = `log:
+  level: debug
+http:
+  port: 5000
+  address: 0.0.0.0
+namespaces:
+  default: default
+  predefined:
+    - name: default
+      plugins:
+        - database0
+        - blockchain0
+`
+
+// newCoreConfigTestStackManager returns a stack manager for a stack that has not been started, with a core config
+// file for one member in its init directory
+func newCoreConfigTestStackManager(t *testing.T) (*StackManager, string) {
+	stacksDir := constants.StacksDir
+	constants.StacksDir = t.TempDir()
+	t.Cleanup(func() { constants.StacksDir = stacksDir })
+
+	stack := &types.Stack{
+		Name:    "config",
+		Members: []*types.Organization{{ID: "0"}},
+		InitDir: filepath.Join(constants.StacksDir, "config", "init"),
+	}
+	stack.RuntimeDir = filepath.Join(constants.StacksDir, "config", "runtime")
+	configFile := filepath.Join(stack.InitDir, "config", "firefly_core_0.yml")
+	assert.NoError(t, os.MkdirAll(filepath.Dir(configFile), 0755))
+	assert.NoError(t, os.WriteFile(configFile, []byte(testMemberCoreConfig), 0755))
+	return &StackManager{Stack: stack}, configFile
+}
+
+func parseTestCoreConfig(t *testing.T, configYAML string) map[string]interface{} {
+	var config map[string]interface{}
+	assert.NoError(t, yaml.Unmarshal([]byte(configYAML), &config))
+	return config
+}
+
+func readTestCoreConfig(t *testing.T, configFile string) map[string]interface{} {
+	return parseTestCoreConfig(t, readFileString(t, configFile))
+}
+
+func TestSplitConfigKey(t *testing.T) {
+	testCases := []struct {
+		Key   string
+		Path  []string
+		Error string
+	}{
+		{Key: "log", Path: []string{"log"}},
+		{Key: "log.level", Path: []string{"log", "level"}},
+		{Key: "namespaces.predefined.0.name", Path: []string{"namespaces", "predefined", "0", "name"}},
+		{Key: "", Error: "invalid config key ''"},
+		{Key: "log..level", Error: "invalid config key 'log..level'"},
+		{Key: ".log", Error: "invalid config key '.log'"},
+		{Key: "log.", Error: "invalid config key 'log.'"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Key, func(t *testing.T) {
+			path, err := splitConfigKey(tc.Key)
+			if tc.Error != "" {
+				assert.EqualError(t, err, tc.Error)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.Path, path)
+			}
+		})
+	}
+}
+
+func TestConfigNodeAt(t *testing.T) {
+	var config yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(testMemberCoreConfig), &config))
+
+	testCases := []struct {
+		Name  string
+		Path  []string
+		Value string
+		Kind  yaml.Kind
+	}{
+		{Name: "root", Path: nil, Kind: yaml.MappingNode},
+		{Name: "map", Path: []string{"http"}, Kind: yaml.MappingNode},
+		{Name: "nested key", Path: []string{"log", "level"}, Value: "debug", Kind: yaml.ScalarNode},
+		{Name: "list", Path: []string{"namespaces", "predefined"}, Kind: yaml.SequenceNode},
+		{Name: "list index", Path: []string{"namespaces", "predefined", "0", "name"}, Value: "default", Kind: yaml.ScalarNode},
+		{Name: "nested list index", Path: []string{"namespaces", "predefined", "0", "plugins", "1"}, Value: "blockchain0", Kind: yaml.ScalarNode},
+		{Name: "missing key", Path: []string{"log", "format"}},
+		{Name: "index out of range", Path: []string{"namespaces", "predefined", "1"}},
+		{Name: "negative index", Path: []string{"namespaces", "predefined", "-1"}},
+		{Name: "key in list", Path: []string{"namespaces", "predefined", "name"}},
+		{Name: "key below scalar", Path: []string{"log", "level", "value"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			node := configNodeAt(&config, tc.Path)
+			if tc.Kind == 0 {
+				assert.Nil(t, node)
+				return
+			}
+			assert.NotNil(t, node)
+			assert.Equal(t, tc.Kind, node.Kind)
+			assert.Equal(t, tc.Value, node.Value)
+		})
+	}
+}
+
+func TestGetCoreConfigFile(t *testing.T) {
+	s, _ := newCoreConfigTestStackManager(t)
+
+	testCases := []struct {
+		Key    string
+		Member int
+		Value  interface{}
+		Error  string
+	}{
+		{Key: "log.level", Value: "debug"},
+		{Key: "http.port", Value: 5000},
+		{Key: "http", Value: map[string]interface{}{"port": 5000, "address": "0.0.0.0"}},
+		{Key: "namespaces.predefined.0.name", Value: "default"},
+		{Key: "namespaces.predefined.0.plugins", Value: []interface{}{"database0", "blockchain0"}},
+		{Key: "log.format", Error: "key 'log.format' is not set"},
+		{Key: "namespaces.predefined.1", Error: "key 'namespaces.predefined.1' is not set"},
+		{Key: "log..level", Error: "invalid config key 'log..level'"},
+		{Key: "log.level", Member: 1, Error: "stack 'config' does not have a member 1"},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s_%d", tc.Key, tc.Member), func(t *testing.T) {
+			value, err := s.GetCoreConfigFile(tc.Member, tc.Key)
+			if tc.Error != "" {
+				assert.ErrorContains(t, err, tc.Error)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.Value, value)
+			}
+		})
+	}
+
+	config, err := s.GetCoreConfigFile(0, "")
+	assert.NoError(t, err)
+	assert.Contains(t, config, "namespaces")
+}
+
+func TestSetCoreConfig(t *testing.T) {
+	testCases := []struct {
+		Name  string
+		Key   string
+		Value string
+		Path  []string
+		Want  interface{}
+		Error string
+	}{
+		{Name: "existing key", Key: "log.level", Value: "info", Path: []string{"log", "level"}, Want: "info"},
+		{Name: "number", Key: "http.port", Value: "5100", Path: []string{"http", "port"}, Want: 5100},
+		{Name: "boolean", Key: "metrics.enabled", Value: "true", Path: []string{"metrics", "enabled"}, Want: true},
+		{Name: "new nested key", Key: "event.transports.websockets.readBufferSize", Value: "16Kb", Path: []string{"event", "transports", "websockets", "readBufferSize"}, Want: "16Kb"},
+		{Name: "empty value", Key: "log.level", Value: "", Path: []string{"log", "level"}, Want: ""},
+		{Name: "list index", Key: "namespaces.predefined.0.name", Value: "ns1", Error: "failed merging 'namespaces.predefined.0.name' into config"},
+		{Name: "invalid key", Key: "log.", Value: "info", Error: "invalid config key 'log.'"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			s, configFile := newCoreConfigTestStackManager(t)
+			messages, err := s.SetCoreConfig(0, tc.Key, tc.Value)
+			if tc.Error != "" {
+				assert.ErrorContains(t, err, tc.Error)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Empty(t, messages)
+
+			var value interface{} = readTestCoreConfig(t, configFile)
+			for _, part := range tc.Path {
+				value = value.(map[string]interface{})[part]
+			}
+			assert.Equal(t, tc.Want, value)
+			// The rest of the config is left as it was
+			config := readTestCoreConfig(t, configFile)
+			assert.Equal(t, "0.0.0.0", config["http"].(map[string]interface{})["address"])
+			assert.Len(t, config["namespaces"].(map[string]interface{})["predefined"], 1)
+		})
+	}
+}
+
+func TestUnsetCoreConfig(t *testing.T) {
+	namespaces := parseTestCoreConfig(t, testMemberCoreConfig)["namespaces"]
+	testCases := []struct {
+		Name   string
+		Key    string
+		Config map[string]interface{}
+		Error  string
+	}{
+		{
+			Name: "nested key",
+			Key:  "http.port",
+			Config: map[string]interface{}{
+				"log":        map[string]interface{}{"level": "debug"},
+				"http":       map[string]interface{}{"address": "0.0.0.0"},
+				"namespaces": namespaces,
+			},
+		},
+		{
+			Name: "last key of a map",
+			Key:  "log.level",
+			Config: map[string]interface{}{
+				"log":        map[string]interface{}{},
+				"http":       map[string]interface{}{"port": 5000, "address": "0.0.0.0"},
+				"namespaces": namespaces,
+			},
+		},
+		{
+			Name: "top level key",
+			Key:  "namespaces",
+			Config: map[string]interface{}{
+				"log":  map[string]interface{}{"level": "debug"},
+				"http": map[string]interface{}{"port": 5000, "address": "0.0.0.0"},
+			},
+		},
+		{Name: "missing key", Key: "log.format", Error: "key 'log.format' is not set"},
+		{Name: "missing parent", Key: "metrics.enabled", Error: "key 'metrics.enabled' is not set"},
+		{Name: "list index", Key: "namespaces.predefined.0", Error: "key 'namespaces.predefined.0' is not set"},
+		{Name: "invalid key", Key: "..", Error: "invalid config key '..'"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			s, configFile := newCoreConfigTestStackManager(t)
+			messages, err := s.UnsetCoreConfig(0, tc.Key)
+			if tc.Error != "" {
+				assert.ErrorContains(t, err, tc.Error)
+				assert.Equal(t, testMemberCoreConfig, readFileString(t, configFile))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Empty(t, messages)
+			assert.Equal(t, tc.Config, readTestCoreConfig(t, configFile))
+		})
+	}
+}
+
+func readFileString(t *testing.T, filename string) string {
+	fileBytes, err := os.ReadFile(filename)
+	assert.NoError(t, err)
+	return string(fileBytes)
+}
@@ -18,12 +18,23 @@ package stacks
 
 import (
 	"fmt"
+	"path"
+
+	"github.com/hyperledger/firefly-cli/internal/constants"
 )
 
 type DataExchangeListenerConfig struct {
-	Hostname string `json:"hostname,omitempty"`
-	Endpoint string `json:"endpoint,omitempty"`
-	Port     int    `json:"port,omitempty"`
+	Hostname string                 `json:"hostname,omitempty"`
+	Endpoint string                 `json:"endpoint,omitempty"`
+	Port     int                    `json:"port,omitempty"`
+	TLS      *DataExchangeTLSConfig `json:"tls,omitempty"`
+}
+
+type DataExchangeTLSConfig struct {
+	Enabled  bool   `json:"enabled,omitempty"`
+	CertFile string `json:"certFile,omitempty"`
+	KeyFile  string `json:"keyFile,omitempty"`
+	CAFile   string `json:"caFile,omitempty"`
 }
 
 type PeerConfig struct {
@@ -38,10 +49,20 @@ type DataExchangePeerConfig struct {
 }
 
 func (s *StackManager) GenerateDataExchangeHTTPSConfig(memberID string) *DataExchangePeerConfig {
+	var apiTLS *DataExchangeTLSConfig
+	if s.Stack.TLSEnabled {
+		apiTLS = &DataExchangeTLSConfig{
+			Enabled:  true,
+			CertFile: path.Join(constants.TLSCertsDir, "cert.pem"),
+			KeyFile:  path.Join(constants.TLSCertsDir, "key.pem"),
+			CAFile:   path.Join(constants.TLSCertsDir, "ca.pem"),
+		}
+	}
 	return &DataExchangePeerConfig{
 		API: &DataExchangeListenerConfig{
 			Hostname: "0.0.0.0",
 			Port:     3000,
+			TLS:      apiTLS,
 		},
 		P2P: &DataExchangeListenerConfig{
 			Hostname: "0.0.0.0",
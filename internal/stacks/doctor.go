@@ -0,0 +1,355 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stacks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/firefly-cli/internal/blockchain/fabric"
+	"github.com/hyperledger/firefly-cli/internal/constants"
+	"github.com/hyperledger/firefly-cli/internal/docker"
+	"github.com/hyperledger/firefly-cli/pkg/types"
+)
+
+// minimumFreeDiskSpaceKB is the free space in docker's data root below which a stack may fail to pull its images or
+// fill up its volumes
+const minimumFreeDiskSpaceKB = 10 * 1024 * 1024
+
+// Doctor runs the preflight checks of the host and of every stack in FIREFLY_HOME, for the problems that most often
+// stop a stack from being created or started. Each finding that did not pass says what to do about it.
+func Doctor(ctx context.Context) []*types.DoctorCheck {
+	checks := []*types.DoctorCheck{checkDockerClient()}
+	daemonCheck, daemonReachable := checkDockerDaemon()
+	checks = append(checks, daemonCheck, checkDockerCompose())
+	checks = append(checks, checkDiskSpace(ctx, daemonReachable), checkOpenSSL(), checkFireFlyHome())
+
+	stackManagers, loadChecks := loadDoctorStacks(ctx)
+	checks = append(checks, loadChecks...)
+	checks = append(checks, checkArchitecture(stackManagers), checkHostDockerInternal(ctx, stackManagers, daemonReachable))
+	for _, s := range stackManagers {
+		checks = append(checks, s.checkStackPorts(daemonReachable))
+	}
+	return checks
+}
+
+func checkDockerClient() *types.DoctorCheck {
+	check := &types.DoctorCheck{Name: "docker"}
+	version, err := docker.GetDockerClientVersion()
+	if err != nil {
+		check.Status = types.DoctorStatusError
+		check.Message = fmt.Sprintf("docker is not installed: %s", err)
+		check.Remediation = "Install Docker Desktop, or Docker Engine on Linux, from https://docs.docker.com/get-docker/ and make sure the docker command is on your PATH"
+		return check
+	}
+	return checkMinimumVersion(check, "docker", version, docker.MinimumDockerVersion, "Upgrade Docker Desktop, or Docker Engine on Linux, from https://docs.docker.com/get-docker/")
+}
+
+func checkDockerDaemon() (*types.DoctorCheck, bool) {
+	check := &types.DoctorCheck{Name: "docker daemon"}
+	version, err := docker.GetDockerServerVersion()
+	if err != nil || version == "" {
+		check.Status = types.DoctorStatusError
+		check.Message = "the docker daemon is not reachable"
+		check.Remediation = "Start Docker Desktop, or the docker service on Linux with 'sudo systemctl start docker'. If it is running, check that your user can use it, e.g. by adding it to the docker group with 'sudo usermod -aG docker $USER' and logging in again, and that DOCKER_HOST or the docker context points at it"
+		return check, false
+	}
+	return checkMinimumVersion(check, "the docker daemon", version, docker.MinimumDockerVersion, "Upgrade Docker Desktop, or Docker Engine on Linux, from https://docs.docker.com/get-docker/"), true
+}
+
+func checkDockerCompose() *types.DoctorCheck {
+	check := &types.DoctorCheck{Name: "docker compose"}
+	if version, err := docker.GetDockerComposeVersion(docker.ComposeV2); err == nil {
+		return checkMinimumVersion(check, "docker compose", version, docker.MinimumComposeVersion, "Upgrade Docker Desktop, or the docker-compose-plugin package on Linux")
+	}
+	if version, err := docker.GetDockerComposeVersion(docker.ComposeV1); err == nil {
+		check.Status = types.DoctorStatusWarning
+		check.Message = fmt.Sprintf("only docker-compose %s (V1) is installed, which is no longer supported by docker", version)
+		check.Remediation = "Install docker compose V2, which comes with Docker Desktop, or is the docker-compose-plugin package on Linux"
+		return check
+	}
+	check.Status = types.DoctorStatusError
+	check.Message = "docker compose is not installed"
+	check.Remediation = "Install docker compose V2, which comes with Docker Desktop, or is the docker-compose-plugin package on Linux"
+	return check
+}
+
+func checkMinimumVersion(check *types.DoctorCheck, name, version, minimum, remediation string) *types.DoctorCheck {
+	cmp, err := docker.CompareVersions(version, minimum)
+	switch {
+	case err != nil:
+		check.Status = types.DoctorStatusWarning
+		check.Message = fmt.Sprintf("could not compare the version '%s' of %s with the minimum %s", version, name, minimum)
+		check.Remediation = remediation
+	case cmp < 0:
+		check.Status = types.DoctorStatusError
+		check.Message = fmt.Sprintf("%s %s is older than the minimum %s", name, version, minimum)
+		check.Remediation = remediation
+	default:
+		check.Status = types.DoctorStatusOK
+		check.Message = fmt.Sprintf("%s %s (minimum %s)", name, version, minimum)
+	}
+	return check
+}
+
+func checkDiskSpace(ctx context.Context, daemonReachable bool) *types.DoctorCheck {
+	check := &types.DoctorCheck{Name: "disk space"}
+	if !daemonReachable {
+		check.Status = types.DoctorStatusSkipped
+		check.Message = "the docker daemon is not reachable"
+		return check
+	}
+	rootDir, err := docker.GetDockerRootDir()
+	if err != nil {
+		rootDir = "docker's data root"
+	}
+	// The data root may be inside the VM of Docker Desktop, so look at the free space from inside a container, whose
+	// filesystem is stored in the data root
+	out, err := docker.RunDockerCommandBuffered(ctx, "", "run", "--rm", constants.DoctorImageName, "df", "-Pk", "/")
+	availableKB, parseErr := parseDFAvailable(out)
+	if err != nil || parseErr != nil {
+		check.Status = types.DoctorStatusWarning
+		check.Message = fmt.Sprintf("could not check the free space in %s", rootDir)
+		check.Remediation = fmt.Sprintf("Check that docker can run the %s image, or check the free space of %s yourself", constants.DoctorImageName, rootDir)
+		return check
+	}
+	freeGiB := float64(availableKB) / (1024 * 1024)
+	if availableKB < minimumFreeDiskSpaceKB {
+		check.Status = types.DoctorStatusWarning
+		check.Message = fmt.Sprintf("only %.1f GiB free in %s, which may not be enough to pull the images of a stack and store its data", freeGiB, rootDir)
+		check.Remediation = "Free up space with 'docker system prune' and 'docker volume prune', or increase the virtual disk limit in the Resources settings of Docker Desktop"
+		return check
+	}
+	check.Status = types.DoctorStatusOK
+	check.Message = fmt.Sprintf("%.1f GiB free in %s", freeGiB, rootDir)
+	return check
+}
+
+// parseDFAvailable returns the available kilobytes from the output of 'df -Pk' for a single filesystem
+func parseDFAvailable(out string) (int64, error) {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) < 2 {
+		return 0, fmt.Errorf("unexpected output from df: %s", out)
+	}
+	fields := strings.Fields(lines[len(lines)-1])
+	if len(fields) < 4 {
+		return 0, fmt.Errorf("unexpected output from df: %s", out)
+	}
+	return strconv.ParseInt(fields[3], 10, 64)
+}
+
+func checkOpenSSL() *types.DoctorCheck {
+	check := &types.DoctorCheck{Name: "openssl"}
+	path, err := exec.LookPath("openssl")
+	if err != nil {
+		check.Status = types.DoctorStatusError
+		check.Message = "openssl is not installed, which ff init needs to create the certificates of each member's data exchange"
+		check.Remediation = "Install openssl with 'brew install openssl' on macOS, or your package manager on Linux such as 'sudo apt-get install openssl', and make sure it is on your PATH"
+		return check
+	}
+	check.Status = types.DoctorStatusOK
+	check.Message = fmt.Sprintf("openssl found at %s", path)
+	return check
+}
+
+func checkFireFlyHome() *types.DoctorCheck {
+	check := &types.DoctorCheck{Name: "FIREFLY_HOME"}
+	homeDir := filepath.Dir(constants.StacksDir)
+	// The stacks directory is created by the first ff init, so check the closest directory that exists
+	dir := constants.StacksDir
+	info, err := os.Stat(dir)
+	for errors.Is(err, os.ErrNotExist) && filepath.Dir(dir) != dir {
+		dir = filepath.Dir(dir)
+		info, err = os.Stat(dir)
+	}
+	remediation := fmt.Sprintf("Give your user ownership of %s, e.g. with 'sudo chown -R $(whoami) %s', or set FIREFLY_HOME to a directory you can write to", dir, dir)
+	switch {
+	case err != nil:
+		check.Status = types.DoctorStatusError
+		check.Message = fmt.Sprintf("could not read %s: %s", dir, err)
+		check.Remediation = remediation
+		return check
+	case !info.IsDir():
+		check.Status = types.DoctorStatusError
+		check.Message = fmt.Sprintf("%s is not a directory", dir)
+		check.Remediation = fmt.Sprintf("Move %s out of the way, or set FIREFLY_HOME to another directory", dir)
+		return check
+	}
+	f, err := os.CreateTemp(dir, ".ff-doctor-*")
+	if err != nil {
+		check.Status = types.DoctorStatusError
+		check.Message = fmt.Sprintf("%s is not writable: %s", dir, err)
+		check.Remediation = remediation
+		return check
+	}
+	f.Close()
+	os.Remove(f.Name())
+	check.Status = types.DoctorStatusOK
+	check.Message = fmt.Sprintf("%s is writable", homeDir)
+	return check
+}
+
+// loadDoctorStacks loads every stack in FIREFLY_HOME, with a finding for each stack that could not be loaded
+func loadDoctorStacks(ctx context.Context) ([]*StackManager, []*types.DoctorCheck) {
+	stackNames, err := ListStacks()
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, []*types.DoctorCheck{{
+			Name:        "stacks",
+			Status:      types.DoctorStatusError,
+			Message:     fmt.Sprintf("could not list the stacks in %s: %s", constants.StacksDir, err),
+			Remediation: fmt.Sprintf("Check the permissions of %s", constants.StacksDir),
+		}}
+	}
+	var stackManagers []*StackManager
+	var checks []*types.DoctorCheck
+	for _, stackName := range stackNames {
+		s := NewStackManager(ctx)
+		if err := s.LoadStack(stackName); err != nil {
+			checks = append(checks, &types.DoctorCheck{
+				Name:        fmt.Sprintf("stack %s", stackName),
+				Status:      types.DoctorStatusError,
+				Message:     fmt.Sprintf("could not load the stack: %s", err),
+				Remediation: fmt.Sprintf("Fix or remove %s, or remove the stack with 'ff remove %s'", filepath.Join(constants.StacksDir, stackName, "stack.json"), stackName),
+			})
+			continue
+		}
+		stackManagers = append(stackManagers, s)
+	}
+	return stackManagers, checks
+}
+
+// noARM64Images returns the images that have no arm64 variant, which run under amd64 emulation on arm64 hosts
+func noARM64Images() map[string]bool {
+	images := map[string]bool{
+		fabric.FabricCAImageName:      true,
+		fabric.FabricOrdererImageName: true,
+		fabric.FabricPeerImageName:    true,
+		fabric.FabricToolsImageName:   true,
+	}
+	for image := range unsupportedARM64Images {
+		images[image] = true
+	}
+	return images
+}
+
+func checkArchitecture(stackManagers []*StackManager) *types.DoctorCheck {
+	check := &types.DoctorCheck{Name: "architecture"}
+	if runtime.GOARCH != "arm64" {
+		check.Status = types.DoctorStatusOK
+		check.Message = fmt.Sprintf("%s runs every image natively", runtime.GOARCH)
+		return check
+	}
+	emulated := noARM64Images()
+	var affected []string
+	for _, s := range stackManagers {
+		for _, service := range s.buildDockerCompose().Services {
+			if emulated[service.Image] || (s.Stack.BlockchainProvider.Equals(types.BlockchainProviderFabric) && strings.HasPrefix(service.Image, "hyperledger/fabric")) {
+				affected = append(affected, s.Stack.Name)
+				break
+			}
+		}
+	}
+	images := make([]string, 0, len(emulated))
+	for image := range emulated {
+		images = append(images, image)
+	}
+	sort.Strings(images)
+	check.Status = types.DoctorStatusWarning
+	check.Message = fmt.Sprintf("arm64 runs these images under amd64 emulation, as they have no arm64 variant: %s", strings.Join(images, ", "))
+	if len(affected) > 0 {
+		check.Message += fmt.Sprintf(". They are used by the stacks: %s", strings.Join(affected, ", "))
+	}
+	check.Remediation = "Turn on 'Use Rosetta for x86_64/amd64 emulation' in the settings of Docker Desktop, or install the amd64 emulator on Linux with 'docker run --privileged --rm tonistiigi/binfmt --install amd64'"
+	return check
+}
+
+// checkHostDockerInternal checks that containers can resolve host.docker.internal, which is how the services of a
+// stack reach the FireFly cores of its external members, which run on the host
+func checkHostDockerInternal(ctx context.Context, stackManagers []*StackManager, daemonReachable bool) *types.DoctorCheck {
+	check := &types.DoctorCheck{Name: "host.docker.internal"}
+	var external []string
+	for _, s := range stackManagers {
+		for _, member := range s.Stack.Members {
+			if member.External {
+				external = append(external, s.Stack.Name)
+				break
+			}
+		}
+	}
+	switch {
+	case len(external) == 0:
+		check.Status = types.DoctorStatusSkipped
+		check.Message = "no stacks have external members"
+		return check
+	case !daemonReachable:
+		check.Status = types.DoctorStatusSkipped
+		check.Message = "the docker daemon is not reachable"
+		return check
+	}
+	out, err := docker.RunDockerCommandBuffered(ctx, "", "run", "--rm", constants.DoctorImageName, "nslookup", "host.docker.internal")
+	if err != nil || !strings.Contains(out, "Name:") {
+		check.Status = types.DoctorStatusError
+		check.Message = fmt.Sprintf("containers cannot resolve host.docker.internal, so the stacks with external members cannot reach them: %s", strings.Join(external, ", "))
+		check.Remediation = "Docker Desktop resolves host.docker.internal itself. On Linux, add 'host.docker.internal:host-gateway' to the extra_hosts of the services that connect to the external members, in the docker-compose.override.yml of each of those stacks"
+		return check
+	}
+	check.Status = types.DoctorStatusOK
+	check.Message = fmt.Sprintf("containers can resolve host.docker.internal, for the external members of the stacks: %s", strings.Join(external, ", "))
+	return check
+}
+
+// checkStackPorts checks that the ports the stack exposes are free, unless the stack is running, when they are in
+// use by its own containers
+func (s *StackManager) checkStackPorts(daemonReachable bool) *types.DoctorCheck {
+	check := &types.DoctorCheck{Name: fmt.Sprintf("stack %s ports", s.Stack.Name)}
+	if daemonReachable {
+		if out, err := docker.RunDockerComposeCommandReturnsStdout(s.Stack.StackDir, "ps", "-q"); err == nil && len(strings.TrimSpace(string(out))) > 0 {
+			check.Status = types.DoctorStatusOK
+			check.Message = "the stack is running, so its ports are in use by its own containers"
+			return check
+		}
+	}
+	var unavailable []string
+	for _, port := range s.stackPorts() {
+		if port == 0 {
+			continue
+		}
+		if available, err := checkPortAvailable(port); err != nil || !available {
+			unavailable = append(unavailable, strconv.Itoa(port))
+		}
+	}
+	if len(unavailable) > 0 {
+		check.Status = types.DoctorStatusError
+		check.Message = fmt.Sprintf("ports in use by other processes: %s", strings.Join(unavailable, ", "))
+		check.Remediation = fmt.Sprintf("Stop the processes listening on those ports, which may be another stack that is running, or remove the stack with 'ff remove %s' and create it again with 'ff init' and different --firefly-base-port and --services-base-port values", s.Stack.Name)
+		return check
+	}
+	check.Status = types.DoctorStatusOK
+	check.Message = "every port the stack exposes is free"
+	return check
+}
@@ -0,0 +1,247 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stacks
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/hyperledger/firefly-cli/internal/core"
+	"github.com/hyperledger/firefly-cli/pkg/types"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+// EventTypes are the types of event FireFly core delivers to subscriptions
+var EventTypes = []string{
+	"transaction_submitted",
+	"message_confirmed",
+	"message_rejected",
+	"datatype_confirmed",
+	"identity_confirmed",
+	"identity_updated",
+	"token_pool_confirmed",
+	"token_pool_op_failed",
+	"token_transfer_confirmed",
+	"token_transfer_op_failed",
+	"token_approval_confirmed",
+	"token_approval_op_failed",
+	"contract_interface_confirmed",
+	"contract_api_confirmed",
+	"blockchain_event_received",
+	"blockchain_invoke_op_succeeded",
+	"blockchain_invoke_op_failed",
+	"blockchain_contract_deploy_op_succeeded",
+	"blockchain_contract_deploy_op_failed",
+}
+
+type eventSubscriptionStart struct {
+	Type      string                   `json:"type"`
+	Namespace string                   `json:"namespace"`
+	Ephemeral bool                     `json:"ephemeral"`
+	AutoAck   bool                     `json:"autoack"`
+	Filter    *eventSubscriptionFilter `json:"filter,omitempty"`
+	Options   map[string]interface{}   `json:"options,omitempty"`
+}
+
+type eventSubscriptionFilter struct {
+	Events          string                      `json:"events,omitempty"`
+	Topic           string                      `json:"topic,omitempty"`
+	Message         *eventMessageFilter         `json:"message,omitempty"`
+	Transaction     *eventTransactionFilter     `json:"transaction,omitempty"`
+	BlockchainEvent *eventBlockchainEventFilter `json:"blockchainevent,omitempty"`
+}
+
+type eventMessageFilter struct {
+	Tag    string `json:"tag,omitempty"`
+	Group  string `json:"group,omitempty"`
+	Author string `json:"author,omitempty"`
+}
+
+type eventTransactionFilter struct {
+	Type string `json:"type,omitempty"`
+}
+
+type eventBlockchainEventFilter struct {
+	Name     string `json:"name,omitempty"`
+	Listener string `json:"listener,omitempty"`
+}
+
+// Events opens an ephemeral subscription on the websocket of the FireFly core of a member, and prints every event
+// it delivers until the connection is closed or the context of the stack manager is cancelled. The filters are the
+// same regular expressions that FireFly subscriptions use.
+func (s *StackManager) Events(options *types.EventsOptions) error {
+	if options.Member < 0 || options.Member >= len(s.Stack.Members) {
+		return fmt.Errorf("stack '%s' does not have a member %d", s.Stack.Name, options.Member)
+	}
+	member := s.Stack.Members[options.Member]
+	namespace := options.Namespace
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+	output := options.Output
+	if output == "" {
+		output = types.EventsOutputText.String()
+	}
+	if _, err := fftypes.FFEnumParseString(s.ctx, types.EventsOutput, output); err != nil {
+		return err
+	}
+
+	wsScheme := "ws"
+	if s.Stack.TLSEnabled {
+		wsScheme = "wss"
+	}
+	wsURL := fmt.Sprintf("%s://127.0.0.1:%d/ws", wsScheme, member.ExposedFireflyPort)
+	conn, err := core.DialWebSocket(s.ctx, wsURL, s.Stack.MemberBasicAuth(member))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err := conn.WriteJSON(eventSubscriptionStartFor(namespace, options)); err != nil {
+		return err
+	}
+	s.Log.Info(fmt.Sprintf("watching events in namespace '%s' of member %s", namespace, member.ID))
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-s.ctx.Done():
+			_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	for {
+		_, payload, err := conn.ReadMessage()
+		if err != nil {
+			if s.ctx.Err() != nil || websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+				return nil
+			}
+			return err
+		}
+		var event map[string]interface{}
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return err
+		}
+		if event["type"] == "protocol_error" {
+			return fmt.Errorf("FireFly rejected the subscription: %v", event["error"])
+		}
+		if output == types.EventsOutputJSON.String() {
+			fmt.Println(string(payload))
+		} else {
+			fmt.Println(formatEvent(event))
+		}
+	}
+}
+
+func eventSubscriptionStartFor(namespace string, options *types.EventsOptions) *eventSubscriptionStart {
+	start := &eventSubscriptionStart{
+		Type:      "start",
+		Namespace: namespace,
+		Ephemeral: true,
+		AutoAck:   true,
+	}
+	if options.WithData {
+		start.Options = map[string]interface{}{"withData": true}
+	}
+
+	filter := &eventSubscriptionFilter{}
+	if len(options.Types) > 0 {
+		filter.Events = fmt.Sprintf("^(%s)$", strings.Join(options.Types, "|"))
+	}
+	filter.Topic = options.Topic
+	if options.Tag != "" || options.Group != "" || options.Author != "" {
+		filter.Message = &eventMessageFilter{
+			Tag:    options.Tag,
+			Group:  options.Group,
+			Author: options.Author,
+		}
+	}
+	if options.TransactionType != "" {
+		filter.Transaction = &eventTransactionFilter{Type: options.TransactionType}
+	}
+	if options.BlockchainEventName != "" || options.BlockchainEventListener != "" {
+		filter.BlockchainEvent = &eventBlockchainEventFilter{
+			Name:     options.BlockchainEventName,
+			Listener: options.BlockchainEventListener,
+		}
+	}
+	if *filter != (eventSubscriptionFilter{}) {
+		start.Filter = filter
+	}
+	return start
+}
+
+// formatEvent returns a single line describing an event, with the details of the object it refers to
+// for the kinds of event that are most useful to watch during development
+func formatEvent(event map[string]interface{}) string {
+	created := eventField(event, "created")
+	if t, err := time.Parse(time.RFC3339Nano, created); err == nil {
+		created = t.Local().Format("15:04:05.000")
+	}
+	line := fmt.Sprintf("%s  %-32s %s", created, eventField(event, "type"), eventField(event, "reference"))
+
+	var details []string
+	addDetail := func(name, value string) {
+		if value != "" {
+			details = append(details, fmt.Sprintf("%s=%s", name, value))
+		}
+	}
+	addDetail("topic", eventField(event, "topic"))
+	addDetail("tx", eventField(event, "transaction", "type"))
+	addDetail("tag", eventField(event, "message", "header", "tag"))
+	addDetail("author", eventField(event, "message", "header", "author"))
+	addDetail("transfer", eventField(event, "tokenTransfer", "type"))
+	addDetail("amount", eventField(event, "tokenTransfer", "amount"))
+	addDetail("from", eventField(event, "tokenTransfer", "from"))
+	addDetail("to", eventField(event, "tokenTransfer", "to"))
+	addDetail("pool", eventField(event, "tokenPool", "name"))
+	addDetail("event", eventField(event, "blockchainEvent", "name"))
+	addDetail("protocolId", eventField(event, "blockchainEvent", "protocolId"))
+	addDetail("operation", eventField(event, "operation", "type"))
+	addDetail("error", eventField(event, "operation", "error"))
+	addDetail("identity", eventField(event, "identity", "did"))
+	if len(details) > 0 {
+		line = fmt.Sprintf("%s  %s", line, strings.Join(details, " "))
+	}
+	return line
+}
+
+// eventField returns the value at a path of nested fields in an event as a string, or "" if there is none
+func eventField(event map[string]interface{}, path ...string) string {
+	var value interface{} = event
+	for _, key := range path {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		value = object[key]
+	}
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
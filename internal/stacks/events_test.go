@@ -0,0 +1,96 @@
+package stacks
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventSubscriptionStartFor(t *testing.T) {
+	testCases := []struct {
+		Name    string
+		Options *types.EventsOptions
+		JSON    string
+	}{
+		{
+			Name:    "no filters",
+			Options: &types.EventsOptions{},
+			JSON:    `{"type":"start","namespace":"default","ephemeral":true,"autoack":true}`,
+		},
+		{
+			Name:    "topic",
+			Options: &types.EventsOptions{Topic: "^orders$"},
+			JSON:    `{"type":"start","namespace":"default","ephemeral":true,"autoack":true,"filter":{"topic":"^orders$"}}`,
+		},
+		{
+			Name:    "event types and data",
+			Options: &types.EventsOptions{Types: []string{"message_confirmed", "token_transfer_confirmed"}, WithData: true},
+			JSON:    `{"type":"start","namespace":"default","ephemeral":true,"autoack":true,"filter":{"events":"^(message_confirmed|token_transfer_confirmed)$"},"options":{"withData":true}}`,
+		},
+		{
+			Name:    "message",
+			Options: &types.EventsOptions{Topic: "orders", Tag: "new", Group: "abc", Author: "did:firefly:org/org_0"},
+			JSON:    `{"type":"start","namespace":"default","ephemeral":true,"autoack":true,"filter":{"topic":"orders","message":{"tag":"new","group":"abc","author":"did:firefly:org/org_0"}}}`,
+		},
+		{
+			Name:    "transaction and blockchain event",
+			Options: &types.EventsOptions{TransactionType: "token_transfer", BlockchainEventName: "Transfer", BlockchainEventListener: "listener1"},
+			JSON:    `{"type":"start","namespace":"default","ephemeral":true,"autoack":true,"filter":{"transaction":{"type":"token_transfer"},"blockchainevent":{"name":"Transfer","listener":"listener1"}}}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			b, err := json.Marshal(eventSubscriptionStartFor("default", tc.Options))
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.JSON, string(b))
+		})
+	}
+}
+
+func TestFormatEvent(t *testing.T) {
+	created := "2024-05-01T12:00:00.5Z"
+	localTime := time.Date(2024, 5, 1, 12, 0, 0, 500000000, time.UTC).Local().Format("15:04:05.000")
+
+	testCases := []struct {
+		Name  string
+		Event string
+		Line  string
+	}{
+		{
+			Name:  "message",
+			Event: `{"type":"message_confirmed","created":"` + created + `","reference":"ref1","topic":"orders","transaction":{"type":"batch_pin"},"message":{"header":{"tag":"new","author":"did:firefly:org/org_0"}}}`,
+			Line:  localTime + "  message_confirmed                ref1  topic=orders tx=batch_pin tag=new author=did:firefly:org/org_0",
+		},
+		{
+			Name:  "token transfer",
+			Event: `{"type":"token_transfer_confirmed","created":"` + created + `","reference":"ref2","tokenTransfer":{"type":"mint","amount":"10","to":"0x123"},"tokenPool":{"name":"pool1"}}`,
+			Line:  localTime + "  token_transfer_confirmed         ref2  transfer=mint amount=10 to=0x123 pool=pool1",
+		},
+		{
+			Name:  "failed operation",
+			Event: `{"type":"blockchain_invoke_op_failed","created":"` + created + `","reference":"ref3","operation":{"type":"blockchain_invoke","error":"reverted"}}`,
+			Line:  localTime + "  blockchain_invoke_op_failed      ref3  operation=blockchain_invoke error=reverted",
+		},
+		{
+			Name:  "numbers and no details",
+			Event: `{"type":"identity_confirmed","created":"not a time","reference":42}`,
+			Line:  "not a time  identity_confirmed               42",
+		},
+		{
+			Name:  "missing fields",
+			Event: `{"message":"not an object"}`,
+			Line:  strings.Repeat(" ", 35),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var event map[string]interface{}
+			assert.NoError(t, json.Unmarshal([]byte(tc.Event), &event))
+			assert.Equal(t, tc.Line, formatEvent(event))
+		})
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stacks
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hyperledger/firefly-cli/internal/blockchain/fabric"
+	"github.com/hyperledger/firefly-cli/internal/docker"
+	"github.com/hyperledger/firefly-cli/pkg/types"
+)
+
+// ShellTargets returns the consoles that "ff shell" can open on the stack, which include the service name of each
+// of its geth nodes
+func (s *StackManager) ShellTargets() []string {
+	var targets []string
+	if s.Stack.Database.Equals(types.DatabaseSelectionPostgres) {
+		targets = append(targets, "postgres")
+	}
+	targets = append(targets, s.gethNodeServices()...)
+	for i := 0; i <= len(s.additionalBlockchains); i++ {
+		chainStack, _, _ := s.blockchainAt(i)
+		if chainStack.BlockchainProvider.Equals(types.BlockchainProviderFabric) {
+			targets = append(targets, "fabric-tools")
+			break
+		}
+	}
+	return targets
+}
+
+// gethNodeServices returns the service names of the geth nodes of every blockchain in the stack, which are
+// geth_<member index> if the blockchain runs a node per member
+func (s *StackManager) gethNodeServices() []string {
+	var serviceNames []string
+	for i := 0; i <= len(s.additionalBlockchains); i++ {
+		chainStack, _, _ := s.blockchainAt(i)
+		if !chainStack.BlockchainNodeProvider.Equals(types.BlockchainNodeProviderGeth) {
+			continue
+		}
+		if !chainStack.NodePerMember {
+			serviceNames = append(serviceNames, chainStack.ServiceName("geth"))
+			continue
+		}
+		for j := range chainStack.Members {
+			serviceNames = append(serviceNames, chainStack.ServiceName(fmt.Sprintf("geth_%d", j)))
+		}
+	}
+	return serviceNames
+}
+
+// gethShellService returns the geth node that the geth console of "ff shell" attaches to. The target is either the
+// service name of a geth node, or geth for the first geth node of the stack, which is the node of the given member
+// if the stack runs a node per member.
+func (s *StackManager) gethShellService(target string, memberIndex int) (string, error) {
+	serviceNames := s.gethNodeServices()
+	if target == "geth" && len(serviceNames) > 0 {
+		target = serviceNames[0]
+		if s.Stack.NodePerMember && s.Stack.BlockchainNodeProvider.Equals(types.BlockchainNodeProviderGeth) && memberIndex >= 0 {
+			if memberIndex >= len(s.Stack.Members) {
+				return "", fmt.Errorf("stack '%s' does not have a member %d", s.Stack.Name, memberIndex)
+			}
+			target = fmt.Sprintf("geth_%d", memberIndex)
+		}
+	}
+	if !slices.Contains(serviceNames, target) {
+		return "", fmt.Errorf("stack '%s' does not have a geth node '%s'", s.Stack.Name, target)
+	}
+	return target, nil
+}
+
+// ResolveService returns the compose service of the stack with a logical name, such as postgres or evmconnect,
+// for a member of the stack. A member index of -1 means no member was given, in which case the name has to match
+// a single service, either exactly or as the start of its name.
+func (s *StackManager) ResolveService(name string, memberIndex int) (string, error) {
+	compose := s.buildDockerCompose()
+	serviceName := name
+	if memberIndex >= 0 {
+		if memberIndex >= len(s.Stack.Members) {
+			return "", fmt.Errorf("stack '%s' does not have a member %d", s.Stack.Name, memberIndex)
+		}
+		serviceName = fmt.Sprintf("%s_%s", name, s.Stack.Members[memberIndex].ID)
+	}
+	if _, ok := compose.Services[serviceName]; ok {
+		return serviceName, nil
+	}
+
+	var matches []string
+	for candidate := range compose.Services {
+		if strings.HasPrefix(candidate, serviceName+"_") {
+			matches = append(matches, candidate)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("stack '%s' does not have a service '%s'", s.Stack.Name, serviceName)
+	case 1:
+		return matches[0], nil
+	default:
+		slices.Sort(matches)
+		return "", fmt.Errorf("'%s' matches several services in stack '%s': %s", serviceName, s.Stack.Name, strings.Join(matches, ", "))
+	}
+}
+
+// Exec runs a command in the running container of a service, attached to the terminal of the CLI
+func (s *StackManager) Exec(serviceName string, command []string) error {
+	return s.runInteractiveDockerComposeCommand(append([]string{"exec", serviceName}, command...)...)
+}
+
+// Shell opens one of the ShellTargets in the terminal of the CLI. The postgres console is for the database of a
+// member, which is the first member if the member index is -1. The geth console attaches to the geth node of the
+// stack, or of the given member if the stack runs a node per member. Any geth node can be chosen by its service
+// name, such as geth_1, or chain1_geth for the node of an additional blockchain.
+func (s *StackManager) Shell(target string, memberIndex int) error {
+	switch {
+	case target == "postgres":
+		if !s.Stack.Database.Equals(types.DatabaseSelectionPostgres) {
+			return fmt.Errorf("stack '%s' does not use postgres", s.Stack.Name)
+		}
+		if memberIndex < 0 {
+			memberIndex = 0
+		}
+		serviceName, err := s.ResolveService("postgres", memberIndex)
+		if err != nil {
+			return err
+		}
+		return s.runInteractiveDockerComposeCommand("exec", "-e", fmt.Sprintf("PGPASSWORD=%s", s.Stack.PostgresPassword()), serviceName,
+			"psql", "-h", "localhost", "-U", "postgres")
+
+	case strings.Contains(target, "geth"):
+		serviceName, err := s.gethShellService(target, memberIndex)
+		if err != nil {
+			return err
+		}
+		return s.runInteractiveDockerComposeCommand("exec", serviceName, "geth", "attach", "http://localhost:8545")
+
+	case target == "fabric-tools":
+		for i := 0; i <= len(s.additionalBlockchains); i++ {
+			chainStack, _, _ := s.blockchainAt(i)
+			if chainStack.BlockchainProvider.Equals(types.BlockchainProviderFabric) {
+				args := []string{"run", "-i"}
+				if docker.IsInteractive() {
+					args = append(args, "-t")
+				}
+				args = append(args, fabric.PeerCLIDockerArgs(chainStack)...)
+				return docker.RunDockerCommandInteractive(s.Stack.StackDir, append(args, "bash")...)
+			}
+		}
+		return fmt.Errorf("stack '%s' does not have a fabric network", s.Stack.Name)
+	}
+	return fmt.Errorf("unknown shell '%s'. Options are: %s", target, strings.Join(s.ShellTargets(), ", "))
+}
+
+// runInteractiveDockerComposeCommand runs "docker compose exec", or another command that takes a service, attached
+// to the terminal of the CLI. A TTY is only allocated when the CLI itself is running in one.
+func (s *StackManager) runInteractiveDockerComposeCommand(command ...string) error {
+	if !docker.IsInteractive() {
+		command = append([]string{command[0], "-T"}, command[1:]...)
+	}
+	return docker.RunDockerComposeCommandInteractive(s.ctx, s.Stack.StackDir, command...)
+}
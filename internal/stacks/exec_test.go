@@ -0,0 +1,60 @@
+package stacks
+
+import (
+	"testing"
+
+	"github.com/hyperledger/firefly-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newShellTestStackManager(nodePerMember bool) *StackManager {
+	s := newTestStackManager()
+	s.Stack.Database = types.DatabaseSelectionPostgres
+	s.Stack.BlockchainNodeProvider = types.BlockchainNodeProviderGeth
+	s.Stack.NodePerMember = nodePerMember
+	s.Stack.AdditionalBlockchains[0].BlockchainNodeProvider = types.BlockchainNodeProviderGeth
+	s.additionalBlockchains[0].stack = s.Stack.AdditionalBlockchainStack(1)
+	return s
+}
+
+func TestShellTargets(t *testing.T) {
+	assert.Equal(t, []string{"postgres", "geth", "chain1_geth"}, newShellTestStackManager(false).ShellTargets())
+	assert.Equal(t, []string{"postgres", "geth_0", "geth_1", "chain1_geth"}, newShellTestStackManager(true).ShellTargets())
+
+	s := newTestStackManager()
+	s.Stack.BlockchainProvider = types.BlockchainProviderFabric
+	assert.Equal(t, []string{"fabric-tools"}, s.ShellTargets())
+}
+
+func TestGethShellService(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		NodePerMember bool
+		Target        string
+		MemberIndex   int
+		Service       string
+		Error         string
+	}{
+		{Name: "geth", Target: "geth", MemberIndex: -1, Service: "geth"},
+		{Name: "geth ignores the member", Target: "geth", MemberIndex: 1, Service: "geth"},
+		{Name: "additional blockchain", Target: "chain1_geth", MemberIndex: -1, Service: "chain1_geth"},
+		{Name: "first member's node", NodePerMember: true, Target: "geth", MemberIndex: -1, Service: "geth_0"},
+		{Name: "member's node", NodePerMember: true, Target: "geth", MemberIndex: 1, Service: "geth_1"},
+		{Name: "node by service name", NodePerMember: true, Target: "geth_1", MemberIndex: -1, Service: "geth_1"},
+		{Name: "additional blockchain of node per member stack", NodePerMember: true, Target: "chain1_geth", MemberIndex: -1, Service: "chain1_geth"},
+		{Name: "unknown member", NodePerMember: true, Target: "geth", MemberIndex: 2, Error: "stack 'stack' does not have a member 2"},
+		{Name: "unknown node", NodePerMember: true, Target: "geth_2", MemberIndex: -1, Error: "stack 'stack' does not have a geth node 'geth_2'"},
+		{Name: "member node without node per member", Target: "geth_0", MemberIndex: -1, Error: "stack 'stack' does not have a geth node 'geth_0'"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			serviceName, err := newShellTestStackManager(tc.NodePerMember).gethShellService(tc.Target, tc.MemberIndex)
+			if tc.Error != "" {
+				assert.EqualError(t, err, tc.Error)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.Service, serviceName)
+			}
+		})
+	}
+}
@@ -23,21 +23,21 @@ import (
 	"github.com/hyperledger/firefly-cli/internal/core"
 )
 
-func (s *StackManager) registerFireflyIdentities() error {
+func (s *StackManager) registerFireflyIdentities(namespace string) error {
 	emptyObject := make(map[string]interface{})
 
 	for _, member := range s.Stack.Members {
-		ffURL := fmt.Sprintf("http://127.0.0.1:%d/api/v1", member.ExposedFireflyPort)
+		ffURL := fmt.Sprintf("%s://127.0.0.1:%d/api/v1/namespaces/%s", s.Stack.HTTPScheme(), member.ExposedFireflyPort, namespace)
 		s.Log.Info(fmt.Sprintf("registering org and node for member %s", member.ID))
 
 		registerOrgURL := fmt.Sprintf("%s/network/organizations/self?confirm=true", ffURL)
-		err := core.RequestWithRetry(s.ctx, http.MethodPost, registerOrgURL, emptyObject, nil)
+		err := core.RequestWithRetryAndAuth(s.ctx, http.MethodPost, registerOrgURL, s.Stack.MemberBasicAuth(member), emptyObject, nil)
 		if err != nil {
 			return err
 		}
 
 		registerNodeURL := fmt.Sprintf("%s/network/nodes/self?confirm=true", ffURL)
-		err = core.RequestWithRetry(s.ctx, http.MethodPost, registerNodeURL, emptyObject, nil)
+		err = core.RequestWithRetryAndAuth(s.ctx, http.MethodPost, registerNodeURL, s.Stack.MemberBasicAuth(member), emptyObject, nil)
 		if err != nil {
 			return nil
 		}
@@ -0,0 +1,316 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stacks
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hyperledger/firefly-cli/pkg/types"
+
+	"gopkg.in/yaml.v3"
+)
+
+const grafanaDatasourceUID = "prometheus"
+
+type GrafanaDatasources struct {
+	APIVersion  int                  `yaml:"apiVersion"`
+	Datasources []*GrafanaDatasource `yaml:"datasources"`
+}
+
+type GrafanaDatasource struct {
+	Name      string `yaml:"name"`
+	Type      string `yaml:"type"`
+	UID       string `yaml:"uid"`
+	Access    string `yaml:"access"`
+	URL       string `yaml:"url"`
+	IsDefault bool   `yaml:"isDefault"`
+}
+
+type GrafanaDashboardProviders struct {
+	APIVersion int                         `yaml:"apiVersion"`
+	Providers  []*GrafanaDashboardProvider `yaml:"providers"`
+}
+
+type GrafanaDashboardProvider struct {
+	Name    string            `yaml:"name"`
+	Folder  string            `yaml:"folder"`
+	Type    string            `yaml:"type"`
+	Options map[string]string `yaml:"options"`
+}
+
+type GrafanaDashboard struct {
+	UID           string            `json:"uid"`
+	Title         string            `json:"title"`
+	Tags          []string          `json:"tags"`
+	Timezone      string            `json:"timezone"`
+	Refresh       string            `json:"refresh"`
+	SchemaVersion int               `json:"schemaVersion"`
+	Time          *GrafanaTimeRange `json:"time"`
+	Panels        []*GrafanaPanel   `json:"panels"`
+}
+
+type GrafanaTimeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type GrafanaPanel struct {
+	ID          int                   `json:"id"`
+	Type        string                `json:"type"`
+	Title       string                `json:"title"`
+	GridPos     *GrafanaGridPos       `json:"gridPos"`
+	Datasource  *GrafanaDatasourceRef `json:"datasource"`
+	Targets     []*GrafanaTarget      `json:"targets"`
+	FieldConfig *GrafanaFieldConfig   `json:"fieldConfig,omitempty"`
+}
+
+type GrafanaGridPos struct {
+	H int `json:"h"`
+	W int `json:"w"`
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type GrafanaDatasourceRef struct {
+	Type string `json:"type"`
+	UID  string `json:"uid"`
+}
+
+type GrafanaTarget struct {
+	RefID        string `json:"refId"`
+	Expr         string `json:"expr"`
+	LegendFormat string `json:"legendFormat,omitempty"`
+}
+
+type GrafanaFieldConfig struct {
+	Defaults *GrafanaFieldDefaults `json:"defaults"`
+}
+
+type GrafanaFieldDefaults struct {
+	Unit string `json:"unit,omitempty"`
+}
+
+// grafanaQuery is a Prometheus query for a panel, with the legend for each of the series it returns
+type grafanaQuery struct {
+	expr   string
+	legend string
+}
+
+// grafanaPanelSpec describes a panel of a dashboard, which are laid out two to a row
+type grafanaPanelSpec struct {
+	title   string
+	unit    string
+	queries []grafanaQuery
+}
+
+// GenerateGrafanaDatasources returns the provisioning config that adds the stack's Prometheus server to Grafana,
+// and its Loki server if the logs of the stack are aggregated
+func (s *StackManager) GenerateGrafanaDatasources() *GrafanaDatasources {
+	datasources := &GrafanaDatasources{
+		APIVersion: 1,
+		Datasources: []*GrafanaDatasource{
+			{
+				Name:      "Prometheus",
+				Type:      "prometheus",
+				UID:       grafanaDatasourceUID,
+				Access:    "proxy",
+				URL:       "http://prometheus:9090",
+				IsDefault: true,
+			},
+		},
+	}
+	if s.Stack.LogAggregation.Equals(types.LogAggregationLoki) {
+		datasources.Datasources = append(datasources.Datasources, &GrafanaDatasource{
+			Name:   "Loki",
+			Type:   "loki",
+			UID:    "loki",
+			Access: "proxy",
+			URL:    "http://loki:3100",
+		})
+	}
+	return datasources
+}
+
+// GenerateGrafanaDashboardProviders returns the provisioning config that loads the dashboards of the stack
+// from the provisioning volume
+func (s *StackManager) GenerateGrafanaDashboardProviders() *GrafanaDashboardProviders {
+	return &GrafanaDashboardProviders{
+		APIVersion: 1,
+		Providers: []*GrafanaDashboardProvider{
+			{
+				Name:    "firefly",
+				Folder:  "FireFly",
+				Type:    "file",
+				Options: map[string]string{"path": "/etc/grafana/provisioning/dashboards"},
+			},
+		},
+	}
+}
+
+// GenerateGrafanaDashboards returns the dashboards for the stack by file name. They use the component, member and
+// org labels of the targets in the Prometheus config.
+func (s *StackManager) GenerateGrafanaDashboards() map[string]*GrafanaDashboard {
+	return map[string]*GrafanaDashboard{
+		"stack.json": grafanaDashboard("firefly-stack", "FireFly stack", []*grafanaPanelSpec{
+			{title: "Components up", queries: []grafanaQuery{
+				{`up{job!="probes"}`, "{{org}} {{component}} {{instance}}"},
+				{`probe_success`, "{{org}} {{component}} {{instance}}"},
+			}},
+			{title: "Databases up", queries: []grafanaQuery{
+				{`pg_up`, "{{org}}"},
+			}},
+		}),
+		"core.json": grafanaDashboard("firefly-core", "FireFly core", []*grafanaPanelSpec{
+			{title: "API requests", unit: "reqps", queries: []grafanaQuery{
+				{`sum by (org, method, route) (rate(ff_apiserver_rest_requests_total{component="core"}[1m]))`, "{{org}} {{method}} {{route}}"},
+			}},
+			{title: "API request duration (p95)", unit: "s", queries: []grafanaQuery{
+				{`histogram_quantile(0.95, sum by (org, le) (rate(ff_apiserver_rest_request_duration_seconds_bucket{component="core"}[1m])))`, "{{org}}"},
+			}},
+			{title: "Broadcast messages", unit: "ops", queries: []grafanaQuery{
+				{`sum by (org) (rate(ff_broadcast_submitted_total[1m]))`, "{{org}} submitted"},
+				{`sum by (org) (rate(ff_broadcast_confirmed_total[1m]))`, "{{org}} confirmed"},
+			}},
+			{title: "Private messages", unit: "ops", queries: []grafanaQuery{
+				{`sum by (org) (rate(ff_private_msg_submitted_total[1m]))`, "{{org}} submitted"},
+				{`sum by (org) (rate(ff_private_msg_confirmed_total[1m]))`, "{{org}} confirmed"},
+			}},
+			{title: "Token mints and transfers", unit: "ops", queries: []grafanaQuery{
+				{`sum by (org) (rate(ff_tokens_mint_submitted_total[1m]))`, "{{org}} mints"},
+				{`sum by (org) (rate(ff_tokens_transfer_submitted_total[1m]))`, "{{org}} transfers"},
+			}},
+			{title: "Memory", unit: "bytes", queries: []grafanaQuery{
+				{`process_resident_memory_bytes{component="core"}`, "{{org}}"},
+			}},
+		}),
+		"connectors.json": grafanaDashboard("firefly-connectors", "Blockchain connectors", []*grafanaPanelSpec{
+			{title: "Connectors up", queries: []grafanaQuery{
+				{`up{component="connector"}`, "{{org}} {{instance}}"},
+				{`probe_success{component="connector"}`, "{{org}} {{instance}}"},
+			}},
+			{title: "API requests", unit: "reqps", queries: []grafanaQuery{
+				{`sum by (org, instance, method, route) (rate(ff_apiserver_rest_requests_total{component="connector"}[1m]))`, "{{org}} {{method}} {{route}}"},
+			}},
+			{title: "API request duration (p95)", unit: "s", queries: []grafanaQuery{
+				{`histogram_quantile(0.95, sum by (org, instance, le) (rate(ff_apiserver_rest_request_duration_seconds_bucket{component="connector"}[1m])))`, "{{org}} {{instance}}"},
+			}},
+			{title: "Memory", unit: "bytes", queries: []grafanaQuery{
+				{`process_resident_memory_bytes{component="connector"}`, "{{org}} {{instance}}"},
+			}},
+		}),
+		"tokens.json": grafanaDashboard("firefly-tokens", "Token connectors", []*grafanaPanelSpec{
+			{title: "Token connectors up", queries: []grafanaQuery{
+				{`probe_success{component="tokens"}`, "{{org}} {{instance}}"},
+			}},
+			{title: "Connection time", unit: "s", queries: []grafanaQuery{
+				{`probe_duration_seconds{component="tokens"}`, "{{org}} {{instance}}"},
+			}},
+		}),
+		"node.json": grafanaDashboard("firefly-blockchain-node", "Blockchain node", []*grafanaPanelSpec{
+			{title: "Block height", queries: []grafanaQuery{
+				{`chain_head_block{component="node"}`, "{{instance}}"},
+				{`ethereum_blockchain_height{component="node"}`, "{{instance}}"},
+			}},
+			{title: "Peers", queries: []grafanaQuery{
+				{`p2p_peers{component="node"}`, "{{instance}}"},
+				{`ethereum_peer_count{component="node"}`, "{{instance}}"},
+			}},
+			{title: "Transaction pool", queries: []grafanaQuery{
+				{`txpool_pending{component="node"}`, "{{instance}} pending"},
+				{`txpool_queued{component="node"}`, "{{instance}} queued"},
+				{`besu_transaction_pool_transactions{component="node"}`, "{{instance}}"},
+			}},
+			{title: "Nodes up", queries: []grafanaQuery{
+				{`up{component="node"}`, "{{instance}}"},
+			}},
+		}),
+	}
+}
+
+func grafanaDashboard(uid, title string, panelSpecs []*grafanaPanelSpec) *GrafanaDashboard {
+	dashboard := &GrafanaDashboard{
+		UID:           uid,
+		Title:         title,
+		Tags:          []string{"firefly"},
+		Timezone:      "browser",
+		Refresh:       "5s",
+		SchemaVersion: 39,
+		Time:          &GrafanaTimeRange{From: "now-15m", To: "now"},
+	}
+	datasource := &GrafanaDatasourceRef{Type: "prometheus", UID: grafanaDatasourceUID}
+	for i, spec := range panelSpecs {
+		panel := &GrafanaPanel{
+			ID:         i + 1,
+			Type:       "timeseries",
+			Title:      spec.title,
+			GridPos:    &GrafanaGridPos{H: 8, W: 12, X: (i % 2) * 12, Y: (i / 2) * 8},
+			Datasource: datasource,
+		}
+		if spec.unit != "" {
+			panel.FieldConfig = &GrafanaFieldConfig{Defaults: &GrafanaFieldDefaults{Unit: spec.unit}}
+		}
+		for j, query := range spec.queries {
+			panel.Targets = append(panel.Targets, &GrafanaTarget{
+				RefID:        string(rune('A' + j)),
+				Expr:         query.expr,
+				LegendFormat: query.legend,
+			})
+		}
+		dashboard.Panels = append(dashboard.Panels, panel)
+	}
+	return dashboard
+}
+
+// writeGrafanaConfig writes the datasource and dashboards that are copied into the provisioning volume of Grafana
+func (s *StackManager) writeGrafanaConfig() error {
+	grafanaDir := filepath.Join(s.Stack.InitDir, "config", "grafana")
+	datasourcesDir := filepath.Join(grafanaDir, "datasources")
+	dashboardsDir := filepath.Join(grafanaDir, "dashboards")
+	for _, dir := range []string{datasourcesDir, dashboardsDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+
+	datasourcesBytes, err := yaml.Marshal(s.GenerateGrafanaDatasources())
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(datasourcesDir, "prometheus.yml"), datasourcesBytes, 0755); err != nil {
+		return err
+	}
+	providersBytes, err := yaml.Marshal(s.GenerateGrafanaDashboardProviders())
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dashboardsDir, "dashboards.yml"), providersBytes, 0755); err != nil {
+		return err
+	}
+	for fileName, dashboard := range s.GenerateGrafanaDashboards() {
+		dashboardBytes, err := json.MarshalIndent(dashboard, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dashboardsDir, fileName), dashboardBytes, 0755); err != nil {
+			return fmt.Errorf("failed to write Grafana dashboard %s: %s", fileName, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stacks
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hyperledger/firefly-cli/internal/docker"
+	"github.com/hyperledger/firefly-cli/internal/tokens"
+	"gopkg.in/yaml.v3"
+)
+
+// lokiPushURL is where promtail ships the logs of the stack's containers
+const lokiPushURL = "http://loki:3100/loki/api/v1/push"
+
+type PromtailConfig struct {
+	Server        *PromtailServerConfig    `yaml:"server"`
+	Positions     *PromtailPositionsConfig `yaml:"positions"`
+	Clients       []*PromtailClientConfig  `yaml:"clients"`
+	ScrapeConfigs []*PromtailScrapeConfig  `yaml:"scrape_configs"`
+}
+
+type PromtailServerConfig struct {
+	HTTPListenPort int `yaml:"http_listen_port"`
+	GRPCListenPort int `yaml:"grpc_listen_port"`
+}
+
+type PromtailPositionsConfig struct {
+	Filename string `yaml:"filename"`
+}
+
+type PromtailClientConfig struct {
+	URL string `yaml:"url"`
+}
+
+type PromtailScrapeConfig struct {
+	JobName         string                    `yaml:"job_name"`
+	DockerSDConfigs []*PromtailDockerSDConfig `yaml:"docker_sd_configs"`
+	RelabelConfigs  []*RelabelConfig          `yaml:"relabel_configs"`
+}
+
+type PromtailDockerSDConfig struct {
+	Host            string                  `yaml:"host"`
+	RefreshInterval string                  `yaml:"refresh_interval"`
+	Filters         []*PromtailDockerFilter `yaml:"filters"`
+}
+
+type PromtailDockerFilter struct {
+	Name   string   `yaml:"name"`
+	Values []string `yaml:"values"`
+}
+
+// GeneratePromtailConfig returns the config of promtail, which discovers the containers of the stack through the
+// docker API and ships their logs to Loki, labelled with the stack, service, member and component of each container
+func (s *StackManager) GeneratePromtailConfig() *PromtailConfig {
+	return &PromtailConfig{
+		Server: &PromtailServerConfig{
+			HTTPListenPort: 9080,
+			GRPCListenPort: 0,
+		},
+		Positions: &PromtailPositionsConfig{
+			Filename: "/var/lib/promtail/positions.yaml",
+		},
+		Clients: []*PromtailClientConfig{
+			{URL: lokiPushURL},
+		},
+		ScrapeConfigs: []*PromtailScrapeConfig{
+			{
+				JobName: "containers",
+				DockerSDConfigs: []*PromtailDockerSDConfig{
+					{
+						Host:            "unix:///var/run/docker.sock",
+						RefreshInterval: "5s",
+						Filters: []*PromtailDockerFilter{
+							{Name: "label", Values: []string{"firefly.stack=" + s.Stack.Name}},
+						},
+					},
+				},
+				RelabelConfigs: []*RelabelConfig{
+					{SourceLabels: []string{"__meta_docker_container_label_firefly_stack"}, TargetLabel: "stack"},
+					{SourceLabels: []string{"__meta_docker_container_label_com_docker_compose_service"}, TargetLabel: "service"},
+					{SourceLabels: []string{"__meta_docker_container_label_firefly_member"}, TargetLabel: "member"},
+					{SourceLabels: []string{"__meta_docker_container_label_firefly_component"}, TargetLabel: "component"},
+				},
+			},
+		},
+	}
+}
+
+func (s *StackManager) writePromtailConfig() error {
+	configBytes, err := yaml.Marshal(s.GeneratePromtailConfig())
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(s.Stack.InitDir, "config", "promtail.yaml"), configBytes, 0755)
+}
+
+// applyLogAggregationLabels labels every service in the stack with the stack name and its component, and the
+// services of each member with the member's ID, which promtail turns into the labels of their logs in Loki. The
+// component of a member's service is its name without the member ID, so that the logs of e.g. every member's
+// firefly_core can be queried together. The token connectors, which are named tokens_<member>_<index>, all have
+// the tokens component, and the TLS terminating proxies in front of them the tokens_api component.
+func (s *StackManager) applyLogAggregationLabels(compose *docker.DockerComposeConfig) {
+	for serviceName, service := range compose.Services {
+		service.Labels = map[string]string{
+			"firefly.stack":     s.Stack.Name,
+			"firefly.component": serviceName,
+		}
+	}
+	for _, member := range s.Stack.Members {
+		for _, serviceName := range s.memberServiceNames(member) {
+			if service, ok := compose.Services[serviceName]; ok {
+				service.Labels["firefly.member"] = member.ID
+				service.Labels["firefly.component"] = strings.TrimSuffix(serviceName, "_"+member.ID)
+			}
+		}
+		for i := range s.tokenProviders {
+			if service, ok := compose.Services[fmt.Sprintf("tokens_%s_%d", member.ID, i)]; ok {
+				service.Labels["firefly.component"] = "tokens"
+			}
+			if service, ok := compose.Services[tokens.TLSProxyName(member, i)]; ok {
+				service.Labels["firefly.component"] = "tokens_api"
+			}
+		}
+	}
+}
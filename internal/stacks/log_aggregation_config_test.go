@@ -0,0 +1,54 @@
+package stacks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeneratePromtailConfig(t *testing.T) {
+	config := newTestStackManager().GeneratePromtailConfig()
+	assert.Equal(t, lokiPushURL, config.Clients[0].URL)
+	assert.Len(t, config.ScrapeConfigs, 1)
+	filters := config.ScrapeConfigs[0].DockerSDConfigs[0].Filters
+	assert.Equal(t, []*PromtailDockerFilter{{Name: "label", Values: []string{"firefly.stack=stack"}}}, filters)
+	var targetLabels []string
+	for _, relabel := range config.ScrapeConfigs[0].RelabelConfigs {
+		targetLabels = append(targetLabels, relabel.TargetLabel)
+	}
+	assert.Equal(t, []string{"stack", "service", "member", "component"}, targetLabels)
+}
+
+func TestApplyLogAggregationLabels(t *testing.T) {
+	s := newTestStackManager()
+	compose := s.buildDockerCompose()
+	s.applyLogAggregationLabels(compose)
+
+	testCases := []struct {
+		Service   string
+		Member    string
+		Component string
+	}{
+		{Service: "firefly_core_0", Member: "0", Component: "firefly_core"},
+		{Service: "firefly_core_1", Member: "1", Component: "firefly_core"},
+		{Service: "ipfs_1", Member: "1", Component: "ipfs"},
+		{Service: "tokens_0_0", Member: "0", Component: "tokens"},
+		{Service: "tokens_1_0", Member: "1", Component: "tokens"},
+		{Service: "evmconnect_1", Member: "1", Component: "evmconnect"},
+		{Service: "chain1_evmconnect_1", Member: "1", Component: "chain1_evmconnect"},
+		{Service: "geth", Component: "geth"},
+		{Service: "chain1_geth", Component: "chain1_geth"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Service, func(t *testing.T) {
+			labels := compose.Services[tc.Service].Labels
+			assert.Equal(t, "stack", labels["firefly.stack"])
+			assert.Equal(t, tc.Component, labels["firefly.component"])
+			if tc.Member == "" {
+				assert.NotContains(t, labels, "firefly.member")
+			} else {
+				assert.Equal(t, tc.Member, labels["firefly.member"])
+			}
+		})
+	}
+}
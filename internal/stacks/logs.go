@@ -0,0 +1,291 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stacks
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly-cli/internal/docker"
+	"github.com/hyperledger/firefly-cli/pkg/types"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+// LogLevels are the levels FireFly core logs at, from the most to the least verbose
+var LogLevels = []string{"trace", "debug", "info", "warn", "error", "fatal", "panic"}
+
+// coreLogLevelRegex finds the level of a FireFly core log line, in either the default text format of
+// "[<time>]  INFO <message>" or the JSON format
+var coreLogLevelRegex = regexp.MustCompile(`(?i)(?:^\[[^\]]*\]\s+|"level":")(trace|debug|info|warn|warning|error|fatal|panic)\b`)
+
+type logLine struct {
+	Time    time.Time `json:"time"`
+	Service string    `json:"service"`
+	Message string    `json:"message"`
+}
+
+// Logs prints the logs of the selected members and services of a stack, or of every service if none are selected.
+// Without any filtering or output options the logs come straight from docker compose, otherwise the logs of each
+// service are read separately with their timestamps so that they can be filtered, merged and saved.
+func (s *StackManager) Logs(options *types.LogsOptions) error {
+	var serviceNames []string
+	if len(options.Members) > 0 || len(options.Services) > 0 {
+		var err error
+		if serviceNames, err = s.SelectServices(options.Members, options.Services); err != nil {
+			return err
+		}
+	}
+
+	commandLine := []string{}
+	if options.ANSI && options.Grep == "" && options.Level == "" && options.Output == "" && options.SaveDir == "" {
+		commandLine = append(commandLine, "--ansi", "always")
+	}
+	commandLine = append(commandLine, "-p", s.Stack.Name, "logs")
+	if options.Follow {
+		commandLine = append(commandLine, "-f")
+	}
+	if options.Since != "" {
+		commandLine = append(commandLine, "--since", options.Since)
+	}
+	if options.Until != "" {
+		commandLine = append(commandLine, "--until", options.Until)
+	}
+	if options.Tail != "" {
+		commandLine = append(commandLine, "--tail", options.Tail)
+	}
+
+	if options.Grep == "" && options.Level == "" && options.Output == "" && options.SaveDir == "" {
+		return docker.RunDockerComposeCommand(s.ctx, s.Stack.RuntimeDir, append(commandLine, serviceNames...)...)
+	}
+
+	var grep *regexp.Regexp
+	if options.Grep != "" {
+		var err error
+		if grep, err = regexp.Compile(options.Grep); err != nil {
+			return fmt.Errorf("invalid --grep expression: %s", err)
+		}
+	}
+	minLevel := -1
+	if options.Level != "" {
+		if minLevel = slices.Index(LogLevels, strings.ToLower(options.Level)); minLevel < 0 {
+			return fmt.Errorf("invalid log level '%s'. Options are: %s", options.Level, strings.Join(LogLevels, ", "))
+		}
+	}
+	output := types.LogsOutputText
+	if options.Output != "" {
+		var err error
+		if output, err = fftypes.FFEnumParseString(s.ctx, types.LogsOutput, options.Output); err != nil {
+			return err
+		}
+	}
+
+	if len(serviceNames) == 0 {
+		for serviceName := range s.buildDockerCompose().Services {
+			serviceNames = append(serviceNames, serviceName)
+		}
+		slices.Sort(serviceNames)
+	}
+
+	writer, err := newLogWriter(output, options.SaveDir)
+	if err != nil {
+		return err
+	}
+	defer writer.close()
+
+	// The readers are stopped, and their docker compose commands killed, if the logs cannot be written
+	ctx, cancel := context.WithCancel(s.ctx)
+	lines := make(chan *logLine)
+	defer func() {
+		cancel()
+		// Drain the lines until every reader has stopped
+		for range lines { //nolint:revive
+		}
+	}()
+	errs := make(chan error, len(serviceNames))
+	var wg sync.WaitGroup
+	for _, serviceName := range serviceNames {
+		wg.Add(1)
+		go func(serviceName string) {
+			defer wg.Done()
+			errs <- s.readServiceLogs(ctx, serviceName, append(slices.Clone(commandLine), "--no-color", "--no-log-prefix", "--timestamps", serviceName), grep, minLevel, lines)
+		}(serviceName)
+	}
+	go func() {
+		wg.Wait()
+		close(lines)
+		close(errs)
+	}()
+
+	if options.Follow {
+		// Lines are written as they arrive, so they are only in order within each service
+		for line := range lines {
+			if err := writer.write(line); err != nil {
+				return err
+			}
+		}
+	} else {
+		var all []*logLine
+		for line := range lines {
+			all = append(all, line)
+		}
+		sort.SliceStable(all, func(i, j int) bool { return all[i].Time.Before(all[j].Time) })
+		for _, line := range all {
+			if err := writer.write(line); err != nil {
+				return err
+			}
+		}
+	}
+	for err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	if options.SaveDir != "" {
+		fmt.Printf("saved logs for %d services to %s\n", len(writer.files), options.SaveDir)
+	}
+	return nil
+}
+
+// readServiceLogs reads the logs of one service, sending the lines that pass the filters, until the logs end or
+// ctx is cancelled
+func (s *StackManager) readServiceLogs(ctx context.Context, serviceName string, commandLine []string, grep *regexp.Regexp, minLevel int, lines chan<- *logLine) error {
+	cmd, stdout, err := docker.StartDockerComposeCommand(ctx, s.Stack.RuntimeDir, commandLine...)
+	if err != nil {
+		return err
+	}
+	sendErr := sendLogLines(ctx, serviceName, stdout, grep, minLevel, lines)
+	_, _ = io.Copy(io.Discard, stdout)
+	if err := cmd.Wait(); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("failed to get logs for %s: %s", serviceName, err)
+	}
+	return sendErr
+}
+
+// sendLogLines sends the lines of the logs of a service that pass the filters. The level filter only applies to
+// FireFly core, and lines without a level (such as stack traces) are kept if the line before them was.
+func sendLogLines(ctx context.Context, serviceName string, logs io.Reader, grep *regexp.Regexp, minLevel int, lines chan<- *logLine) error {
+	filterLevel := minLevel >= 0 && strings.HasPrefix(serviceName, "firefly_core_")
+	keepUnleveled := true
+	scanner := bufio.NewScanner(logs)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := parseLogLine(serviceName, scanner.Text())
+		if filterLevel {
+			if match := coreLogLevelRegex.FindStringSubmatch(line.Message); match != nil {
+				level := strings.ToLower(match[1])
+				if level == "warning" {
+					level = "warn"
+				}
+				keepUnleveled = slices.Index(LogLevels, level) >= minLevel
+			}
+			if !keepUnleveled {
+				continue
+			}
+		}
+		if grep != nil && !grep.MatchString(line.Message) {
+			continue
+		}
+		select {
+		case lines <- line:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return scanner.Err()
+}
+
+// parseLogLine splits the timestamp that docker adds to the start of each line from the message
+func parseLogLine(serviceName, text string) *logLine {
+	line := &logLine{Service: serviceName, Message: text}
+	if timestamp, message, found := strings.Cut(text, " "); found {
+		if t, err := time.Parse(time.RFC3339Nano, timestamp); err == nil {
+			line.Time = t
+			line.Message = message
+		}
+	}
+	return line
+}
+
+// logWriter writes log lines to stdout, or to a file for each service when saving them to a directory
+type logWriter struct {
+	output  fftypes.FFEnum
+	saveDir string
+	files   map[string]*os.File
+}
+
+func newLogWriter(output fftypes.FFEnum, saveDir string) (*logWriter, error) {
+	if saveDir != "" {
+		if err := os.MkdirAll(saveDir, 0755); err != nil {
+			return nil, err
+		}
+	}
+	return &logWriter{output: output, saveDir: saveDir, files: make(map[string]*os.File)}, nil
+}
+
+func (w *logWriter) write(line *logLine) error {
+	var text string
+	if w.output.Equals(types.LogsOutputJSONL) {
+		b, err := json.Marshal(line)
+		if err != nil {
+			return err
+		}
+		text = string(b)
+	} else if w.saveDir != "" {
+		text = fmt.Sprintf("%s %s", line.Time.Format(time.RFC3339Nano), line.Message)
+	} else {
+		text = fmt.Sprintf("%s | %s %s", line.Service, line.Time.Format(time.RFC3339Nano), line.Message)
+	}
+
+	if w.saveDir == "" {
+		fmt.Println(text)
+		return nil
+	}
+	file, ok := w.files[line.Service]
+	if !ok {
+		extension := "log"
+		if w.output.Equals(types.LogsOutputJSONL) {
+			extension = "jsonl"
+		}
+		var err error
+		if file, err = os.Create(filepath.Join(w.saveDir, fmt.Sprintf("%s.%s", line.Service, extension))); err != nil {
+			return err
+		}
+		w.files[line.Service] = file
+	}
+	_, err := fmt.Fprintln(file, text)
+	return err
+}
+
+func (w *logWriter) close() {
+	for _, file := range w.files {
+		file.Close()
+	}
+}
@@ -0,0 +1,168 @@
+package stacks
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLogLine(t *testing.T) {
+	testCases := []struct {
+		Name    string
+		Text    string
+		Time    time.Time
+		Message string
+	}{
+		{
+			Name:    "timestamp",
+			Text:    "2024-05-01T12:00:00.123456789Z [2024-05-01T12:00:00.123Z]  INFO started",
+			Time:    time.Date(2024, 5, 1, 12, 0, 0, 123456789, time.UTC),
+			Message: "[2024-05-01T12:00:00.123Z]  INFO started",
+		},
+		{
+			Name:    "timestamp with offset",
+			Text:    "2024-05-01T13:00:00+01:00 ready",
+			Time:    time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+			Message: "ready",
+		},
+		{
+			Name:    "no timestamp",
+			Text:    "panic: runtime error",
+			Message: "panic: runtime error",
+		},
+		{
+			Name:    "no space",
+			Text:    "2024-05-01T12:00:00Z",
+			Message: "2024-05-01T12:00:00Z",
+		},
+		{
+			Name:    "empty",
+			Text:    "",
+			Message: "",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			line := parseLogLine("firefly_core_0", tc.Text)
+			assert.Equal(t, "firefly_core_0", line.Service)
+			assert.True(t, tc.Time.Equal(line.Time), "expected %s, got %s", tc.Time, line.Time)
+			assert.Equal(t, tc.Message, line.Message)
+		})
+	}
+}
+
+func TestCoreLogLevelRegex(t *testing.T) {
+	testCases := []struct {
+		Message string
+		Level   string
+	}{
+		{Message: "[2024-05-01T12:00:00.123Z]  INFO Starting HTTP server", Level: "INFO"},
+		{Message: "[2024-05-01T12:00:00.123Z] DEBUG Event dispatched", Level: "DEBUG"},
+		{Message: "[2024-05-01T12:00:00.123Z] ERROR failed: timeout pid=1", Level: "ERROR"},
+		{Message: "[2024-05-01T12:00:00.123Z]  WARN retrying", Level: "WARN"},
+		{Message: `{"level":"warning","msg":"retrying","time":"2024-05-01T12:00:00Z"}`, Level: "warning"},
+		{Message: `{"msg":"panic","level":"panic"}`, Level: "panic"},
+		{Message: "goroutine 1 [running]:"},
+		{Message: "INFO without a timestamp"},
+		{Message: "[2024-05-01T12:00:00.123Z]  INFORMATION not a level"},
+		{Message: `{"msg":"level info"}`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Message, func(t *testing.T) {
+			match := coreLogLevelRegex.FindStringSubmatch(tc.Message)
+			if tc.Level == "" {
+				assert.Nil(t, match)
+			} else {
+				assert.Equal(t, tc.Level, match[1])
+			}
+		})
+	}
+}
+
+const testCoreLogs = `2024-05-01T12:00:00Z [2024-05-01T12:00:00Z] DEBUG polling
+2024-05-01T12:00:01Z [2024-05-01T12:00:01Z]  INFO started
+2024-05-01T12:00:02Z [2024-05-01T12:00:02Z] ERROR failed
+2024-05-01T12:00:02Z goroutine 1 [running]:
+2024-05-01T12:00:03Z [2024-05-01T12:00:03Z] DEBUG polling again
+2024-05-01T12:00:03Z   more debug detail
+`
+
+func TestSendLogLines(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Service  string
+		Grep     string
+		MinLevel int
+		Messages []string
+	}{
+		{
+			Name:     "no filters",
+			Service:  "firefly_core_0",
+			MinLevel: -1,
+			Messages: []string{"DEBUG polling", "INFO started", "ERROR failed", "goroutine", "DEBUG polling again", "more debug detail"},
+		},
+		{
+			Name:     "level keeps unleveled lines after kept lines",
+			Service:  "firefly_core_0",
+			MinLevel: 2,
+			Messages: []string{"INFO started", "ERROR failed", "goroutine"},
+		},
+		{
+			Name:     "level only filters core",
+			Service:  "evmconnect_0",
+			MinLevel: 4,
+			Messages: []string{"DEBUG polling", "INFO started", "ERROR failed", "goroutine", "DEBUG polling again", "more debug detail"},
+		},
+		{
+			Name:     "grep",
+			Service:  "firefly_core_0",
+			Grep:     "polling",
+			MinLevel: -1,
+			Messages: []string{"DEBUG polling", "DEBUG polling again"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var grep *regexp.Regexp
+			if tc.Grep != "" {
+				grep = regexp.MustCompile(tc.Grep)
+			}
+			lines := make(chan *logLine, 10)
+			err := sendLogLines(context.Background(), tc.Service, strings.NewReader(testCoreLogs), grep, tc.MinLevel, lines)
+			assert.NoError(t, err)
+			close(lines)
+			var messages []string
+			for line := range lines {
+				assert.Equal(t, tc.Service, line.Service)
+				messages = append(messages, line.Message)
+			}
+			assert.Len(t, messages, len(tc.Messages))
+			for i, message := range messages {
+				assert.Contains(t, message, tc.Messages[i])
+			}
+		})
+	}
+}
+
+func TestSendLogLinesCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	lines := make(chan *logLine)
+	done := make(chan error)
+	go func() {
+		done <- sendLogLines(ctx, "firefly_core_0", strings.NewReader(testCoreLogs), nil, -1, lines)
+	}()
+
+	// The reader is blocked sending the second line, as nothing is receiving, until it is cancelled
+	<-lines
+	cancel()
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "reader did not stop when cancelled")
+	}
+}
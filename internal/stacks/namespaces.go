@@ -0,0 +1,283 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stacks
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/hyperledger/firefly-cli/pkg/types"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"gopkg.in/yaml.v3"
+)
+
+const defaultNamespace = "default"
+
+// AddNamespace adds a namespace to every member of the stack. If the stack has already been started, the FireFly
+// contract for the namespace is deployed, the core configs are updated and the FireFly core containers restarted.
+// Otherwise the namespace is set up along with the default namespace the first time the stack is started.
+func (s *StackManager) AddNamespace(name string, options *types.AddNamespaceOptions) (messages []string, err error) {
+	if err := fftypes.ValidateFFNameField(s.ctx, name, "name"); err != nil {
+		return nil, err
+	}
+	if name == defaultNamespace || s.Stack.Namespace(name) != nil {
+		return nil, fmt.Errorf("namespace '%s' already exists in stack '%s'", name, s.Stack.Name)
+	}
+
+	if _, _, err := s.blockchainAt(options.Blockchain); err != nil {
+		return nil, err
+	}
+
+	// The token connectors of the stack are all connected to the first blockchain, so namespaces on other
+	// blockchains do not get any token providers unless they are asked for (which is then an error)
+	stackTokenProviders := types.FFEnumArrayToStrings(s.Stack.TokenProviders)
+	tokenProviders := stackTokenProviders
+	if options.Blockchain != 0 {
+		tokenProviders = nil
+	}
+	if options.TokenProviders != nil {
+		for _, tokenProvider := range options.TokenProviders {
+			if options.Blockchain != 0 {
+				return nil, fmt.Errorf("token provider '%s' cannot be used on blockchain %d, as the token providers of stack '%s' are connected to blockchain 0", tokenProvider, options.Blockchain, s.Stack.Name)
+			}
+			if !slices.Contains(stackTokenProviders, tokenProvider) {
+				return nil, fmt.Errorf("token provider '%s' is not part of stack '%s'", tokenProvider, s.Stack.Name)
+			}
+		}
+		tokenProviders = options.TokenProviders
+	}
+
+	namespace := &types.StackNamespace{
+		Name:           name,
+		Multiparty:     options.Multiparty,
+		TokenProviders: tokenProviders,
+		Blockchain:     options.Blockchain,
+	}
+
+	hasRunBefore, err := s.Stack.HasRunBefore()
+	if err != nil {
+		return nil, err
+	}
+	if hasRunBefore {
+		return s.setupNamespace(namespace)
+	}
+
+	s.Stack.Namespaces = append(s.Stack.Namespaces, namespace)
+	return nil, s.writeStackConfig()
+}
+
+// setupNamespace adds a namespace to a stack that is already running. The core config of every member is checked
+// before anything is changed, and the core configs are rolled back if stack.json cannot be written, so that a
+// failure leaves the stack as it was and the namespace can be added again.
+func (s *StackManager) setupNamespace(namespace *types.StackNamespace) (messages []string, err error) {
+	coreConfigs := make([]*coreConfigNamespaces, len(s.Stack.Members))
+	for i, member := range s.Stack.Members {
+		configFile := filepath.Join(s.Stack.RuntimeDir, "config", fmt.Sprintf("firefly_core_%s.yml", member.ID))
+		if coreConfigs[i], err = readCoreConfigNamespaces(configFile, namespace.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	var contractLocation interface{}
+	if namespace.Multiparty {
+		s.Log.Info(fmt.Sprintf("deploying FireFly smart contracts for namespace '%s'", namespace.Name))
+		var message string
+		contractLocation, message, err = s.deployFireFlyContract(namespace.Blockchain)
+		if err != nil {
+			return messages, err
+		}
+		if message != "" {
+			messages = append(messages, message)
+		}
+		if err := s.writeStackStateJSON(s.Stack.RuntimeDir); err != nil {
+			return messages, err
+		}
+	}
+
+	restartCommand := []string{"restart"}
+	for i, member := range s.Stack.Members {
+		namespaceConfig, err := s.namespaceConfig(member, namespace, "", contractLocation)
+		if err != nil {
+			return messages, err
+		}
+		if err := coreConfigs[i].add(namespaceConfig); err != nil {
+			return messages, err
+		}
+		if member.External {
+			messages = append(messages, fmt.Sprintf("please restart your firefly core for member %s to load namespace '%s' from %s", member.ID, namespace.Name, coreConfigs[i].file))
+		} else {
+			restartCommand = append(restartCommand, fmt.Sprintf("firefly_core_%s", member.ID))
+		}
+	}
+
+	if err := writeCoreConfigs(coreConfigs); err != nil {
+		return messages, err
+	}
+	s.Stack.Namespaces = append(s.Stack.Namespaces, namespace)
+	if err := s.writeStackConfig(); err != nil {
+		s.Stack.Namespaces = s.Stack.Namespaces[:len(s.Stack.Namespaces)-1]
+		return messages, restoreCoreConfigs(coreConfigs, err)
+	}
+
+	if len(restartCommand) > 1 {
+		s.Log.Info("restarting FireFly core containers")
+		if err := s.runDockerComposeCommand(restartCommand...); err != nil {
+			return messages, err
+		}
+	}
+
+	if namespace.Multiparty {
+		s.Log.Info(fmt.Sprintf("registering FireFly identities in namespace '%s'", namespace.Name))
+		if err := s.registerFireflyIdentities(namespace.Name); err != nil {
+			return messages, err
+		}
+	}
+	return messages, nil
+}
+
+// deployFireFlyContract deploys a new instance of the FireFly multiparty contract to one of the blockchains in the
+// stack, and records it in the stack state
+func (s *StackManager) deployFireFlyContract(blockchain int) (location interface{}, message string, err error) {
+	_, provider, err := s.blockchainAt(blockchain)
+	if err != nil {
+		return nil, "", err
+	}
+	result, err := provider.DeployFireFlyContract()
+	if err != nil || result == nil {
+		return nil, "", err
+	}
+	s.Stack.State.DeployedContracts = append(s.Stack.State.DeployedContracts, result.DeployedContract)
+	return result.DeployedContract.Location, result.Message, nil
+}
+
+// namespaceConfig returns the predefined namespace config for a member. Every namespace shares the same database,
+// data exchange and shared storage plugins, but uses the blockchain plugin of its own blockchain and may use a subset
+// of the token plugins.
+func (s *StackManager) namespaceConfig(member *types.Organization, stackNamespace *types.StackNamespace, description string, contractLocation interface{}) (*types.Namespace, error) {
+	chainStack, provider, err := s.blockchainAt(stackNamespace.Blockchain)
+	if err != nil {
+		return nil, err
+	}
+	orgConfig := provider.GetOrgConfig(chainStack, findMember(chainStack, member.ID))
+	namespace := &types.Namespace{
+		Name:        stackNamespace.Name,
+		Description: description,
+		Plugins:     append([]string{"database0", chainStack.BlockchainPluginName(), "dataexchange0", "sharedstorage0"}, stackNamespace.TokenProviders...),
+		DefaultKey:  orgConfig.Key,
+	}
+	if stackNamespace.Multiparty {
+		options := make(map[string]interface{})
+		if s.Stack.CustomPinSupport {
+			options["customPinSupport"] = true
+		}
+		namespace.Multiparty = &types.MultipartyConfig{
+			Enabled: true,
+			Org:     orgConfig,
+			Node: &types.NodeConfig{
+				Name: member.NodeName,
+			},
+			Contract: []*types.ContractConfig{
+				{
+					Location:   contractLocation,
+					FirstEvent: "0",
+					Options:    options,
+				},
+			},
+		}
+	}
+	return namespace, nil
+}
+
+// coreConfigNamespaces is a FireFly core config file that a namespace is being added to. The original content of
+// the file is kept so that it can be restored if the namespace cannot be added to every member.
+type coreConfigNamespaces struct {
+	file       string
+	original   []byte
+	config     yaml.Node
+	predefined *yaml.Node
+}
+
+// readCoreConfigNamespaces reads the predefined namespaces of a FireFly core config file. It fails if the file
+// already defines a namespace with the given name.
+func readCoreConfigNamespaces(configFile, name string) (*coreConfigNamespaces, error) {
+	configBytes, err := os.ReadFile(configFile)
+	if err != nil {
+		return nil, err
+	}
+	c := &coreConfigNamespaces{file: configFile, original: configBytes}
+	if err := yaml.Unmarshal(configBytes, &c.config); err != nil {
+		return nil, err
+	}
+	if len(c.config.Content) > 0 {
+		c.predefined = yamlMappingValue(yamlMappingValue(c.config.Content[0], "namespaces"), "predefined")
+	}
+	if c.predefined == nil || c.predefined.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("no predefined namespaces found in %s", configFile)
+	}
+	for _, existing := range c.predefined.Content {
+		if existingName := yamlMappingValue(existing, "name"); existingName != nil && existingName.Value == name {
+			return nil, fmt.Errorf("namespace '%s' is already defined in %s", name, configFile)
+		}
+	}
+	return c, nil
+}
+
+// add appends a namespace to the predefined namespaces, leaving the rest of the config as it is
+func (c *coreConfigNamespaces) add(namespace *types.Namespace) error {
+	var namespaceNode yaml.Node
+	if err := namespaceNode.Encode(namespace); err != nil {
+		return err
+	}
+	c.predefined.Content = append(c.predefined.Content, &namespaceNode)
+	return nil
+}
+
+// writeCoreConfigs writes the core config files that a namespace has been added to. If one of them cannot be
+// written, the files that have already been written are restored.
+func writeCoreConfigs(coreConfigs []*coreConfigNamespaces) error {
+	for i, c := range coreConfigs {
+		if err := writeYAMLNode(c.file, &c.config); err != nil {
+			return restoreCoreConfigs(coreConfigs[:i], err)
+		}
+	}
+	return nil
+}
+
+// restoreCoreConfigs puts back the original content of core config files after an error adding a namespace,
+// returning that error along with any error restoring the files
+func restoreCoreConfigs(coreConfigs []*coreConfigNamespaces, err error) error {
+	for _, c := range coreConfigs {
+		if restoreErr := os.WriteFile(c.file, c.original, 0755); restoreErr != nil {
+			return fmt.Errorf("%s - error restoring %s: %s", err.Error(), c.file, restoreErr.Error())
+		}
+	}
+	return err
+}
+
+func yamlMappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
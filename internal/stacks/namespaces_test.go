@@ -0,0 +1,201 @@
+package stacks
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/firefly-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+const testCoreConfig = `log:
+  level: debug
+namespaces:
+  default: default
+  predefined:
+    - name: default
+      plugins:
+        - database0
+        - blockchain0
+`
+
+func readTestNamespaceNames(t *testing.T, configFile string) []string {
+	var config struct {
+		Log        map[string]string `yaml:"log"`
+		Namespaces struct {
+			Predefined []*types.Namespace `yaml:"predefined"`
+		} `yaml:"namespaces"`
+	}
+	configBytes, err := os.ReadFile(configFile)
+	assert.NoError(t, err)
+	assert.NoError(t, yaml.Unmarshal(configBytes, &config))
+	assert.Equal(t, "debug", config.Log["level"])
+	var names []string
+	for _, namespace := range config.Namespaces.Predefined {
+		names = append(names, namespace.Name)
+	}
+	return names
+}
+
+func TestAddNamespaceToCoreConfig(t *testing.T) {
+	testCases := []struct {
+		Name      string
+		Config    string
+		Namespace *types.Namespace
+		Error     string
+		Names     []string
+	}{
+		{
+			Name:      "new namespace",
+			Config:    testCoreConfig,
+			Namespace: &types.Namespace{Name: "ns1", Plugins: []string{"database0", "blockchain1", "dataexchange0", "sharedstorage0"}},
+			Names:     []string{"default", "ns1"},
+		},
+		{
+			Name:      "duplicate namespace",
+			Config:    testCoreConfig,
+			Namespace: &types.Namespace{Name: "default", Plugins: []string{"database0"}},
+			Error:     "namespace 'default' is already defined",
+			Names:     []string{"default"},
+		},
+		{
+			Name:      "no predefined namespaces",
+			Config:    "log:\n  level: debug\n",
+			Namespace: &types.Namespace{Name: "ns1"},
+			Error:     "no predefined namespaces found",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			configFile := filepath.Join(t.TempDir(), "firefly_core_0.yml")
+			assert.NoError(t, os.WriteFile(configFile, []byte(tc.Config), 0755))
+
+			coreConfig, err := readCoreConfigNamespaces(configFile, tc.Namespace.Name)
+			if tc.Error != "" {
+				assert.ErrorContains(t, err, tc.Error)
+			} else {
+				assert.NoError(t, err)
+				assert.NoError(t, coreConfig.add(tc.Namespace))
+				assert.NoError(t, writeCoreConfigs([]*coreConfigNamespaces{coreConfig}))
+			}
+			if tc.Names == nil {
+				return
+			}
+			assert.Equal(t, tc.Names, readTestNamespaceNames(t, configFile))
+		})
+	}
+}
+
+func TestSetupNamespace(t *testing.T) {
+	testCases := []struct {
+		Name           string
+		Member1Config  string
+		NoStackDir     bool
+		Error          string
+		Names          []string
+		StackNamespace bool
+	}{
+		{
+			Name:           "added to every member",
+			Member1Config:  testCoreConfig,
+			Names:          []string{"default", "ns1"},
+			StackNamespace: true,
+		},
+		{
+			Name:          "already defined on the second member",
+			Member1Config: testCoreConfig + "    - name: ns1\n",
+			Error:         "namespace 'ns1' is already defined",
+			Names:         []string{"default"},
+		},
+		{
+			Name:          "stack config cannot be written",
+			Member1Config: testCoreConfig,
+			NoStackDir:    true,
+			Error:         "stack.json",
+			Names:         []string{"default"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			dir := t.TempDir()
+			stack := &types.Stack{
+				Name:       "stack",
+				StackDir:   dir,
+				RuntimeDir: filepath.Join(dir, "runtime"),
+				Members: []*types.Organization{
+					{ID: "0", OrgName: "org_0", External: true},
+					{ID: "1", OrgName: "org_1", External: true},
+				},
+			}
+			if tc.NoStackDir {
+				stack.StackDir = filepath.Join(dir, "missing")
+			}
+			assert.NoError(t, os.MkdirAll(filepath.Join(stack.RuntimeDir, "config"), 0755))
+			configFiles := []string{
+				filepath.Join(stack.RuntimeDir, "config", "firefly_core_0.yml"),
+				filepath.Join(stack.RuntimeDir, "config", "firefly_core_1.yml"),
+			}
+			assert.NoError(t, os.WriteFile(configFiles[0], []byte(testCoreConfig), 0755))
+			assert.NoError(t, os.WriteFile(configFiles[1], []byte(tc.Member1Config), 0755))
+			s := &StackManager{Stack: stack, blockchainProvider: &mockBlockchainProvider{}}
+
+			messages, err := s.setupNamespace(&types.StackNamespace{Name: "ns1"})
+			if tc.Error != "" {
+				assert.ErrorContains(t, err, tc.Error)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, messages, 2)
+			}
+			assert.Equal(t, tc.Names, readTestNamespaceNames(t, configFiles[0]))
+			assert.Equal(t, tc.StackNamespace, stack.Namespace("ns1") != nil)
+			_, statErr := os.Stat(filepath.Join(stack.StackDir, "stack.json"))
+			assert.Equal(t, tc.StackNamespace, statErr == nil)
+		})
+	}
+}
+
+func TestNamespaceConfigPlugins(t *testing.T) {
+	member := &types.Organization{ID: "0", OrgName: "org_0", NodeName: "node_0"}
+	stack := &types.Stack{Name: "stack", Members: []*types.Organization{member}}
+	chainStack := &types.Stack{Name: "stack_chain1", Members: []*types.Organization{{ID: "0", OrgName: "org_0"}}, BlockchainIndex: 1}
+	s := &StackManager{
+		Stack:                 stack,
+		blockchainProvider:    &mockBlockchainProvider{},
+		additionalBlockchains: []*additionalBlockchain{{stack: chainStack, provider: &mockBlockchainProvider{}}},
+	}
+
+	testCases := []struct {
+		Name      string
+		Namespace *types.StackNamespace
+		Plugins   []string
+		Key       interface{}
+	}{
+		{
+			Name:      "first blockchain with tokens",
+			Namespace: &types.StackNamespace{Name: "ns1", TokenProviders: []string{"erc20_erc721", "erc1155"}},
+			Plugins:   []string{"database0", "blockchain0", "dataexchange0", "sharedstorage0", "erc20_erc721", "erc1155"},
+			Key:       "stack_0",
+		},
+		{
+			Name:      "additional blockchain",
+			Namespace: &types.StackNamespace{Name: "ns2", Blockchain: 1},
+			Plugins:   []string{"database0", "blockchain1", "dataexchange0", "sharedstorage0"},
+			Key:       "stack_chain1_0",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			namespace, err := s.namespaceConfig(member, tc.Namespace, "", nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Namespace.Name, namespace.Name)
+			assert.Equal(t, tc.Plugins, namespace.Plugins)
+			assert.Equal(t, tc.Key, namespace.DefaultKey)
+			assert.Nil(t, namespace.Multiparty)
+		})
+	}
+
+	_, err := s.namespaceConfig(member, &types.StackNamespace{Name: "ns3", Blockchain: 2}, "", nil)
+	assert.ErrorContains(t, err, "does not have a blockchain 2")
+}
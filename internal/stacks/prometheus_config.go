@@ -16,7 +16,12 @@
 
 package stacks
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/hyperledger/firefly-cli/internal/constants"
+	"github.com/hyperledger/firefly-cli/pkg/types"
+)
 
 type GlobalConfig struct {
 	ScrapeInterval string `yaml:"scrape_interval,omitempty"`
@@ -24,46 +29,199 @@ type GlobalConfig struct {
 }
 
 type ScrapeConfig struct {
-	JobName       string          `yaml:"job_name,omitempty"`
-	MetricsPath   string          `yaml:"metrics_path,omitempty"`
-	StaticConfigs []*StaticConfig `yaml:"static_configs,omitempty"`
+	JobName        string              `yaml:"job_name,omitempty"`
+	MetricsPath    string              `yaml:"metrics_path,omitempty"`
+	Params         map[string][]string `yaml:"params,omitempty"`
+	StaticConfigs  []*StaticConfig     `yaml:"static_configs,omitempty"`
+	RelabelConfigs []*RelabelConfig    `yaml:"relabel_configs,omitempty"`
 }
 
 type StaticConfig struct {
-	Targets []string `yaml:"targets,omitempty"`
+	Targets []string          `yaml:"targets,omitempty"`
+	Labels  map[string]string `yaml:"labels,omitempty"`
+}
+
+type RelabelConfig struct {
+	SourceLabels []string `yaml:"source_labels,omitempty"`
+	TargetLabel  string   `yaml:"target_label,omitempty"`
+	Replacement  string   `yaml:"replacement,omitempty"`
 }
 
 type PrometheusConfig struct {
 	Global        *GlobalConfig   `yaml:"global,omitempty"`
+	RuleFiles     []string        `yaml:"rule_files,omitempty"`
 	ScrapeConfigs []*ScrapeConfig `yaml:"scrape_configs,omitempty"`
 }
 
+type AlertingRules struct {
+	Groups []*AlertingRuleGroup `yaml:"groups"`
+}
+
+type AlertingRuleGroup struct {
+	Name  string          `yaml:"name"`
+	Rules []*AlertingRule `yaml:"rules"`
+}
+
+type AlertingRule struct {
+	Alert       string            `yaml:"alert"`
+	Expr        string            `yaml:"expr"`
+	For         string            `yaml:"for,omitempty"`
+	Labels      map[string]string `yaml:"labels,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+}
+
+// The component label of each scrape target
+const (
+	componentCore      = "core"
+	componentConnector = "connector"
+	componentSigner    = "signer"
+	componentNode      = "node"
+	componentTokens    = "tokens"
+	componentIPFS      = "ipfs"
+	componentPostgres  = "postgres"
+)
+
+const probesJobName = "probes"
+
+// GeneratePrometheusConfig returns the config of the shared Prometheus server, which scrapes every service in the
+// stack that serves metrics, and checks that the others are accepting connections with the blackbox exporter.
+// Every target has a component label, and a member and org label if it belongs to a member.
 func (s *StackManager) GeneratePrometheusConfig() *PrometheusConfig {
+	compose := s.buildDockerCompose()
+
+	coreJob := &ScrapeConfig{JobName: "fireflies", MetricsPath: "/metrics"}
+	connectorJob := &ScrapeConfig{JobName: "connectors", MetricsPath: "/metrics"}
+	signerJob := &ScrapeConfig{JobName: "signers", MetricsPath: "/metrics"}
+	gethJob := &ScrapeConfig{JobName: "geth", MetricsPath: "/debug/metrics/prometheus"}
+	besuJob := &ScrapeConfig{JobName: "besu", MetricsPath: "/metrics"}
+	ipfsJob := &ScrapeConfig{JobName: "ipfs", MetricsPath: "/debug/metrics/prometheus"}
+	postgresJob := &ScrapeConfig{JobName: "postgres", MetricsPath: "/metrics"}
+	probesJob := &ScrapeConfig{
+		JobName:     probesJobName,
+		MetricsPath: "/probe",
+		Params:      map[string][]string{"module": {"tcp_connect"}},
+		RelabelConfigs: []*RelabelConfig{
+			{SourceLabels: []string{"__address__"}, TargetLabel: "__param_target"},
+			{SourceLabels: []string{"__param_target"}, TargetLabel: "instance"},
+			{TargetLabel: "__address__", Replacement: "blackbox:9115"},
+		},
+	}
+
+	// Only services that are part of the stack are added, which leaves out the services of external members
+	addTarget := func(job *ScrapeConfig, serviceName string, port int, component string, member *types.Organization, blockchainStack *types.Stack) {
+		if _, ok := compose.Services[serviceName]; !ok {
+			return
+		}
+		labels := map[string]string{"component": component}
+		if member != nil {
+			labels["member"] = member.ID
+			labels["org"] = member.OrgName
+		}
+		if blockchainStack != nil {
+			labels["blockchain"] = blockchainStack.BlockchainPluginName()
+		}
+		job.StaticConfigs = append(job.StaticConfigs, &StaticConfig{
+			Targets: []string{fmt.Sprintf("%s:%d", serviceName, port)},
+			Labels:  labels,
+		})
+	}
+
+	for _, member := range s.Stack.Members {
+		addTarget(coreJob, "firefly_core_"+member.ID, member.ExposedFireflyMetricsPort, componentCore, member, nil)
+		addTarget(ipfsJob, "ipfs_"+member.ID, 5001, componentIPFS, member, nil)
+		addTarget(postgresJob, "pgexporter_"+member.ID, 9187, componentPostgres, member, nil)
+		for i, tp := range s.tokenProviders {
+			addTarget(probesJob, fmt.Sprintf("tokens_%s_%d", member.ID, i), tp.GetPort(), componentTokens, member, nil)
+		}
+	}
+
+	// Besu pushes its metrics to the OpenTelemetry collector instead of serving them when tracing is enabled, so the
+	// nodes are only probed, and their metrics are scraped from the collector
+	besuNodeJob, besuNodePort := besuJob, constants.BesuMetricsPort
+	if s.Stack.BesuTracingEnabled {
+		besuNodeJob, besuNodePort = probesJob, 8545
+		addTarget(besuJob, "otel_collector", otelCollectorMetricsPort, componentNode, nil, nil)
+	}
+	for i := 0; i <= len(s.additionalBlockchains); i++ {
+		chainStack, provider, _ := s.blockchainAt(i)
+		for j, member := range chainStack.Members {
+			connectorService := chainStack.ServiceName(fmt.Sprintf("%s_%s", provider.GetConnectorName(), member.ID))
+			switch provider.GetConnectorName() {
+			case "ethconnect":
+				// ethconnect and fabconnect do not serve metrics
+				addTarget(probesJob, connectorService, 8080, componentConnector, member, chainStack)
+			case "fabconnect":
+				addTarget(probesJob, connectorService, 3000, componentConnector, member, chainStack)
+			default:
+				addTarget(connectorJob, connectorService, member.ExposedConnectorMetricsPort, componentConnector, member, chainStack)
+			}
+			addTarget(gethJob, chainStack.ServiceName(fmt.Sprintf("quorum_%d", j)), constants.GethMetricsPort, componentNode, member, chainStack)
+			addTarget(gethJob, chainStack.ServiceName(fmt.Sprintf("geth_%d", j)), constants.GethMetricsPort, componentNode, member, chainStack)
+			addTarget(besuNodeJob, chainStack.ServiceName(fmt.Sprintf("besu_%d", j)), besuNodePort, componentNode, member, chainStack)
+			addTarget(probesJob, chainStack.ServiceName(fmt.Sprintf("ethsigner_%d", j)), 8545, componentSigner, member, chainStack)
+		}
+		addTarget(gethJob, chainStack.ServiceName("geth"), constants.GethMetricsPort, componentNode, nil, chainStack)
+		addTarget(besuNodeJob, chainStack.ServiceName("besu"), besuNodePort, componentNode, nil, chainStack)
+		addTarget(signerJob, chainStack.ServiceName("tezossigner"), 9583, componentSigner, nil, chainStack)
+		addTarget(probesJob, chainStack.ServiceName("ethsigner"), 8545, componentSigner, nil, chainStack)
+		addTarget(probesJob, chainStack.ServiceName("cardanosigner"), 8555, componentSigner, nil, chainStack)
+	}
+
 	config := &PrometheusConfig{
 		Global: &GlobalConfig{
 			ScrapeInterval: "5s",
 			ScrapeTimeout:  "5s",
 		},
-		ScrapeConfigs: []*ScrapeConfig{
+		RuleFiles: []string{"/etc/prometheus/alerts.yml"},
+	}
+	for _, job := range []*ScrapeConfig{coreJob, connectorJob, signerJob, gethJob, besuJob, ipfsJob, postgresJob, probesJob} {
+		if len(job.StaticConfigs) > 0 {
+			config.ScrapeConfigs = append(config.ScrapeConfigs, job)
+		}
+	}
+	return config
+}
+
+// GeneratePrometheusAlertingRules returns the default alerting rules of the shared Prometheus server, which fire
+// when a component of the stack stops responding
+func (s *StackManager) GeneratePrometheusAlertingRules() *AlertingRules {
+	return &AlertingRules{
+		Groups: []*AlertingRuleGroup{
 			{
-				JobName:     "fireflies",
-				MetricsPath: "/metrics",
-				StaticConfigs: []*StaticConfig{
+				Name: "firefly_stack",
+				Rules: []*AlertingRule{
 					{
-						Targets: []string{},
+						Alert:  "ComponentDown",
+						Expr:   fmt.Sprintf(`up{job!="%s"} == 0`, probesJobName),
+						For:    "30s",
+						Labels: map[string]string{"severity": "critical"},
+						Annotations: map[string]string{
+							"summary":     "{{ $labels.component }} {{ $labels.instance }} is down",
+							"description": "Prometheus cannot scrape the metrics of {{ $labels.component }} {{ $labels.instance }} of org {{ $labels.org }}",
+						},
+					},
+					{
+						Alert:  "ComponentUnreachable",
+						Expr:   "probe_success == 0",
+						For:    "30s",
+						Labels: map[string]string{"severity": "critical"},
+						Annotations: map[string]string{
+							"summary":     "{{ $labels.component }} {{ $labels.instance }} is not accepting connections",
+							"description": "{{ $labels.component }} {{ $labels.instance }} of org {{ $labels.org }} is not accepting connections",
+						},
+					},
+					{
+						Alert:  "PostgresDown",
+						Expr:   "pg_up == 0",
+						For:    "30s",
+						Labels: map[string]string{"severity": "critical"},
+						Annotations: map[string]string{
+							"summary":     "The database of member {{ $labels.member }} is down",
+							"description": "The postgres exporter of org {{ $labels.org }} cannot connect to its database",
+						},
 					},
 				},
 			},
 		},
 	}
-
-	for i, member := range s.Stack.Members {
-		config.ScrapeConfigs[0].StaticConfigs[0].Targets = append(config.ScrapeConfigs[0].StaticConfigs[0].Targets, fmt.Sprintf("firefly_core_%d:%d", i, member.ExposedFireflyMetricsPort))
-
-		if s.blockchainProvider.GetConnectorName() == "evmconnect" {
-			config.ScrapeConfigs[0].StaticConfigs[0].Targets = append(config.ScrapeConfigs[0].StaticConfigs[0].Targets, fmt.Sprintf("evmconnect_%d:%d", i, member.ExposedConnectorMetricsPort))
-		}
-	}
-
-	return config
 }
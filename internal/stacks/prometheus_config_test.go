@@ -0,0 +1,91 @@
+package stacks
+
+import (
+	"testing"
+
+	"github.com/hyperledger/firefly-cli/internal/tokens"
+	"github.com/stretchr/testify/assert"
+)
+
+// scrapeTargets returns the labels of each target of each job in a Prometheus config
+func scrapeTargets(config *PrometheusConfig) map[string]map[string]map[string]string {
+	jobs := make(map[string]map[string]map[string]string)
+	for _, job := range config.ScrapeConfigs {
+		jobs[job.JobName] = make(map[string]map[string]string)
+		for _, staticConfig := range job.StaticConfigs {
+			for _, target := range staticConfig.Targets {
+				jobs[job.JobName][target] = staticConfig.Labels
+			}
+		}
+	}
+	return jobs
+}
+
+func TestGeneratePrometheusConfig(t *testing.T) {
+	s := newTestStackManager()
+	jobs := scrapeTargets(s.GeneratePrometheusConfig())
+
+	assert.Equal(t, map[string]map[string]string{
+		"firefly_core_0:6000": {"component": "core", "member": "0", "org": "org_0"},
+		"firefly_core_1:6001": {"component": "core", "member": "1", "org": "org_1"},
+	}, jobs["fireflies"])
+	assert.Equal(t, map[string]map[string]string{
+		"evmconnect_0:6100":        {"component": "connector", "member": "0", "org": "org_0", "blockchain": "blockchain0"},
+		"evmconnect_1:6101":        {"component": "connector", "member": "1", "org": "org_1", "blockchain": "blockchain0"},
+		"chain1_evmconnect_0:6100": {"component": "connector", "member": "0", "org": "org_0", "blockchain": "blockchain1"},
+		"chain1_evmconnect_1:6101": {"component": "connector", "member": "1", "org": "org_1", "blockchain": "blockchain1"},
+	}, jobs["connectors"])
+	assert.Equal(t, map[string]map[string]string{
+		"geth:6060":        {"component": "node", "blockchain": "blockchain0"},
+		"chain1_geth:6060": {"component": "node", "blockchain": "blockchain1"},
+	}, jobs["geth"])
+	assert.Equal(t, map[string]map[string]string{
+		"ipfs_0:5001": {"component": "ipfs", "member": "0", "org": "org_0"},
+		"ipfs_1:5001": {"component": "ipfs", "member": "1", "org": "org_1"},
+	}, jobs["ipfs"])
+	assert.Equal(t, map[string]map[string]string{
+		"tokens_0_0:3000": {"component": "tokens", "member": "0", "org": "org_0"},
+		"tokens_1_0:3000": {"component": "tokens", "member": "1", "org": "org_1"},
+	}, jobs["probes"])
+	// Jobs without any targets are left out
+	assert.NotContains(t, jobs, "besu")
+	assert.NotContains(t, jobs, "postgres")
+	assert.NotContains(t, jobs, "signers")
+}
+
+func TestGeneratePrometheusConfigTokensPort(t *testing.T) {
+	s := newTestStackManager()
+	s.tokenProviders = []tokens.ITokensProvider{
+		&mockTokensProvider{stack: s.Stack, port: 3000},
+		&mockTokensProvider{stack: s.Stack, port: 4000},
+	}
+	probes := scrapeTargets(s.GeneratePrometheusConfig())["probes"]
+	assert.Contains(t, probes, "tokens_0_0:3000")
+	assert.Contains(t, probes, "tokens_1_0:3000")
+	assert.Contains(t, probes, "tokens_0_1:4000")
+	assert.Contains(t, probes, "tokens_1_1:4000")
+	assert.Len(t, probes, 4)
+}
+
+func TestGeneratePrometheusConfigExternalMember(t *testing.T) {
+	s := newTestStackManager()
+	s.Stack.Members[1].External = true
+	fireflies := scrapeTargets(s.GeneratePrometheusConfig())["fireflies"]
+	assert.Contains(t, fireflies, "firefly_core_0:6000")
+	assert.NotContains(t, fireflies, "firefly_core_1:6001")
+}
+
+func TestGeneratePrometheusConfigTracing(t *testing.T) {
+	s := newTestStackManager()
+	s.Stack.BesuTracingEnabled = true
+	s.blockchainProvider = &mockBlockchainProvider{connectorName: "evmconnect", serviceNames: []string{"besu_0", "besu_1", "evmconnect_0", "evmconnect_1"}}
+	s.additionalBlockchains = nil
+	jobs := scrapeTargets(s.GeneratePrometheusConfig())
+
+	// Besu pushes its metrics to the collector, so the nodes are only probed
+	assert.Equal(t, map[string]map[string]string{
+		"otel_collector:8889": {"component": "node"},
+	}, jobs["besu"])
+	assert.Equal(t, map[string]string{"component": "node", "member": "0", "org": "org_0", "blockchain": "blockchain0"}, jobs["probes"]["besu_0:8545"])
+	assert.Equal(t, map[string]string{"component": "node", "member": "1", "org": "org_1", "blockchain": "blockchain0"}, jobs["probes"]["besu_1:8545"])
+}
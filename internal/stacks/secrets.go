@@ -0,0 +1,150 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stacks
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hyperledger/firefly-cli/internal/docker"
+	"github.com/hyperledger/firefly-cli/pkg/types"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func generatePassword() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+// generateStackSecrets generates the passwords for the stack. It runs before the members are created, because
+// their accounts are encrypted with the keystore password.
+func (s *StackManager) generateStackSecrets() error {
+	secrets := &types.StackSecrets{}
+	for _, password := range []*string{&secrets.PostgresPassword, &secrets.KeystorePassword, &secrets.FabricCAAdminPassword, &secrets.GrafanaAdminPassword} {
+		generated, err := generatePassword()
+		if err != nil {
+			return err
+		}
+		*password = generated
+	}
+	s.Stack.Secrets = secrets
+	return nil
+}
+
+// generateMemberSecrets generates the credentials for each member of the stack
+func (s *StackManager) generateMemberSecrets() error {
+	s.Stack.Secrets.Members = make([]*types.MemberSecrets, len(s.Stack.Members))
+	for i, member := range s.Stack.Members {
+		memberSecrets := &types.MemberSecrets{
+			ID: member.ID,
+		}
+		if s.Stack.AuthMode.Equals(types.AuthModeBasic) {
+			password, err := generatePassword()
+			if err != nil {
+				return err
+			}
+			memberSecrets.BasicAuth = &types.BasicAuth{
+				Username: fmt.Sprintf("member_%s", member.ID),
+				Password: password,
+			}
+		}
+		s.Stack.Secrets.Members[i] = memberSecrets
+	}
+	return nil
+}
+
+func (s *StackManager) writeStackSecrets() error {
+	secretsBytes, err := json.MarshalIndent(s.Stack.Secrets, "", " ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(s.Stack.StackDir, "secrets.json"), secretsBytes, 0600)
+}
+
+func (s *StackManager) loadStackSecrets() error {
+	secretsBytes, err := os.ReadFile(filepath.Join(s.Stack.StackDir, "secrets.json"))
+	if os.IsNotExist(err) {
+		// Stacks created with older CLI versions do not have any secrets, and use the passwords
+		// those versions hard-coded
+		s.Stack.Secrets = &types.StackSecrets{}
+		s.Stack.Secrets.SetLegacyDefaults()
+		return nil
+	} else if err != nil {
+		return err
+	}
+	var secrets *types.StackSecrets
+	if err := json.Unmarshal(secretsBytes, &secrets); err != nil {
+		return err
+	}
+	secrets.SetLegacyDefaults()
+	s.Stack.Secrets = secrets
+	return nil
+}
+
+// writeBasicAuthFiles writes an htpasswd file for each member, which is mounted into the services that
+// check the member's credentials, and env files that pass the credentials to the token connectors and the sandbox
+func (s *StackManager) writeBasicAuthFiles() error {
+	// The connectors of additional blockchains mount the htpasswd files from the init directory of their blockchain
+	authDirs := []string{filepath.Join(s.Stack.InitDir, "config", "auth")}
+	for _, chain := range s.additionalBlockchains {
+		authDirs = append(authDirs, filepath.Join(chain.stack.InitDir, "config", "auth"))
+	}
+	for _, authDir := range authDirs {
+		if err := s.writeBasicAuthFilesToDir(authDir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *StackManager) writeBasicAuthFilesToDir(authDir string) error {
+	if err := os.MkdirAll(authDir, 0755); err != nil {
+		return err
+	}
+	for _, member := range s.Stack.Members {
+		auth := s.Stack.MemberBasicAuth(member)
+		if auth == nil {
+			continue
+		}
+		hash, err := bcrypt.GenerateFromPassword([]byte(auth.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return err
+		}
+		htpasswd := fmt.Sprintf("%s:%s\n", auth.Username, hash)
+		if err := os.WriteFile(filepath.Join(authDir, fmt.Sprintf("htpasswd_%s", member.ID)), []byte(htpasswd), 0644); err != nil {
+			return err
+		}
+		env := fmt.Sprintf("BASIC_AUTH_USERNAME=%[1]s\nBASIC_AUTH_PASSWORD=%[2]s\nETHCONNECT_USERNAME=%[1]s\nETHCONNECT_PASSWORD=%[2]s\n", auth.Username, auth.Password)
+		if err := os.WriteFile(filepath.Join(authDir, fmt.Sprintf("auth_%s.env", member.ID)), []byte(env), 0600); err != nil {
+			return err
+		}
+		if s.Stack.SandboxEnabled {
+			sandboxEnv := fmt.Sprintf("FF_ENDPOINT=%s\n", docker.SandboxFireFlyEndpoint(s.Stack, member, auth))
+			if err := os.WriteFile(filepath.Join(authDir, fmt.Sprintf("sandbox_%s.env", member.ID)), []byte(sandboxEnv), 0600); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
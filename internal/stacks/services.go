@@ -0,0 +1,126 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stacks
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hyperledger/firefly-cli/internal/tokens"
+	"github.com/hyperledger/firefly-cli/pkg/types"
+)
+
+// ServiceCommands are the docker compose commands that can be run on a selection of the services in a stack
+var ServiceCommands = []string{"start", "stop", "restart", "pause", "unpause"}
+
+// SelectServices returns the compose services of the stack that belong to the selected members, along with the
+// selected services. A service selector matches the service with that name, and every service whose name starts
+// with the selector followed by an underscore, so "evmconnect" selects the connector of every member. The services of
+// additional blockchains also match without their chain prefix, so "evmconnect" selects chain1_evmconnect_0 as well,
+// whereas "chain1_evmconnect" only selects the connectors of that blockchain.
+func (s *StackManager) SelectServices(memberIndexes []int, serviceSelectors []string) ([]string, error) {
+	compose := s.buildDockerCompose()
+	selected := make(map[string]bool)
+
+	for _, memberIndex := range memberIndexes {
+		if memberIndex < 0 || memberIndex >= len(s.Stack.Members) {
+			return nil, fmt.Errorf("stack '%s' does not have a member %d", s.Stack.Name, memberIndex)
+		}
+		for _, serviceName := range s.memberServiceNames(s.Stack.Members[memberIndex]) {
+			if _, ok := compose.Services[serviceName]; ok {
+				selected[serviceName] = true
+			}
+		}
+	}
+
+	for _, selector := range serviceSelectors {
+		found := false
+		for serviceName := range compose.Services {
+			if matchesServiceSelector(serviceName, selector) || matchesServiceSelector(s.trimChainPrefix(serviceName), selector) {
+				selected[serviceName] = true
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("stack '%s' does not have a service '%s'", s.Stack.Name, selector)
+		}
+	}
+
+	serviceNames := make([]string, 0, len(selected))
+	for serviceName := range selected {
+		serviceNames = append(serviceNames, serviceName)
+	}
+	slices.Sort(serviceNames)
+	return serviceNames, nil
+}
+
+func matchesServiceSelector(serviceName, selector string) bool {
+	return serviceName == selector || strings.HasPrefix(serviceName, selector+"_")
+}
+
+// trimChainPrefix returns the name of a service of an additional blockchain without the prefix of its blockchain
+func (s *StackManager) trimChainPrefix(serviceName string) string {
+	for _, chain := range s.additionalBlockchains {
+		if chain.stack.ServicePrefix != "" && strings.HasPrefix(serviceName, chain.stack.ServicePrefix) {
+			return strings.TrimPrefix(serviceName, chain.stack.ServicePrefix)
+		}
+	}
+	return serviceName
+}
+
+// memberServiceNames returns the names of the services that belong to a member of the stack, which is every service
+// that would go offline along with the member's organization. Services shared by every member, such as the blockchain
+// node of a geth stack, are not included. Some of the services may not be part of the stack, depending on its options.
+func (s *StackManager) memberServiceNames(member *types.Organization) []string {
+	serviceNames := []string{
+		fmt.Sprintf("firefly_core_%s", member.ID),
+		fmt.Sprintf("postgres_%s", member.ID),
+		fmt.Sprintf("ipfs_%s", member.ID),
+		fmt.Sprintf("ipfs_api_%s", member.ID),
+		fmt.Sprintf("dataexchange_%s", member.ID),
+		fmt.Sprintf("sandbox_%s", member.ID),
+		fmt.Sprintf("pgexporter_%s", member.ID),
+	}
+	// Blockchain services that are run for each member, such as their connectors and quorum nodes, have the member ID
+	// as the last part of their name
+	for _, serviceDefinition := range s.blockchainServiceDefinitions() {
+		if strings.HasSuffix(serviceDefinition.ServiceName, "_"+member.ID) {
+			serviceNames = append(serviceNames, serviceDefinition.ServiceName)
+		}
+	}
+	for i := range s.tokenProviders {
+		serviceNames = append(serviceNames, fmt.Sprintf("tokens_%s_%d", member.ID, i), tokens.TLSProxyName(member, i))
+	}
+	return serviceNames
+}
+
+// RunServiceCommand runs one of the ServiceCommands on services of a stack that has already been started, or on
+// every service in the stack if none are given
+func (s *StackManager) RunServiceCommand(command string, serviceNames []string) error {
+	if !slices.Contains(ServiceCommands, command) {
+		return fmt.Errorf("unsupported service command '%s'", command)
+	}
+	hasRunBefore, err := s.Stack.HasRunBefore()
+	if err != nil {
+		return err
+	}
+	if !hasRunBefore {
+		return fmt.Errorf("stack '%s' has not been started yet - start the whole stack first", s.Stack.Name)
+	}
+	return s.runDockerComposeCommand(append([]string{command}, serviceNames...)...)
+}
@@ -0,0 +1,82 @@
+package stacks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectServices(t *testing.T) {
+	s := newTestStackManager()
+
+	testCases := []struct {
+		Name      string
+		Members   []int
+		Selectors []string
+		Services  []string
+		Error     string
+	}{
+		{
+			Name:      "connectors of every blockchain",
+			Selectors: []string{"evmconnect"},
+			Services:  []string{"chain1_evmconnect_0", "chain1_evmconnect_1", "evmconnect_0", "evmconnect_1"},
+		},
+		{
+			Name:      "connector of a member on every blockchain",
+			Selectors: []string{"evmconnect_1"},
+			Services:  []string{"chain1_evmconnect_1", "evmconnect_1"},
+		},
+		{
+			Name:      "connectors of an additional blockchain",
+			Selectors: []string{"chain1_evmconnect"},
+			Services:  []string{"chain1_evmconnect_0", "chain1_evmconnect_1"},
+		},
+		{
+			Name:      "every service of an additional blockchain",
+			Selectors: []string{"chain1"},
+			Services:  []string{"chain1_evmconnect_0", "chain1_evmconnect_1", "chain1_geth"},
+		},
+		{
+			Name:      "shared node",
+			Selectors: []string{"geth"},
+			Services:  []string{"chain1_geth", "geth"},
+		},
+		{
+			Name:     "member",
+			Members:  []int{1},
+			Services: []string{"chain1_evmconnect_1", "dataexchange_1", "evmconnect_1", "firefly_core_1", "ipfs_1", "tokens_1_0"},
+		},
+		{
+			Name:      "member and service",
+			Members:   []int{0},
+			Selectors: []string{"geth"},
+			Services:  []string{"chain1_evmconnect_0", "chain1_geth", "dataexchange_0", "evmconnect_0", "firefly_core_0", "geth", "ipfs_0", "tokens_0_0"},
+		},
+		{
+			Name:      "unknown service",
+			Selectors: []string{"besu"},
+			Error:     "stack 'stack' does not have a service 'besu'",
+		},
+		{
+			Name:      "partial name",
+			Selectors: []string{"evm"},
+			Error:     "stack 'stack' does not have a service 'evm'",
+		},
+		{
+			Name:    "unknown member",
+			Members: []int{2},
+			Error:   "stack 'stack' does not have a member 2",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			services, err := s.SelectServices(tc.Members, tc.Selectors)
+			if tc.Error != "" {
+				assert.EqualError(t, err, tc.Error)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.Services, services)
+			}
+		})
+	}
+}
@@ -34,20 +34,12 @@ import (
 	"time"
 
 	"github.com/hyperledger/firefly-cli/internal/blockchain"
-	cardanoremoterpc "github.com/hyperledger/firefly-cli/internal/blockchain/cardano/remoterpc"
-	"github.com/hyperledger/firefly-cli/internal/blockchain/ethereum/besu"
-	"github.com/hyperledger/firefly-cli/internal/blockchain/ethereum/geth"
-	"github.com/hyperledger/firefly-cli/internal/blockchain/ethereum/quorum"
-	ethremoterpc "github.com/hyperledger/firefly-cli/internal/blockchain/ethereum/remoterpc"
-	"github.com/hyperledger/firefly-cli/internal/blockchain/fabric"
-	tezosremoterpc "github.com/hyperledger/firefly-cli/internal/blockchain/tezos/remoterpc"
 	"github.com/hyperledger/firefly-cli/internal/constants"
 	"github.com/hyperledger/firefly-cli/internal/core"
 	"github.com/hyperledger/firefly-cli/internal/docker"
 	"github.com/hyperledger/firefly-cli/internal/log"
 	"github.com/hyperledger/firefly-cli/internal/tokens"
-	"github.com/hyperledger/firefly-cli/internal/tokens/erc1155"
-	"github.com/hyperledger/firefly-cli/internal/tokens/erc20erc721"
+	customtokens "github.com/hyperledger/firefly-cli/internal/tokens/custom"
 	"github.com/hyperledger/firefly-cli/pkg/types"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/miracl/conflate"
@@ -63,8 +55,10 @@ type StackManager struct {
 	Stack              *types.Stack
 	blockchainProvider blockchain.IBlockchainProvider
 	tokenProviders     []tokens.ITokensProvider
-	IsOldFileStructure bool
-	once               sync.Once
+	// additionalBlockchains are the blockchains in the stack other than the first one
+	additionalBlockchains []*additionalBlockchain
+	IsOldFileStructure    bool
+	once                  sync.Once
 }
 
 var unsupportedARM64Images map[string]bool = map[string]bool{
@@ -100,6 +94,20 @@ func (s *StackManager) InitStack(options *types.InitOptions) (err error) {
 	for key, value := range options.EnvironmentVars {
 		environmentVarsMap[key] = value
 	}
+	resources := types.ResourceProfileResources(fftypes.FFEnum(options.ResourceProfile))
+	for class, override := range options.ServiceResources {
+		resources[fftypes.FFEnum(class)] = resources[fftypes.FFEnum(class)].Merge(override)
+	}
+	var serviceEnvironmentVarsMap map[string]map[string]interface{}
+	if len(options.ServiceEnvironmentVars) > 0 {
+		serviceEnvironmentVarsMap = make(map[string]map[string]interface{}, len(options.ServiceEnvironmentVars))
+		for selector, environmentVars := range options.ServiceEnvironmentVars {
+			serviceEnvironmentVarsMap[selector] = make(map[string]interface{}, len(environmentVars))
+			for key, value := range environmentVars {
+				serviceEnvironmentVarsMap[selector][key] = value
+			}
+		}
+	}
 	s.Stack = &types.Stack{
 		Name:                      options.StackName,
 		Members:                   make([]*types.Organization, options.MemberCount),
@@ -119,28 +127,50 @@ func (s *StackManager) InitStack(options *types.InitOptions) (err error) {
 			DeployedContracts: make([]*types.DeployedContract, 0),
 			Accounts:          make([]interface{}, options.MemberCount),
 		},
-		SandboxEnabled:    options.SandboxEnabled,
-		MultipartyEnabled: options.MultipartyEnabled,
-		ChainIDPtr:        &options.ChainID,
-		Network:           options.Network,
-		BlockfrostKey:     options.BlockfrostKey,
-		BlockfrostBaseURL: options.BlockfrostBaseURL,
-		Socket:            options.Socket,
-		RemoteNodeURL:     options.RemoteNodeURL,
-		RequestTimeout:    options.RequestTimeout,
-		IPFSMode:          fftypes.FFEnum(options.IPFSMode),
-		ChannelName:       options.ChannelName,
-		ChaincodeName:     options.ChaincodeName,
-		CustomPinSupport:  options.CustomPinSupport,
-		RemoteNodeDeploy:  options.RemoteNodeDeploy,
-		EnvironmentVars:   environmentVarsMap,
+		SandboxEnabled:         options.SandboxEnabled,
+		MultipartyEnabled:      options.MultipartyEnabled,
+		ChainIDPtr:             &options.ChainID,
+		Network:                options.Network,
+		BlockfrostKey:          options.BlockfrostKey,
+		BlockfrostBaseURL:      options.BlockfrostBaseURL,
+		Socket:                 options.Socket,
+		RemoteNodeURL:          options.RemoteNodeURL,
+		RequestTimeout:         options.RequestTimeout,
+		IPFSMode:               fftypes.FFEnum(options.IPFSMode),
+		ChannelName:            options.ChannelName,
+		ChaincodeName:          options.ChaincodeName,
+		CustomPinSupport:       options.CustomPinSupport,
+		RemoteNodeDeploy:       options.RemoteNodeDeploy,
+		EnvironmentVars:        environmentVarsMap,
+		ServiceEnvironmentVars: serviceEnvironmentVarsMap,
+		Resources:              resources,
+		TLSEnabled:             options.TLSEnabled,
+		AuthMode:               fftypes.FFEnum(options.AuthMode),
+	}
+
+	if options.BlockPeriod > 0 {
+		s.Stack.BlockPeriod = options.BlockPeriod
+	}
+
+	if fftypes.FFEnum(options.GethMode).Equals(types.GethModeDev) {
+		s.Stack.GethMode = types.GethModeDev
 	}
 
+	s.Stack.NodePerMember = options.NodePerMember
+
 	tokenProviders, err := types.FFEnumArray(s.ctx, options.TokenProviders)
 	if err != nil {
 		return err
 	}
-	s.Stack.TokenProviders = tokenProviders
+	s.Stack.TokenProviders = make([]fftypes.FFEnum, 0, len(tokenProviders))
+	for _, tp := range tokenProviders {
+		if !tp.Equals(types.TokenProviderNone) {
+			s.Stack.TokenProviders = append(s.Stack.TokenProviders, tp)
+		}
+	}
+	if err := s.writeCustomTokenProviders(options.CustomTokenProviders); err != nil {
+		return err
+	}
 
 	if s.Stack.IPFSMode.Equals(types.IPFSModePrivate) {
 		s.Stack.SwarmKey, err = GenerateSwarmKey()
@@ -154,6 +184,21 @@ func (s *StackManager) InitStack(options *types.InitOptions) (err error) {
 		s.Stack.ExposedPrometheusPort = options.PrometheusPort
 	}
 
+	if options.GrafanaEnabled {
+		s.Stack.GrafanaEnabled = true
+		s.Stack.ExposedGrafanaPort = options.GrafanaPort
+	}
+
+	if options.BesuTracingEnabled {
+		s.Stack.BesuTracingEnabled = true
+		s.Stack.ExposedJaegerPort = options.JaegerPort
+	}
+
+	if options.LogAggregation != "" && !fftypes.FFEnum(options.LogAggregation).Equals(types.LogAggregationNone) {
+		s.Stack.LogAggregation = fftypes.FFEnum(options.LogAggregation)
+		s.Stack.ExposedLokiPort = options.LokiPort
+	}
+
 	if len(options.CCPYAMLPaths) != 0 && len(options.MSPPaths) != 0 {
 		s.Stack.RemoteFabricNetwork = true
 	} else {
@@ -186,7 +231,13 @@ func (s *StackManager) InitStack(options *types.InitOptions) (err error) {
 
 	s.Stack.VersionManifest = manifest
 	s.blockchainProvider = s.getBlockchainProvider()
-	s.tokenProviders = s.getITokenProviders()
+	if s.tokenProviders, err = s.getITokenProviders(); err != nil {
+		return err
+	}
+
+	if err := s.generateStackSecrets(); err != nil {
+		return err
+	}
 
 	for i := 0; i < options.MemberCount; i++ {
 		externalProcess := i < options.ExternalProcesses
@@ -200,6 +251,14 @@ func (s *StackManager) InitStack(options *types.InitOptions) (err error) {
 		}
 	}
 
+	if err := s.createAdditionalBlockchains(options); err != nil {
+		return err
+	}
+
+	if err := s.generateMemberSecrets(); err != nil {
+		return err
+	}
+
 	if err := s.ensureInitDirectories(); err != nil {
 		return err
 	}
@@ -231,9 +290,26 @@ func (s *StackManager) runDockerComposeCommand(command ...string) error {
 
 func (s *StackManager) buildDockerCompose() *docker.DockerComposeConfig {
 	compose := docker.CreateDockerCompose(s.Stack)
-	extraServices := s.blockchainProvider.GetDockerServiceDefinitions()
+	serviceClasses := s.serviceClasses()
+	extraServices := s.blockchainServiceDefinitions()
+	connectorPrefixes := []string{s.blockchainProvider.GetConnectorName() + "_"}
+	for _, chain := range s.additionalBlockchains {
+		connectorPrefixes = append(connectorPrefixes, chain.stack.ServiceName(chain.provider.GetConnectorName()+"_"))
+	}
+	for _, serviceDefinition := range extraServices {
+		serviceClasses[serviceDefinition.ServiceName] = types.ServiceClassBlockchain
+		for _, prefix := range connectorPrefixes {
+			if strings.HasPrefix(serviceDefinition.ServiceName, prefix) {
+				serviceClasses[serviceDefinition.ServiceName] = types.ServiceClassConnector
+			}
+		}
+	}
 	for i, tp := range s.tokenProviders {
-		extraServices = append(extraServices, tp.GetDockerServiceDefinitions(i)...)
+		tokenServices := tp.GetDockerServiceDefinitions(i)
+		for _, serviceDefinition := range tokenServices {
+			serviceClasses[serviceDefinition.ServiceName] = types.ServiceClassTokens
+		}
+		extraServices = append(extraServices, tokenServices...)
 	}
 
 	for _, serviceDefinition := range extraServices {
@@ -255,9 +331,31 @@ func (s *StackManager) buildDockerCompose() *docker.DockerComposeConfig {
 			}
 		}
 	}
+	if s.Stack.LogAggregation.Equals(types.LogAggregationLoki) {
+		s.applyLogAggregationLabels(compose)
+	}
+	docker.ApplyServiceEnvironmentVars(compose, s.Stack.ServiceEnvironmentVars)
+	for serviceName, class := range serviceClasses {
+		if service, ok := compose.Services[serviceName]; ok {
+			docker.ApplyServiceResources(service, s.Stack.Resources[class])
+		}
+	}
 	return compose
 }
 
+// serviceClasses returns the class of each of the services that are created for every stack, for setting their
+// resource limits and restart policies
+func (s *StackManager) serviceClasses() map[string]fftypes.FFEnum {
+	serviceClasses := make(map[string]fftypes.FFEnum)
+	for _, member := range s.Stack.Members {
+		serviceClasses["firefly_core_"+member.ID] = types.ServiceClassCore
+		serviceClasses["postgres_"+member.ID] = types.ServiceClassPostgres
+		serviceClasses["ipfs_"+member.ID] = types.ServiceClassIPFS
+		serviceClasses["ipfs_api_"+member.ID] = types.ServiceClassIPFS
+	}
+	return serviceClasses
+}
+
 func CheckExists(stackName string) (bool, error) {
 	_, err := os.Stat(filepath.Join(constants.StacksDir, stackName, "stack.json"))
 	switch {
@@ -289,8 +387,13 @@ func (s *StackManager) LoadStack(stackName string) error {
 	}
 	s.Stack = stack
 	s.Stack.StackDir = stackDir
+	if err := s.loadStackSecrets(); err != nil {
+		return err
+	}
 	s.blockchainProvider = s.getBlockchainProvider()
-	s.tokenProviders = s.getITokenProviders()
+	if s.tokenProviders, err = s.getITokenProviders(); err != nil {
+		return err
+	}
 
 	if s.Stack.RequestTimeout > 0 {
 		core.SetRequestTimeout(s.Stack.RequestTimeout)
@@ -310,6 +413,13 @@ func (s *StackManager) LoadStack(stackName string) error {
 		s.Stack.RuntimeDir = s.Stack.StackDir
 	}
 
+	if s.Stack.TLSEnabled {
+		// Trust the stack CA for the requests the CLI makes to the services in the stack
+		if err := core.SetTLSCACert(filepath.Join(s.Stack.InitDir, "config", "tls", "ca.pem")); err != nil {
+			return err
+		}
+	}
+
 	for _, member := range stack.Members {
 		if member.Account != nil {
 			member.Account = s.blockchainProvider.ParseAccount(member.Account)
@@ -355,6 +465,10 @@ func (s *StackManager) LoadStack(stackName string) error {
 		}
 	}
 
+	if err := s.loadAdditionalBlockchains(); err != nil {
+		return err
+	}
+
 	stackHasRunBefore, err := s.Stack.HasRunBefore()
 	if err != nil {
 		return nil
@@ -418,10 +532,25 @@ func (s *StackManager) ensureInitDirectories() error {
 		}
 	}
 
+	for _, chain := range s.additionalBlockchains {
+		if err := os.MkdirAll(filepath.Join(chain.stack.InitDir, "config"), 0755); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
 func (s *StackManager) writeDockerCompose(compose *docker.DockerComposeConfig) error {
+	// The resources can be edited in stack.json, so they are checked each time they are written to the compose file
+	for class, resources := range s.Stack.Resources {
+		if _, err := fftypes.FFEnumParseString(s.ctx, types.ServiceClass, class.String()); err != nil {
+			return fmt.Errorf("invalid service class '%s' in the resources of stack.json. Options are: %v", class, fftypes.FFEnumValues(types.ServiceClass))
+		}
+		if err := resources.Validate(); err != nil {
+			return fmt.Errorf("invalid resources for service class '%s' in stack.json: %s", class, err)
+		}
+	}
 	comments := "# This file is generated - DO NOT EDIT!\n# To override config, edit docker-compose.override.yml\n"
 	bytes := []byte(comments)
 	yamlBytes, err := yaml.Marshal(compose)
@@ -456,16 +585,41 @@ func (s *StackManager) writeConfig(options *types.InitOptions) error {
 		return err
 	}
 
-	for _, member := range s.Stack.Members {
+	if s.Stack.TLSEnabled {
+		if err := s.writeTLSCerts(); err != nil {
+			return err
+		}
+	}
+
+	if s.Stack.AuthMode.Equals(types.AuthModeBasic) {
+		if err := s.writeBasicAuthFiles(); err != nil {
+			return err
+		}
+	}
+
+	for i, member := range s.Stack.Members {
 		config := core.NewFireflyConfig(s.Stack, member)
 
-		// TODO: This code assumes that there is only one plugin instance per type. When we add support for
-		// multiple namespaces, this code will likely have to change a lot
+		// There is only one plugin instance per type, which is shared by all of the namespaces in the stack
 		blockchainConfig := s.blockchainProvider.GetBlockchainPluginConfig(s.Stack, member)
 		blockchainConfig.Name = "blockchain0"
+		if blockchainConfig.Ethereum != nil && blockchainConfig.Ethereum.Ethconnect != nil {
+			blockchainConfig.Ethereum.Ethconnect.Auth = s.Stack.MemberBasicAuth(member)
+			blockchainConfig.Ethereum.Ethconnect.TLS = s.Stack.ClientTLSConfig(core.TLSCertsDir(s.Stack, member))
+		}
 		config.Plugins.Blockchain = []*types.BlockchainConfig{
 			blockchainConfig,
 		}
+		for _, chain := range s.additionalBlockchains {
+			chainMember := findMember(chain.stack, member.ID)
+			chainConfig := chain.provider.GetBlockchainPluginConfig(chain.stack, chainMember)
+			chainConfig.Name = chain.stack.BlockchainPluginName()
+			if chainConfig.Ethereum != nil && chainConfig.Ethereum.Ethconnect != nil {
+				chainConfig.Ethereum.Ethconnect.Auth = s.Stack.MemberBasicAuth(member)
+				chainConfig.Ethereum.Ethconnect.TLS = s.Stack.ClientTLSConfig(core.TLSCertsDir(s.Stack, member))
+			}
+			config.Plugins.Blockchain = append(config.Plugins.Blockchain, chainConfig)
+		}
 
 		if config.Plugins.Tokens == nil {
 			config.Plugins.Tokens = []*types.TokensConfig{}
@@ -474,11 +628,14 @@ func (s *StackManager) writeConfig(options *types.InitOptions) error {
 		for iTok, tp := range s.tokenProviders {
 			tokenConfig := tp.GetFireflyConfig(member, iTok)
 			tokenConfig.Name = tp.GetName()
+			if tokenConfig.FFTokens != nil {
+				tokenConfig.FFTokens.TLS = s.Stack.ClientTLSConfig(core.TLSCertsDir(s.Stack, member))
+			}
 			config.Plugins.Tokens = append(config.Plugins.Tokens, tokenConfig)
 		}
 
 		coreConfigFilename := filepath.Join(s.Stack.InitDir, "config", fmt.Sprintf("firefly_core_%s.yml", member.ID))
-		if err := core.WriteFireflyConfig(config, coreConfigFilename, options.ExtraCoreConfigPath); err != nil {
+		if err := core.WriteFireflyConfig(config, coreConfigFilename, options.CoreConfigPaths(i)...); err != nil {
 			return err
 		}
 	}
@@ -486,6 +643,9 @@ func (s *StackManager) writeConfig(options *types.InitOptions) error {
 	if err := s.writeStackConfig(); err != nil {
 		return err
 	}
+	if err := s.writeStackSecrets(); err != nil {
+		return err
+	}
 	if err := s.writeStackStateJSON(s.Stack.InitDir); err != nil {
 		return err
 	}
@@ -493,6 +653,11 @@ func (s *StackManager) writeConfig(options *types.InitOptions) error {
 	if err := s.blockchainProvider.WriteConfig(options); err != nil {
 		return err
 	}
+	for _, chain := range s.additionalBlockchains {
+		if err := chain.provider.WriteConfig(additionalBlockchainInitOptions(options, chain.stack)); err != nil {
+			return err
+		}
+	}
 
 	if s.Stack.PrometheusEnabled {
 		promConfig := s.GeneratePrometheusConfig()
@@ -503,6 +668,31 @@ func (s *StackManager) writeConfig(options *types.InitOptions) error {
 		if err := os.WriteFile(path.Join(s.Stack.InitDir, "config", "prometheus.yml"), configBytes, 0755); err != nil {
 			return err
 		}
+		rulesBytes, err := yaml.Marshal(s.GeneratePrometheusAlertingRules())
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(path.Join(s.Stack.InitDir, "config", "alerts.yml"), rulesBytes, 0755); err != nil {
+			return err
+		}
+	}
+
+	if s.Stack.GrafanaEnabled {
+		if err := s.writeGrafanaConfig(); err != nil {
+			return err
+		}
+	}
+
+	if s.Stack.BesuTracingEnabled {
+		if err := s.writeOTelCollectorConfig(); err != nil {
+			return err
+		}
+	}
+
+	if s.Stack.LogAggregation.Equals(types.LogAggregationLoki) {
+		if err := s.writePromtailConfig(); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -582,6 +772,10 @@ func (s *StackManager) createMember(id string, index int, options *types.InitOpt
 		OrgName:                    options.OrgNames[index],
 		NodeName:                   options.NodeNames[index],
 	}
+	if options.NodePerMember {
+		// Each member's blockchain node takes the port of the shared node in its range
+		member.ExposedBlockchainPort = serviceBase
+	}
 
 	nextPort := serviceBase + 5
 	member.ExposedDataexchangePort = serviceBase + nextPort
@@ -597,7 +791,7 @@ func (s *StackManager) createMember(id string, index int, options *types.InitOpt
 		member.ExposedConnectorMetricsPort = nextPort
 		nextPort++
 	}
-	for range options.TokenProviders {
+	for range s.Stack.TokenProviders {
 		member.ExposedTokensPorts = append(member.ExposedTokensPorts, nextPort)
 		nextPort++
 	}
@@ -686,8 +880,29 @@ func (s *StackManager) PullStack(options *types.PullOptions) error {
 		images = append(images, constants.SandboxImageName)
 	}
 
-	// Iterate over all images used by the blockchain provider
-	for _, service := range s.blockchainProvider.GetDockerServiceDefinitions() {
+	// Also pull Prometheus and its exporters if we're using it
+	if s.Stack.PrometheusEnabled {
+		images = append(images, constants.PrometheusImageName, constants.BlackboxExporterImageName)
+		if s.Stack.Database.Equals(types.DatabaseSelectionPostgres) {
+			images = append(images, constants.PostgresExporterImageName)
+		}
+		if s.Stack.GrafanaEnabled {
+			images = append(images, constants.GrafanaImageName)
+		}
+	}
+
+	// Also pull the OpenTelemetry collector and Jaeger if tracing is enabled
+	if s.Stack.BesuTracingEnabled {
+		images = append(images, constants.OTelCollectorImageName, constants.JaegerImageName)
+	}
+
+	// Also pull Loki and Promtail if the logs are aggregated
+	if s.Stack.LogAggregation.Equals(types.LogAggregationLoki) {
+		images = append(images, constants.LokiImageName, constants.PromtailImageName)
+	}
+
+	// Iterate over all images used by the blockchain providers
+	for _, service := range s.blockchainServiceDefinitions() {
 		if !manifestImages[service.Service.Image] {
 			images = append(images, service.Service.Image)
 		}
@@ -723,7 +938,7 @@ func (s *StackManager) PullStack(options *types.PullOptions) error {
 
 func (s *StackManager) removeVolumes() error {
 	var volumes []string
-	for _, service := range s.blockchainProvider.GetDockerServiceDefinitions() {
+	for _, service := range s.blockchainServiceDefinitions() {
 		volumes = append(volumes, service.VolumeNames...)
 	}
 	for iTok, tp := range s.tokenProviders {
@@ -748,6 +963,11 @@ func (s *StackManager) runStartupSequence(firstTimeSetup bool) error {
 	if err := s.blockchainProvider.PreStart(); err != nil {
 		return err
 	}
+	for _, chain := range s.additionalBlockchains {
+		if err := chain.provider.PreStart(); err != nil {
+			return err
+		}
+	}
 
 	s.Log.Info("starting FireFly dependencies")
 	if err := s.runDockerComposeCommand("up", "-d"); err != nil {
@@ -757,6 +977,11 @@ func (s *StackManager) runStartupSequence(firstTimeSetup bool) error {
 	if err := s.blockchainProvider.PostStart(firstTimeSetup); err != nil {
 		return err
 	}
+	for _, chain := range s.additionalBlockchains {
+		if err := chain.provider.PostStart(firstTimeSetup); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -775,6 +1000,11 @@ func (s *StackManager) ResetStack() error {
 	if err := s.blockchainProvider.Reset(); err != nil {
 		return err
 	}
+	for _, chain := range s.additionalBlockchains {
+		if err := chain.provider.Reset(); err != nil {
+			return err
+		}
+	}
 	if err := s.removeVolumes(); err != nil {
 		return err
 	}
@@ -792,10 +1022,27 @@ func (s *StackManager) RemoveStack() error {
 }
 
 func (s *StackManager) checkPortsAvailable() error {
+	for _, port := range s.stackPorts() {
+		available, err := checkPortAvailable(port)
+		if err != nil {
+			return err
+		}
+		if !available {
+			return fmt.Errorf("port %d is unavailable. please check to see if another process is listening on that port", port)
+		}
+	}
+	return nil
+}
+
+// stackPorts returns every port the stack exposes on the host
+func (s *StackManager) stackPorts() []int {
 	ports := make([]int, 1)
 	ports[0] = s.Stack.ExposedBlockchainPort
 	for _, member := range s.Stack.Members {
 
+		if member.ExposedBlockchainPort != 0 && member.ExposedBlockchainPort != s.Stack.ExposedBlockchainPort {
+			ports = append(ports, member.ExposedBlockchainPort)
+		}
 		ports = append(ports, member.ExposedConnectorPort)
 		ports = append(ports, member.ExposedDatabasePort)
 		ports = append(ports, member.ExposedUIPort)
@@ -815,20 +1062,32 @@ func (s *StackManager) checkPortsAvailable() error {
 		}
 	}
 
+	for _, chain := range s.additionalBlockchains {
+		ports = append(ports, chain.stack.ExposedBlockchainPort)
+		for _, member := range chain.stack.Members {
+			ports = append(ports, member.ExposedConnectorPort)
+			if member.ExposedConnectorMetricsPort != 0 {
+				ports = append(ports, member.ExposedConnectorMetricsPort)
+			}
+		}
+	}
+
 	if s.Stack.PrometheusEnabled {
 		ports = append(ports, s.Stack.ExposedPrometheusPort)
 	}
 
-	for _, port := range ports {
-		available, err := checkPortAvailable(port)
-		if err != nil {
-			return err
-		}
-		if !available {
-			return fmt.Errorf("port %d is unavailable. please check to see if another process is listening on that port", port)
-		}
+	if s.Stack.GrafanaEnabled {
+		ports = append(ports, s.Stack.ExposedGrafanaPort)
 	}
-	return nil
+
+	if s.Stack.BesuTracingEnabled {
+		ports = append(ports, s.Stack.ExposedJaegerPort)
+	}
+
+	if s.Stack.LogAggregation.Equals(types.LogAggregationLoki) {
+		ports = append(ports, s.Stack.ExposedLokiPort)
+	}
+	return ports
 }
 
 func checkPortAvailable(port int) (bool, error) {
@@ -891,13 +1150,48 @@ func (s *StackManager) runFirstTimeSetup(options *types.StartOptions) (messages
 	if err := s.blockchainProvider.FirstTimeSetup(); err != nil {
 		return messages, err
 	}
+	for _, chain := range s.additionalBlockchains {
+		s.Log.Info(fmt.Sprintf("initializing %s", chain.stack.BlockchainPluginName()))
+		if err := chain.provider.FirstTimeSetup(); err != nil {
+			return messages, err
+		}
+	}
 
 	if s.Stack.PrometheusEnabled {
-		s.Log.Info("copying prometheus.yml to prometheus_config")
+		s.Log.Info("copying prometheus.yml and alerts.yml to prometheus_config")
 		volumeName := fmt.Sprintf("%s_prometheus_config", s.Stack.Name)
 		if err := docker.CopyFileToVolume(s.ctx, volumeName, path.Join(configDir, "prometheus.yml"), "/prometheus.yml"); err != nil {
 			return messages, err
 		}
+		if err := docker.CopyFileToVolume(s.ctx, volumeName, path.Join(configDir, "alerts.yml"), "/alerts.yml"); err != nil {
+			return messages, err
+		}
+	}
+
+	if s.Stack.GrafanaEnabled {
+		s.Log.Info("copying grafana datasource and dashboards to grafana_provisioning")
+		volumeName := fmt.Sprintf("%s_grafana_provisioning", s.Stack.Name)
+		for _, dir := range []string{"datasources", "dashboards"} {
+			if err := docker.CopyFileToVolume(s.ctx, volumeName, path.Join(configDir, "grafana", dir), "/"+dir); err != nil {
+				return messages, err
+			}
+		}
+	}
+
+	if s.Stack.BesuTracingEnabled {
+		s.Log.Info("copying otel_collector.yaml to otel_collector_config")
+		volumeName := fmt.Sprintf("%s_otel_collector_config", s.Stack.Name)
+		if err := docker.CopyFileToVolume(s.ctx, volumeName, path.Join(configDir, "otel_collector.yaml"), "/config.yaml"); err != nil {
+			return messages, err
+		}
+	}
+
+	if s.Stack.LogAggregation.Equals(types.LogAggregationLoki) {
+		s.Log.Info("copying promtail.yaml to promtail_config")
+		volumeName := fmt.Sprintf("%s_promtail_config", s.Stack.Name)
+		if err := docker.CopyFileToVolume(s.ctx, volumeName, path.Join(configDir, "promtail.yaml"), "/config.yml"); err != nil {
+			return messages, err
+		}
 	}
 
 	if err := s.copyDataExchangeConfigToVolumes(); err != nil {
@@ -930,71 +1224,63 @@ func (s *StackManager) runFirstTimeSetup(options *types.StartOptions) (messages
 		}
 	}
 
-	newConfig := &types.FireflyConfig{
-		Namespaces: &types.NamespacesConfig{
-			Default: "default",
-			Predefined: []*types.Namespace{
-				{
-					Name:        "default",
-					Description: "Default predefined namespace",
-					Plugins:     []string{"database0", "blockchain0", "dataexchange0", "sharedstorage0"},
-				},
-			},
-		},
-	}
-
-	newConfig.Namespaces.Predefined[0].Plugins = append(newConfig.Namespaces.Predefined[0].Plugins, types.FFEnumArrayToStrings(s.Stack.TokenProviders)...)
-
-	var contractDeploymentResult *types.ContractDeploymentResult
+	var contractLocation interface{}
 	if s.Stack.MultipartyEnabled {
-		if s.Stack.ContractAddress == "" {
-			// TODO: This code assumes that there is only one plugin instance per type. When we add support for
-			// multiple namespaces, this code will likely have to change a lot
+		if s.Stack.ContractAddress != "" {
+			contractLocation = map[string]interface{}{
+				"address": s.Stack.ContractAddress,
+			}
+		} else {
 			s.Log.Info("deploying FireFly smart contracts")
-			contractDeploymentResult, err = s.blockchainProvider.DeployFireFlyContract()
+			var message string
+			contractLocation, message, err = s.deployFireFlyContract(0)
 			if err != nil {
 				return messages, err
 			}
-			if contractDeploymentResult != nil {
-				if contractDeploymentResult.Message != "" {
-					messages = append(messages, contractDeploymentResult.Message)
-				}
-				s.Stack.State.DeployedContracts = append(s.Stack.State.DeployedContracts, contractDeploymentResult.DeployedContract)
+			if message != "" {
+				messages = append(messages, message)
 			}
 		}
 	}
 
-	for _, member := range s.Stack.Members {
-		orgConfig := s.blockchainProvider.GetOrgConfig(s.Stack, member)
-		newConfig.Namespaces.Predefined[0].DefaultKey = orgConfig.Key
-		if s.Stack.MultipartyEnabled {
-			var contractLocation interface{}
-			if s.Stack.ContractAddress != "" {
-				contractLocation = map[string]interface{}{
-					"address": s.Stack.ContractAddress,
-				}
-			} else {
-				contractLocation = contractDeploymentResult.DeployedContract.Location
+	// Each namespace added with "ff namespaces add" gets its own instance of the FireFly contract
+	namespaceContractLocations := make(map[string]interface{})
+	for _, namespace := range s.Stack.Namespaces {
+		if namespace.Multiparty {
+			s.Log.Info(fmt.Sprintf("deploying FireFly smart contracts for namespace '%s'", namespace.Name))
+			location, message, err := s.deployFireFlyContract(namespace.Blockchain)
+			if err != nil {
+				return messages, err
 			}
-			options := make(map[string]interface{})
-			if s.Stack.CustomPinSupport {
-				options["customPinSupport"] = true
+			if message != "" {
+				messages = append(messages, message)
 			}
+			namespaceContractLocations[namespace.Name] = location
+		}
+	}
 
-			newConfig.Namespaces.Predefined[0].Multiparty = &types.MultipartyConfig{
-				Enabled: true,
-				Org:     orgConfig,
-				Node: &types.NodeConfig{
-					Name: member.NodeName,
-				},
-				Contract: []*types.ContractConfig{
-					{
-						Location:   contractLocation,
-						FirstEvent: "0",
-						Options:    options,
-					},
-				},
+	defaultStackNamespace := &types.StackNamespace{
+		Name:           defaultNamespace,
+		Multiparty:     s.Stack.MultipartyEnabled,
+		TokenProviders: types.FFEnumArrayToStrings(s.Stack.TokenProviders),
+	}
+	for _, member := range s.Stack.Members {
+		defaultNamespaceConfig, err := s.namespaceConfig(member, defaultStackNamespace, "Default predefined namespace", contractLocation)
+		if err != nil {
+			return messages, err
+		}
+		newConfig := &types.FireflyConfig{
+			Namespaces: &types.NamespacesConfig{
+				Default:    defaultNamespace,
+				Predefined: []*types.Namespace{defaultNamespaceConfig},
+			},
+		}
+		for _, namespace := range s.Stack.Namespaces {
+			namespaceConfig, err := s.namespaceConfig(member, namespace, "", namespaceContractLocations[namespace.Name])
+			if err != nil {
+				return messages, err
 			}
+			newConfig.Namespaces.Predefined = append(newConfig.Namespaces.Predefined, namespaceConfig)
 		}
 
 		if err := s.patchFireFlyCoreConfigs(configDir, member, newConfig); err != nil {
@@ -1031,10 +1317,19 @@ func (s *StackManager) runFirstTimeSetup(options *types.StartOptions) (messages
 		return messages, err
 	}
 
+	for _, namespace := range s.Stack.Namespaces {
+		if namespace.Multiparty {
+			s.Log.Info(fmt.Sprintf("registering FireFly identities in namespace '%s'", namespace.Name))
+			if err := s.registerFireflyIdentities(namespace.Name); err != nil {
+				return messages, err
+			}
+		}
+	}
+
 	if s.Stack.MultipartyEnabled {
 		if s.Stack.ContractAddress == "" {
 			s.Log.Info("registering FireFly identities")
-			if err := s.registerFireflyIdentities(); err != nil {
+			if err := s.registerFireflyIdentities(defaultNamespace); err != nil {
 				return messages, err
 			}
 		} else {
@@ -1341,45 +1636,30 @@ func (s *StackManager) getBlockchainProvider() blockchain.IBlockchainProvider {
 
 	s.Stack.DisableTokenFactories = false
 
-	switch s.Stack.BlockchainProvider {
-	case types.BlockchainProviderEthereum:
-		switch s.Stack.BlockchainNodeProvider {
-		case types.BlockchainNodeProviderGeth:
-			return geth.NewGethProvider(s.ctx, s.Stack)
-		case types.BlockchainNodeProviderBesu:
-			return besu.NewBesuProvider(s.ctx, s.Stack)
-		case types.BlockchainNodeProviderQuorum:
-			return quorum.NewQuorumProvider(s.ctx, s.Stack)
-		case types.BlockchainNodeProviderRemoteRPC:
-			s.Stack.DisableTokenFactories = true
-			return ethremoterpc.NewRemoteRPCProvider(s.ctx, s.Stack)
-		default:
-			return nil
-		}
-	case types.BlockchainProviderCardano:
-		s.Stack.DisableTokenFactories = true
-		return cardanoremoterpc.NewRemoteRPCProvider(s.ctx, s.Stack)
-	case types.BlockchainProviderTezos:
-		s.Stack.DisableTokenFactories = true
-		return tezosremoterpc.NewRemoteRPCProvider(s.ctx, s.Stack)
-	case types.BlockchainProviderFabric:
-		s.Stack.DisableTokenFactories = true
-		return fabric.NewFabricProvider(s.ctx, s.Stack)
-	}
-	return nil
+	return s.newBlockchainProvider(s.Stack)
 }
 
-func (s *StackManager) getITokenProviders() []tokens.ITokensProvider {
-	tps := make([]tokens.ITokensProvider, len(s.Stack.TokenProviders))
-	for i, tp := range s.Stack.TokenProviders {
-		switch tp {
-		case types.TokenProviderERC1155:
-			tps[i] = erc1155.NewERC1155Provider(s.ctx, s.Stack, s.getBlockchainProvider())
-		case types.TokenProviderERC20ERC721:
-			tps[i] = erc20erc721.NewERC20ERC721Provider(s.ctx, s.Stack, s.getBlockchainProvider())
-		default:
-			return nil
+// newBlockchainProvider creates the provider for the blockchain of a stack, or of a copy of the stack for
+// one of its additional blockchains
+func (s *StackManager) newBlockchainProvider(stack *types.Stack) blockchain.IBlockchainProvider {
+	return blockchain.NewProvider(s.ctx, stack)
+}
+
+func (s *StackManager) getITokenProviders() ([]tokens.ITokensProvider, error) {
+	tps := make([]tokens.ITokensProvider, 0, len(s.Stack.TokenProviders))
+	for _, tp := range s.Stack.TokenProviders {
+		if tp.Equals(types.TokenProviderNone) {
+			continue
+		}
+		if custom := s.Stack.CustomTokenProvider(tp.String()); custom != nil {
+			tps = append(tps, customtokens.NewCustomTokensProvider(s.ctx, s.Stack, s.getBlockchainProvider(), custom))
+			continue
+		}
+		provider, err := tokens.NewProvider(s.ctx, tp.String(), s.Stack, s.getBlockchainProvider())
+		if err != nil {
+			return nil, err
 		}
+		tps = append(tps, provider)
 	}
-	return tps
+	return tps, nil
 }
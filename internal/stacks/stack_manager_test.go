@@ -0,0 +1,193 @@
+package stacks
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/firefly-cli/internal/blockchain"
+	"github.com/hyperledger/firefly-cli/internal/docker"
+	"github.com/hyperledger/firefly-cli/internal/tokens"
+	"github.com/hyperledger/firefly-cli/pkg/types"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockBlockchainProvider implements the parts of a blockchain provider that building the compose file and the
+// namespace config of a stack use
+type mockBlockchainProvider struct {
+	blockchain.IBlockchainProvider
+	connectorName string
+	serviceNames  []string
+}
+
+func (p *mockBlockchainProvider) GetOrgConfig(stack *types.Stack, org *types.Organization) *types.OrgConfig {
+	return &types.OrgConfig{Name: org.OrgName, Key: stack.Name + "_" + org.ID}
+}
+
+func (p *mockBlockchainProvider) GetConnectorName() string {
+	return p.connectorName
+}
+
+func (p *mockBlockchainProvider) GetDockerServiceDefinitions() []*docker.ServiceDefinition {
+	serviceDefinitions := make([]*docker.ServiceDefinition, len(p.serviceNames))
+	for i, serviceName := range p.serviceNames {
+		serviceDefinitions[i] = &docker.ServiceDefinition{ServiceName: serviceName, Service: &docker.Service{}}
+	}
+	return serviceDefinitions
+}
+
+// mockTokensProvider implements the parts of a token provider that building the compose file of a stack uses
+type mockTokensProvider struct {
+	tokens.ITokensProvider
+	stack *types.Stack
+	port  int
+}
+
+func (p *mockTokensProvider) GetPort() int {
+	return p.port
+}
+
+func (p *mockTokensProvider) GetDockerServiceDefinitions(tokenIdx int) []*docker.ServiceDefinition {
+	serviceDefinitions := make([]*docker.ServiceDefinition, len(p.stack.Members))
+	for i, member := range p.stack.Members {
+		serviceDefinitions[i] = &docker.ServiceDefinition{ServiceName: fmt.Sprintf("tokens_%s_%d", member.ID, tokenIdx), Service: &docker.Service{}}
+	}
+	return serviceDefinitions
+}
+
+// newTestStackManager returns a stack manager for a two member geth stack with evmconnect and a token provider, with
+// an additional blockchain of the same kind
+func newTestStackManager() *StackManager {
+	manifestEntry := &types.ManifestEntry{}
+	stack := &types.Stack{
+		Name:                  "stack",
+		VersionManifest:       &types.VersionManifest{FireFly: manifestEntry, DataExchange: manifestEntry},
+		BlockchainProvider:    types.BlockchainProviderEthereum,
+		AdditionalBlockchains: []*types.AdditionalBlockchain{{BlockchainProvider: types.BlockchainProviderEthereum}},
+	}
+	for i := 0; i < 2; i++ {
+		index := i
+		stack.Members = append(stack.Members, &types.Organization{
+			ID:                          fmt.Sprint(i),
+			Index:                       &index,
+			OrgName:                     fmt.Sprintf("org_%d", i),
+			ExposedFireflyPort:          5000 + i,
+			ExposedFireflyMetricsPort:   6000 + i,
+			ExposedConnectorMetricsPort: 6100 + i,
+		})
+	}
+	chainStack := stack.AdditionalBlockchainStack(1)
+	provider := &mockBlockchainProvider{connectorName: "evmconnect", serviceNames: []string{"geth", "evmconnect_0", "evmconnect_1"}}
+	return &StackManager{
+		ctx:                   context.Background(),
+		Stack:                 stack,
+		blockchainProvider:    provider,
+		tokenProviders:        []tokens.ITokensProvider{&mockTokensProvider{stack: stack, port: 3000}},
+		additionalBlockchains: []*additionalBlockchain{{stack: chainStack, provider: provider}},
+	}
+}
+
+func TestAdditionalBlockchainStackNodePerMember(t *testing.T) {
+	stack := newTestStackManager().Stack
+	stack.NodePerMember = true
+	stack.GethMode = types.GethModeDev
+	for i, member := range stack.Members {
+		member.ExposedBlockchainPort = 5100 + i*100
+	}
+	chainStack := stack.AdditionalBlockchainStack(1)
+	assert.False(t, chainStack.NodePerMember)
+	assert.Empty(t, chainStack.GethMode)
+	for i, member := range chainStack.Members {
+		assert.Zero(t, member.ExposedBlockchainPort)
+		assert.Equal(t, 5100+i*100, stack.Members[i].ExposedBlockchainPort)
+	}
+}
+
+func TestWriteDockerComposeValidatesResources(t *testing.T) {
+	testCases := []struct {
+		Name      string
+		Resources map[fftypes.FFEnum]*types.ServiceResources
+		Error     string
+	}{
+		{
+			Name:      "valid",
+			Resources: map[fftypes.FFEnum]*types.ServiceResources{types.ServiceClassCore: {CPUs: "1", Memory: "512m", Restart: "always"}},
+		},
+		{
+			Name:      "invalid memory",
+			Resources: map[fftypes.FFEnum]*types.ServiceResources{types.ServiceClassCore: {Memory: "half"}},
+			Error:     "invalid resources for service class 'core' in stack.json: invalid memory 'half'",
+		},
+		{
+			Name:      "invalid restart policy",
+			Resources: map[fftypes.FFEnum]*types.ServiceResources{types.ServiceClassTokens: {Restart: "never"}},
+			Error:     "invalid resources for service class 'tokens' in stack.json: invalid restart policy 'never'",
+		},
+		{
+			Name:      "unknown class",
+			Resources: map[fftypes.FFEnum]*types.ServiceResources{"database": {Memory: "1G"}},
+			Error:     "invalid service class 'database' in the resources of stack.json",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			s := &StackManager{
+				ctx:   context.Background(),
+				Stack: &types.Stack{StackDir: t.TempDir(), Resources: tc.Resources},
+			}
+			err := s.writeDockerCompose(&docker.DockerComposeConfig{})
+			if tc.Error != "" {
+				assert.ErrorContains(t, err, tc.Error)
+				assert.NoFileExists(t, filepath.Join(s.Stack.StackDir, "docker-compose.yml"))
+			} else {
+				assert.NoError(t, err)
+				_, err := os.Stat(filepath.Join(s.Stack.StackDir, "docker-compose.yml"))
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBuildDockerComposeResources(t *testing.T) {
+	s := newTestStackManager()
+	s.Stack.Database = types.DatabaseSelectionPostgres
+	s.Stack.Resources = map[fftypes.FFEnum]*types.ServiceResources{
+		types.ServiceClassCore:       {CPUs: "1", Memory: "512m", Restart: "on-failure"},
+		types.ServiceClassConnector:  {Memory: "256m"},
+		types.ServiceClassBlockchain: {Memory: "1g", Ulimits: map[string]*types.Ulimit{"nofile": {Soft: 1024, Hard: 2048}}},
+		types.ServiceClassIPFS:       {Memory: "128m"},
+		types.ServiceClassPostgres:   {Memory: "64m"},
+		types.ServiceClassTokens:     {Memory: "32m"},
+	}
+	compose := s.buildDockerCompose()
+
+	testCases := []struct {
+		Service string
+		Memory  string
+	}{
+		{Service: "firefly_core_0", Memory: "512m"},
+		{Service: "firefly_core_1", Memory: "512m"},
+		{Service: "evmconnect_0", Memory: "256m"},
+		{Service: "chain1_evmconnect_1", Memory: "256m"},
+		{Service: "geth", Memory: "1g"},
+		{Service: "chain1_geth", Memory: "1g"},
+		{Service: "ipfs_0", Memory: "128m"},
+		{Service: "postgres_1", Memory: "64m"},
+		{Service: "tokens_1_0", Memory: "32m"},
+		{Service: "dataexchange_0"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Service, func(t *testing.T) {
+			assert.Equal(t, tc.Memory, compose.Services[tc.Service].MemLimit)
+		})
+	}
+
+	core := compose.Services["firefly_core_0"]
+	assert.Equal(t, "1", core.CPUs)
+	assert.Equal(t, "on-failure", core.Restart)
+	assert.Empty(t, compose.Services["evmconnect_0"].Restart)
+	assert.Equal(t, &types.Ulimit{Soft: 1024, Hard: 2048}, compose.Services["chain1_geth"].Ulimits["nofile"])
+}
@@ -0,0 +1,192 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stacks
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hyperledger/firefly-cli/internal/tokens"
+	"github.com/hyperledger/firefly-cli/pkg/types"
+)
+
+const tlsCertValidity = 365 * 24 * time.Hour
+
+type tlsKeyPair struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// tlsServiceNames returns the compose service names of every service for a member that serves its API over HTTPS
+// when TLS is enabled, apart from the blockchain connectors. The service name is also the hostname the other
+// containers use to reach it.
+func (s *StackManager) tlsServiceNames(member *types.Organization) []string {
+	serviceNames := []string{
+		fmt.Sprintf("firefly_core_%s", member.ID),
+		fmt.Sprintf("dataexchange_%s", member.ID),
+		fmt.Sprintf("ipfs_api_%s", member.ID),
+	}
+	for i := range s.tokenProviders {
+		serviceNames = append(serviceNames, tokens.TLSProxyName(member, i))
+	}
+	return serviceNames
+}
+
+// writeTLSCerts generates a CA for the stack, and a server certificate signed by that CA for each
+// service with an HTTPS API. Each service gets its own directory containing cert.pem, key.pem and ca.pem
+// so that it can be mounted into the container on its own.
+func (s *StackManager) writeTLSCerts() error {
+	tlsDir := filepath.Join(s.Stack.InitDir, "config", "tls")
+	if err := os.MkdirAll(tlsDir, 0755); err != nil {
+		return err
+	}
+
+	ca, err := generateTLSCA(s.Stack.Name)
+	if err != nil {
+		return err
+	}
+	if err := writeTLSKeyPair(tlsDir, "ca.pem", "ca-key.pem", ca, 0600); err != nil {
+		return err
+	}
+
+	for _, member := range s.Stack.Members {
+		for _, serviceName := range s.tlsServiceNames(member) {
+			if err := writeTLSServiceCert(ca, filepath.Join(tlsDir, serviceName), serviceName); err != nil {
+				return err
+			}
+		}
+	}
+
+	// The connectors of each ethereum blockchain mount their certificates from the init directory of their
+	// blockchain. Their hostname includes the service prefix of the blockchain, if it has one. The connectors of
+	// the other blockchain providers serve plain HTTP.
+	for i := 0; i <= len(s.additionalBlockchains); i++ {
+		chainStack, provider, _ := s.blockchainAt(i)
+		if !chainStack.BlockchainProvider.Equals(types.BlockchainProviderEthereum) {
+			continue
+		}
+		chainTLSDir := filepath.Join(chainStack.InitDir, "config", "tls")
+		for _, member := range chainStack.Members {
+			serviceName := fmt.Sprintf("%s_%s", provider.GetConnectorName(), member.ID)
+			if err := writeTLSServiceCert(ca, filepath.Join(chainTLSDir, serviceName), chainStack.ServiceName(serviceName)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func writeTLSServiceCert(ca *tlsKeyPair, serviceDir, hostname string) error {
+	if err := os.MkdirAll(serviceDir, 0755); err != nil {
+		return err
+	}
+	serverCert, err := generateTLSServerCert(ca, hostname)
+	if err != nil {
+		return err
+	}
+	// The key needs to be readable by the (non-root) users the service containers run as
+	if err := writeTLSKeyPair(serviceDir, "cert.pem", "key.pem", serverCert, 0644); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(serviceDir, "ca.pem"), ca.certPEM, 0644)
+}
+
+func generateTLSCA(stackName string) (*tlsKeyPair, error) {
+	template := &x509.Certificate{
+		Subject: pkix.Name{
+			CommonName:   fmt.Sprintf("%s_ca", stackName),
+			Organization: []string{"FireFly CLI"},
+		},
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	return generateTLSKeyPair(template, nil)
+}
+
+func generateTLSServerCert(ca *tlsKeyPair, serviceName string) (*tlsKeyPair, error) {
+	template := &x509.Certificate{
+		Subject: pkix.Name{
+			CommonName: serviceName,
+		},
+		// Services are reached by their compose service name from other containers, and through the
+		// exposed ports on localhost from the CLI, or via host.docker.internal for external processes
+		DNSNames:    []string{serviceName, "localhost", "host.docker.internal"},
+		IPAddresses: []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	return generateTLSKeyPair(template, ca)
+}
+
+// generateTLSKeyPair creates a new key and a certificate from the template, signed by the parent.
+// The certificate is self-signed if parent is nil.
+func generateTLSKeyPair(template *x509.Certificate, parent *tlsKeyPair) (*tlsKeyPair, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	template.SerialNumber = serialNumber
+	template.NotBefore = time.Now().Add(-1 * time.Hour)
+	template.NotAfter = time.Now().Add(tlsCertValidity)
+
+	parentCert, parentKey := template, key
+	if parent != nil {
+		parentCert, parentKey = parent.cert, parent.key
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, parentCert, &key.PublicKey, parentKey)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(certBytes)
+	if err != nil {
+		return nil, err
+	}
+	keyBytes, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return &tlsKeyPair{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBytes}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes}),
+	}, nil
+}
+
+func writeTLSKeyPair(directory, certFilename, keyFilename string, keyPair *tlsKeyPair, keyPerm os.FileMode) error {
+	if err := os.WriteFile(filepath.Join(directory, certFilename), keyPair.certPEM, 0644); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(directory, keyFilename), keyPair.keyPEM, keyPerm)
+}
@@ -0,0 +1,63 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stacks
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/hyperledger/firefly-cli/internal/blockchain"
+	"github.com/hyperledger/firefly-cli/internal/tokens"
+	"github.com/hyperledger/firefly-cli/internal/tokens/erc1155"
+	"github.com/hyperledger/firefly-cli/internal/tokens/erc20erc721"
+	"github.com/hyperledger/firefly-cli/pkg/types"
+)
+
+// The built in tokens providers are registered here. A stack can also have custom token providers, which are created
+// from the config of the stack rather than the registry.
+func init() {
+	tokens.Register(types.TokenProviderERC1155.String(), func(ctx context.Context, stack *types.Stack, blockchainProvider blockchain.IBlockchainProvider) tokens.ITokensProvider {
+		return erc1155.NewERC1155Provider(ctx, stack, blockchainProvider)
+	})
+	tokens.Register(types.TokenProviderERC20ERC721.String(), func(ctx context.Context, stack *types.Stack, blockchainProvider blockchain.IBlockchainProvider) tokens.ITokensProvider {
+		return erc20erc721.NewERC20ERC721Provider(ctx, stack, blockchainProvider)
+	})
+}
+
+// writeCustomTokenProviders adds the custom token providers to the stack, and copies the contracts file of each one
+// into the init directory so the stack does not depend on the file it was initialized from
+func (s *StackManager) writeCustomTokenProviders(customTokenProviders []*types.CustomTokenProvider) error {
+	for _, provider := range customTokenProviders {
+		stackProvider := *provider
+		if provider.Contracts != "" {
+			contractsBytes, err := os.ReadFile(provider.Contracts)
+			if err != nil {
+				return err
+			}
+			stackProvider.Contracts = filepath.Join("tokens", provider.Name, filepath.Base(provider.Contracts))
+			if err := os.MkdirAll(filepath.Join(s.Stack.InitDir, "tokens", provider.Name), 0755); err != nil {
+				return err
+			}
+			if err := os.WriteFile(filepath.Join(s.Stack.InitDir, stackProvider.Contracts), contractsBytes, 0755); err != nil {
+				return err
+			}
+		}
+		s.Stack.CustomTokenProviders = append(s.Stack.CustomTokenProviders, &stackProvider)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stacks
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// otelCollectorMetricsPort is where the collector serves the metrics it receives over OTLP, for Prometheus to scrape
+const otelCollectorMetricsPort = 8889
+
+type OTelCollectorConfig struct {
+	Receivers  map[string]interface{} `yaml:"receivers"`
+	Processors map[string]interface{} `yaml:"processors"`
+	Exporters  map[string]interface{} `yaml:"exporters"`
+	Service    *OTelCollectorService  `yaml:"service"`
+}
+
+type OTelCollectorService struct {
+	Pipelines map[string]*OTelCollectorPipeline `yaml:"pipelines"`
+}
+
+type OTelCollectorPipeline struct {
+	Receivers  []string `yaml:"receivers"`
+	Processors []string `yaml:"processors,omitempty"`
+	Exporters  []string `yaml:"exporters"`
+}
+
+// GenerateOTelCollectorConfig returns the config of the OpenTelemetry collector, which receives traces over OTLP
+// from the besu nodes in the stack and exports them to Jaeger. Besu also pushes its metrics to the collector, which
+// serves them for Prometheus to scrape.
+func (s *StackManager) GenerateOTelCollectorConfig() *OTelCollectorConfig {
+	return &OTelCollectorConfig{
+		Receivers: map[string]interface{}{
+			"otlp": map[string]interface{}{
+				"protocols": map[string]interface{}{
+					"grpc": map[string]string{"endpoint": "0.0.0.0:4317"},
+					"http": map[string]string{"endpoint": "0.0.0.0:4318"},
+				},
+			},
+		},
+		Processors: map[string]interface{}{
+			"batch": map[string]interface{}{},
+		},
+		Exporters: map[string]interface{}{
+			"otlp/jaeger": map[string]interface{}{
+				"endpoint": "jaeger:4317",
+				"tls":      map[string]bool{"insecure": true},
+			},
+			"prometheus": map[string]interface{}{
+				"endpoint":                         fmt.Sprintf("0.0.0.0:%d", otelCollectorMetricsPort),
+				"resource_to_telemetry_conversion": map[string]bool{"enabled": true},
+			},
+		},
+		Service: &OTelCollectorService{
+			Pipelines: map[string]*OTelCollectorPipeline{
+				"traces": {
+					Receivers:  []string{"otlp"},
+					Processors: []string{"batch"},
+					Exporters:  []string{"otlp/jaeger"},
+				},
+				"metrics": {
+					Receivers:  []string{"otlp"},
+					Processors: []string{"batch"},
+					Exporters:  []string{"prometheus"},
+				},
+			},
+		},
+	}
+}
+
+func (s *StackManager) writeOTelCollectorConfig() error {
+	configBytes, err := yaml.Marshal(s.GenerateOTelCollectorConfig())
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(s.Stack.InitDir, "config", "otel_collector.yaml"), configBytes, 0755)
+}
@@ -0,0 +1,183 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package custom
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/hyperledger/firefly-cli/internal/blockchain"
+	"github.com/hyperledger/firefly-cli/internal/core"
+	"github.com/hyperledger/firefly-cli/internal/docker"
+	"github.com/hyperledger/firefly-cli/internal/log"
+	"github.com/hyperledger/firefly-cli/internal/tokens"
+	"github.com/hyperledger/firefly-cli/pkg/types"
+)
+
+// CustomTokensProvider runs a token connector that implements the fftokens API from an image that is not built in to
+// the CLI, and deploys its factory contract from a compiled contracts file
+type CustomTokensProvider struct {
+	ctx                context.Context
+	stack              *types.Stack
+	blockchainProvider blockchain.IBlockchainProvider
+	config             *types.CustomTokenProvider
+}
+
+func NewCustomTokensProvider(ctx context.Context, stack *types.Stack, blockchainProvider blockchain.IBlockchainProvider, config *types.CustomTokenProvider) *CustomTokensProvider {
+	return &CustomTokensProvider{
+		ctx:                ctx,
+		stack:              stack,
+		blockchainProvider: blockchainProvider,
+		config:             config,
+	}
+}
+
+func (p *CustomTokensProvider) DeploySmartContracts(tokenIndex int) (*types.ContractDeploymentResult, error) {
+	if p.config.Contracts == "" || p.config.FactoryContract == "" {
+		return nil, nil
+	}
+	l := log.LoggerFromContext(p.ctx)
+	l.Info(fmt.Sprintf("deploying %s factory contract", p.config.Name))
+	return p.blockchainProvider.DeployContract(filepath.Join(p.stack.RuntimeDir, p.config.Contracts), p.config.FactoryContract, p.contractName(tokenIndex), p.stack.Members[0], nil)
+}
+
+func (p *CustomTokensProvider) FirstTimeSetup(tokenIdx int) error {
+	l := log.LoggerFromContext(p.ctx)
+	for _, member := range p.stack.Members {
+		l.Info(fmt.Sprintf("initializing %s tokens on member %s", p.config.Name, member.ID))
+		tokenInitURL := fmt.Sprintf("%s://localhost:%d/api/v1/init", p.stack.HTTPScheme(), member.ExposedTokensPorts[tokenIdx])
+		if err := core.RequestWithRetry(p.ctx, "POST", tokenInitURL, nil, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *CustomTokensProvider) GetDockerServiceDefinitions(tokenIdx int) []*docker.ServiceDefinition {
+	factoryAddress := p.factoryAddress(tokenIdx)
+	serviceDefinitions := make([]*docker.ServiceDefinition, 0, len(p.stack.Members))
+	for i, member := range p.stack.Members {
+		connectorName := fmt.Sprintf("tokens_%v_%v", member.ID, tokenIdx)
+
+		vars := map[string]interface{}{
+			"ETHCONNECT_URL":   p.blockchainProvider.GetConnectorURL(member),
+			"ETHCONNECT_TOPIC": connectorName,
+			"AUTO_INIT":        "false",
+		}
+		for k, v := range p.config.Environment {
+			vars[k] = v
+		}
+		env := p.stack.ConcatenateWithProvidedEnvironmentVars(vars)
+
+		if !p.stack.DisableTokenFactories && factoryAddress != "" {
+			env[p.factoryAddressEnv()] = factoryAddress
+		}
+
+		var envFile string
+		if p.stack.AuthMode.Equals(types.AuthModeBasic) {
+			// The token connector reads the credentials to call the blockchain connector from ETHCONNECT_USERNAME and
+			// ETHCONNECT_PASSWORD. Its own API does not check credentials.
+			envFile = docker.AuthEnvFile(p.stack, member)
+		}
+		var healthCheck *docker.HealthCheck
+		if p.config.HealthCheckPath != "" {
+			healthCheck = &docker.HealthCheck{
+				Test: []string{"CMD", "curl", fmt.Sprintf("http://localhost:%d%s", p.port(), p.config.HealthCheckPath)},
+			}
+		}
+		connector := &docker.ServiceDefinition{
+			ServiceName: connectorName,
+			Service: &docker.Service{
+				Image:         p.config.Image,
+				ContainerName: fmt.Sprintf("%s_tokens_%v_%v", p.stack.Name, i, tokenIdx),
+				Ports:         []string{fmt.Sprintf("%d:%d", member.ExposedTokensPorts[tokenIdx], p.port())},
+				Environment:   env,
+				EnvFile:       envFile,
+				DependsOn: map[string]map[string]string{
+					fmt.Sprintf("%s_%s", p.blockchainProvider.GetConnectorName(), member.ID): {"condition": "service_started"},
+				},
+				HealthCheck: healthCheck,
+				Logging:     docker.StandardLogOptions,
+			},
+		}
+		serviceDefinitions = append(serviceDefinitions, tokens.WithTLSProxy(p.stack, connector, member, tokenIdx, p.port())...)
+	}
+	return serviceDefinitions
+}
+
+func (p *CustomTokensProvider) GetFireflyConfig(m *types.Organization, tokenIdx int) *types.TokensConfig {
+	return &types.TokensConfig{
+		Type: "fftokens",
+		Name: p.config.Name,
+		FFTokens: &types.FFTokensConfig{
+			URL: p.getTokensURL(m, tokenIdx),
+		},
+	}
+}
+
+func (p *CustomTokensProvider) getTokensURL(member *types.Organization, tokenIdx int) string {
+	if !member.External {
+		return fmt.Sprintf("%s://%s:%d", p.stack.HTTPScheme(), tokens.ServiceHost(p.stack, member, tokenIdx), p.port())
+	} else {
+		return fmt.Sprintf("%s://127.0.0.1:%v", p.stack.HTTPScheme(), member.ExposedTokensPorts[tokenIdx])
+	}
+}
+
+func (p *CustomTokensProvider) GetName() string {
+	return p.config.Name
+}
+
+func (p *CustomTokensProvider) GetPort() int {
+	return p.port()
+}
+
+// factoryAddress returns the address of the factory contract once it has been deployed, which is in the state of the
+// stack as either a map of strings or, when the state has been read back from disk, a map of interfaces
+func (p *CustomTokensProvider) factoryAddress(tokenIdx int) string {
+	for _, contract := range p.stack.State.DeployedContracts {
+		if contract.Name == p.contractName(tokenIdx) {
+			switch loc := contract.Location.(type) {
+			case map[string]string:
+				return loc["address"]
+			case map[string]interface{}:
+				if address, ok := loc["address"].(string); ok {
+					return address
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func (p *CustomTokensProvider) port() int {
+	if p.config.Port == 0 {
+		return types.DefaultCustomTokenProviderPort
+	}
+	return p.config.Port
+}
+
+func (p *CustomTokensProvider) factoryAddressEnv() string {
+	if p.config.FactoryAddressEnv == "" {
+		return types.DefaultCustomTokenProviderFactoryAddressEnv
+	}
+	return p.config.FactoryAddressEnv
+}
+
+func (p *CustomTokensProvider) contractName(tokenIndex int) string {
+	return fmt.Sprintf("%s_%s_%d", p.config.Name, p.config.FactoryContract, tokenIndex)
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/hyperledger/firefly-cli/internal/core"
 	"github.com/hyperledger/firefly-cli/internal/docker"
 	"github.com/hyperledger/firefly-cli/internal/log"
+	"github.com/hyperledger/firefly-cli/internal/tokens"
 	"github.com/hyperledger/firefly-cli/pkg/types"
 )
 
@@ -72,7 +73,7 @@ func (p *ERC1155Provider) FirstTimeSetup(tokenIdx int) error {
 	l := log.LoggerFromContext(p.ctx)
 	for _, member := range p.stack.Members {
 		l.Info(fmt.Sprintf("initializing tokens on member %s", member.ID))
-		tokenInitURL := fmt.Sprintf("http://localhost:%d/api/v1/init", member.ExposedTokensPorts[tokenIdx])
+		tokenInitURL := fmt.Sprintf("%s://localhost:%d/api/v1/init", p.stack.HTTPScheme(), member.ExposedTokensPorts[tokenIdx])
 		if err := core.RequestWithRetry(p.ctx, "POST", tokenInitURL, nil, nil); err != nil {
 			return err
 		}
@@ -102,13 +103,20 @@ func (p *ERC1155Provider) GetDockerServiceDefinitions(tokenIdx int) []*docker.Se
 			"AUTO_INIT":        "false",
 			"CONTRACT_ADDRESS": contractAddress,
 		})
-		serviceDefinitions = append(serviceDefinitions, &docker.ServiceDefinition{
+		var envFile string
+		if p.stack.AuthMode.Equals(types.AuthModeBasic) {
+			// The token connector reads the credentials to call the blockchain connector from ETHCONNECT_USERNAME and
+			// ETHCONNECT_PASSWORD. Its own API does not check credentials.
+			envFile = docker.AuthEnvFile(p.stack, member)
+		}
+		connector := &docker.ServiceDefinition{
 			ServiceName: connectorName,
 			Service: &docker.Service{
 				Image:         p.stack.VersionManifest.TokensERC1155.GetDockerImageString(),
 				ContainerName: fmt.Sprintf("%s_tokens_%v_%v", p.stack.Name, i, tokenIdx),
 				Ports:         []string{fmt.Sprintf("%d:3000", member.ExposedTokensPorts[tokenIdx])},
 				Environment:   env,
+				EnvFile:       envFile,
 				DependsOn: map[string]map[string]string{
 					fmt.Sprintf("%s_%s", p.blockchainProvider.GetConnectorName(), member.ID): {"condition": "service_started"},
 				},
@@ -117,7 +125,8 @@ func (p *ERC1155Provider) GetDockerServiceDefinitions(tokenIdx int) []*docker.Se
 				},
 				Logging: docker.StandardLogOptions,
 			},
-		})
+		}
+		serviceDefinitions = append(serviceDefinitions, tokens.WithTLSProxy(p.stack, connector, member, tokenIdx, 3000)...)
 	}
 	return serviceDefinitions
 }
@@ -138,12 +147,16 @@ func (p *ERC1155Provider) GetFireflyConfig(m *types.Organization, tokenIdx int)
 
 func (p *ERC1155Provider) getTokensURL(member *types.Organization, tokenIdx int) string {
 	if !member.External {
-		return fmt.Sprintf("http://tokens_%s_%d:3000", member.ID, tokenIdx)
+		return fmt.Sprintf("%s://%s:3000", p.stack.HTTPScheme(), tokens.ServiceHost(p.stack, member, tokenIdx))
 	} else {
-		return fmt.Sprintf("http://127.0.0.1:%v", member.ExposedTokensPorts[tokenIdx])
+		return fmt.Sprintf("%s://127.0.0.1:%v", p.stack.HTTPScheme(), member.ExposedTokensPorts[tokenIdx])
 	}
 }
 
 func (p *ERC1155Provider) GetName() string {
 	return tokenProviderName
 }
+
+func (p *ERC1155Provider) GetPort() int {
+	return 3000
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/hyperledger/firefly-cli/internal/core"
 	"github.com/hyperledger/firefly-cli/internal/docker"
 	"github.com/hyperledger/firefly-cli/internal/log"
+	"github.com/hyperledger/firefly-cli/internal/tokens"
 	"github.com/hyperledger/firefly-cli/pkg/types"
 )
 
@@ -72,7 +73,7 @@ func (p *ERC20ERC721Provider) FirstTimeSetup(tokenIdx int) error {
 	l := log.LoggerFromContext(p.ctx)
 	for _, member := range p.stack.Members {
 		l.Info(fmt.Sprintf("initializing tokens on member %s", member.ID))
-		tokenInitURL := fmt.Sprintf("http://localhost:%d/api/v1/init", member.ExposedTokensPorts[tokenIdx])
+		tokenInitURL := fmt.Sprintf("%s://localhost:%d/api/v1/init", p.stack.HTTPScheme(), member.ExposedTokensPorts[tokenIdx])
 		if err := core.RequestWithRetry(p.ctx, "POST", tokenInitURL, nil, nil); err != nil {
 			return err
 		}
@@ -106,13 +107,20 @@ func (p *ERC20ERC721Provider) GetDockerServiceDefinitions(tokenIdx int) []*docke
 			env["FACTORY_CONTRACT_ADDRESS"] = factoryAddress
 		}
 
-		serviceDefinitions = append(serviceDefinitions, &docker.ServiceDefinition{
+		var envFile string
+		if p.stack.AuthMode.Equals(types.AuthModeBasic) {
+			// The token connector reads the credentials to call the blockchain connector from ETHCONNECT_USERNAME and
+			// ETHCONNECT_PASSWORD. Its own API does not check credentials.
+			envFile = docker.AuthEnvFile(p.stack, member)
+		}
+		connector := &docker.ServiceDefinition{
 			ServiceName: connectorName,
 			Service: &docker.Service{
 				Image:         p.stack.VersionManifest.TokensERC20ERC721.GetDockerImageString(),
 				ContainerName: fmt.Sprintf("%s_tokens_%v_%v", p.stack.Name, i, tokenIdx),
 				Ports:         []string{fmt.Sprintf("%d:3000", member.ExposedTokensPorts[tokenIdx])},
 				Environment:   env,
+				EnvFile:       envFile,
 				DependsOn: map[string]map[string]string{
 					fmt.Sprintf("%s_%s", p.blockchainProvider.GetConnectorName(), member.ID): {"condition": "service_started"},
 				},
@@ -121,7 +129,8 @@ func (p *ERC20ERC721Provider) GetDockerServiceDefinitions(tokenIdx int) []*docke
 				},
 				Logging: docker.StandardLogOptions,
 			},
-		})
+		}
+		serviceDefinitions = append(serviceDefinitions, tokens.WithTLSProxy(p.stack, connector, member, tokenIdx, 3000)...)
 	}
 	return serviceDefinitions
 }
@@ -142,9 +151,9 @@ func (p *ERC20ERC721Provider) GetFireflyConfig(m *types.Organization, tokenIdx i
 
 func (p *ERC20ERC721Provider) getTokensURL(member *types.Organization, tokenIdx int) string {
 	if !member.External {
-		return fmt.Sprintf("http://tokens_%s_%d:3000", member.ID, tokenIdx)
+		return fmt.Sprintf("%s://%s:3000", p.stack.HTTPScheme(), tokens.ServiceHost(p.stack, member, tokenIdx))
 	} else {
-		return fmt.Sprintf("http://127.0.0.1:%v", member.ExposedTokensPorts[tokenIdx])
+		return fmt.Sprintf("%s://127.0.0.1:%v", p.stack.HTTPScheme(), member.ExposedTokensPorts[tokenIdx])
 	}
 }
 
@@ -152,6 +161,10 @@ func (p *ERC20ERC721Provider) GetName() string {
 	return tokenProviderName
 }
 
+func (p *ERC20ERC721Provider) GetPort() int {
+	return 3000
+}
+
 func contractName(tokenIndex int) string {
 	return fmt.Sprintf("erc20erc721_TokenFactory_%d", tokenIndex)
 }
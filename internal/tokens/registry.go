@@ -0,0 +1,68 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokens
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/firefly-cli/internal/blockchain"
+	"github.com/hyperledger/firefly-cli/pkg/types"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+// ProviderFactory creates a tokens provider for a stack, which deploys its contracts with the blockchain provider
+type ProviderFactory func(ctx context.Context, stack *types.Stack, blockchainProvider blockchain.IBlockchainProvider) ITokensProvider
+
+var registry = make(map[string]ProviderFactory)
+
+// Register adds a tokens provider, such as erc1155, which is created for every entry in the token providers of a
+// stack with its name. Its name becomes a valid --token-providers value. Registering a name again replaces the provider.
+func Register(name string, factory ProviderFactory) {
+	name = strings.ToLower(name)
+	if _, err := fftypes.FFEnumParseString(context.Background(), types.TokenProvider, name); err != nil {
+		fftypes.FFEnumValue(types.TokenProvider, name)
+	}
+	registry[name] = factory
+}
+
+// IsRegistered returns true if there is a tokens provider registered with the name
+func IsRegistered(name string) bool {
+	_, ok := registry[strings.ToLower(name)]
+	return ok
+}
+
+// NewProvider creates the registered tokens provider with the name, or returns an error if there is not one
+func NewProvider(ctx context.Context, name string, stack *types.Stack, blockchainProvider blockchain.IBlockchainProvider) (ITokensProvider, error) {
+	factory, ok := registry[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("token provider '%s' is not registered. Options are: %s", name, strings.Join(Providers(), ", "))
+	}
+	return factory(ctx, stack, blockchainProvider), nil
+}
+
+// Providers returns the names of the registered tokens providers
+func Providers() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,36 @@
+package tokens
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/firefly-cli/internal/blockchain"
+	"github.com/hyperledger/firefly-cli/pkg/types"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+	defer delete(registry, "testtokens")
+	var created *types.Stack
+	Register("TestTokens", func(ctx context.Context, stack *types.Stack, blockchainProvider blockchain.IBlockchainProvider) ITokensProvider {
+		created = stack
+		return nil
+	})
+
+	_, err := fftypes.FFEnumParseString(context.Background(), types.TokenProvider, "testtokens")
+	assert.NoError(t, err)
+	assert.True(t, IsRegistered("testtokens"))
+	assert.Contains(t, Providers(), "testtokens")
+
+	stack := &types.Stack{}
+	_, err = NewProvider(context.Background(), "testtokens", stack, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, stack, created)
+}
+
+func TestNewProviderNotRegistered(t *testing.T) {
+	assert.False(t, IsRegistered("unknown"))
+	_, err := NewProvider(context.Background(), "unknown", &types.Stack{}, nil)
+	assert.Regexp(t, "token provider 'unknown' is not registered", err)
+}
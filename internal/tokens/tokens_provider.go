@@ -17,6 +17,10 @@
 package tokens
 
 import (
+	"fmt"
+	"path"
+
+	"github.com/hyperledger/firefly-cli/internal/constants"
 	"github.com/hyperledger/firefly-cli/internal/docker"
 	"github.com/hyperledger/firefly-cli/pkg/types"
 )
@@ -27,4 +31,38 @@ type ITokensProvider interface {
 	GetDockerServiceDefinitions(tokenIdx int) []*docker.ServiceDefinition
 	GetFireflyConfig(m *types.Organization, tokenIdx int) *types.TokensConfig
 	GetName() string
+	// GetPort returns the port the token connector listens on inside its container
+	GetPort() int
+}
+
+// TLSProxyName returns the service name of the TLS terminating proxy in front of a member's token connector
+func TLSProxyName(member *types.Organization, tokenIdx int) string {
+	return fmt.Sprintf("tokens_api_%s_%d", member.ID, tokenIdx)
+}
+
+// ServiceHost returns the host that FireFly core reaches a member's token connector at, which is the TLS
+// terminating proxy in front of it when TLS is enabled
+func ServiceHost(stack *types.Stack, member *types.Organization, tokenIdx int) string {
+	if stack.TLSEnabled {
+		return TLSProxyName(member, tokenIdx)
+	}
+	return fmt.Sprintf("tokens_%s_%d", member.ID, tokenIdx)
+}
+
+// WithTLSProxy puts a TLS terminating proxy in front of a member's token connector when TLS is enabled, as the
+// token connectors cannot serve their API over HTTPS themselves. The proxy takes over the exposed port of the
+// connector, which keeps serving HTTP inside the stack and trusts the stack CA to call the blockchain connector.
+func WithTLSProxy(stack *types.Stack, connector *docker.ServiceDefinition, member *types.Organization, tokenIdx, port int) []*docker.ServiceDefinition {
+	if !stack.TLSEnabled {
+		return []*docker.ServiceDefinition{connector}
+	}
+	proxyName := TLSProxyName(member, tokenIdx)
+	connector.Service.Ports = nil
+	connector.Service.Environment["NODE_EXTRA_CA_CERTS"] = path.Join(constants.TLSCertsDir, "ca.pem")
+	connector.Service.Volumes = append(connector.Service.Volumes, docker.TLSCertsVolume(stack, proxyName))
+	proxy := docker.TLSProxyService(stack, proxyName, connector.ServiceName, port, member.ExposedTokensPorts[tokenIdx])
+	if connector.Service.HealthCheck == nil {
+		proxy.DependsOn[connector.ServiceName] = map[string]string{"condition": "service_started"}
+	}
+	return []*docker.ServiceDefinition{connector, {ServiceName: proxyName, Service: proxy}}
 }
@@ -0,0 +1,59 @@
+package tokens
+
+import (
+	"testing"
+
+	"github.com/hyperledger/firefly-cli/internal/docker"
+	"github.com/hyperledger/firefly-cli/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTLSProxy(t *testing.T) {
+	member := &types.Organization{ID: "0", ExposedTokensPorts: []int{5108}}
+
+	testCases := []struct {
+		Name        string
+		TLSEnabled  bool
+		HealthCheck *docker.HealthCheck
+		Services    []string
+		Host        string
+		Condition   string
+	}{
+		{Name: "no tls", Services: []string{"tokens_0_0"}, Host: "tokens_0_0"},
+		{Name: "tls", TLSEnabled: true, HealthCheck: &docker.HealthCheck{}, Services: []string{"tokens_0_0", "tokens_api_0_0"}, Host: "tokens_api_0_0", Condition: "service_healthy"},
+		{Name: "tls without healthcheck", TLSEnabled: true, Services: []string{"tokens_0_0", "tokens_api_0_0"}, Host: "tokens_api_0_0", Condition: "service_started"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			stack := &types.Stack{Name: "tls", RuntimeDir: "/stacks/tls/runtime", TLSEnabled: tc.TLSEnabled}
+			connector := &docker.ServiceDefinition{
+				ServiceName: "tokens_0_0",
+				Service: &docker.Service{
+					Ports:       []string{"5108:3000"},
+					Environment: map[string]interface{}{},
+					HealthCheck: tc.HealthCheck,
+				},
+			}
+
+			services := WithTLSProxy(stack, connector, member, 0, 3000)
+			names := []string{}
+			for _, service := range services {
+				names = append(names, service.ServiceName)
+			}
+			assert.Equal(t, tc.Services, names)
+			assert.Equal(t, tc.Host, ServiceHost(stack, member, 0))
+			if !tc.TLSEnabled {
+				assert.Equal(t, []string{"5108:3000"}, connector.Service.Ports)
+				return
+			}
+
+			assert.Empty(t, connector.Service.Ports)
+			assert.Equal(t, "/etc/firefly/tls/ca.pem", connector.Service.Environment["NODE_EXTRA_CA_CERTS"])
+			proxy := services[1].Service
+			assert.Equal(t, []string{"5108:3000"}, proxy.Ports)
+			assert.Equal(t, []string{"/stacks/tls/runtime/config/tls/tokens_api_0_0:/etc/firefly/tls:ro"}, proxy.Volumes)
+			assert.Contains(t, proxy.Command, "--target tokens_0_0:3000")
+			assert.Equal(t, tc.Condition, proxy.DependsOn["tokens_0_0"]["condition"])
+		})
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+// MaxAdditionalBlockchains is the number of blockchains that can be added to a stack alongside the first one,
+// which is limited by the ports available to each member
+const MaxAdditionalBlockchains = 10
+
+// AdditionalBlockchain is a blockchain in a stack alongside the one the stack was created with. Each blockchain
+// is a separate blockchain plugin in FireFly core, named after its position in the stack (blockchain1, blockchain2...)
+type AdditionalBlockchain struct {
+	BlockchainProvider     fftypes.FFEnum                `json:"blockchainProvider"`
+	BlockchainConnector    fftypes.FFEnum                `json:"blockchainConnector"`
+	BlockchainNodeProvider fftypes.FFEnum                `json:"blockchainNodeProvider"`
+	ChainIDPtr             *int64                        `json:"chainID,omitempty"`
+	RemoteNodeURL          string                        `json:"remoteNodeURL,omitempty"`
+	ExposedBlockchainPort  int                           `json:"exposedBlockchainPort,omitempty"`
+	Members                []*AdditionalBlockchainMember `json:"members"`
+}
+
+// AdditionalBlockchainMember holds the account and connector ports of a member on an additional blockchain
+type AdditionalBlockchainMember struct {
+	Account                     interface{} `json:"account,omitempty"`
+	ExposedConnectorPort        int         `json:"exposedConnectorPort,omitempty"`
+	ExposedConnectorMetricsPort int         `json:"exposedConnectorMetricsPort,omitempty"`
+}
+
+// AdditionalBlockchainStack returns a copy of the stack for the additional blockchain at the given index (starting at 1),
+// which the blockchain and connector providers can use in the same way as the stack itself. The copy has its own
+// init and runtime directories, and its members have the accounts and connector ports for that blockchain.
+//
+// If an earlier blockchain in the stack uses the same blockchain provider, the services of this one are prefixed
+// with "chain<index>_" so that they do not clash. The name of the copy includes the prefix too, so that the volume
+// and container names the providers build from it match the prefixed services.
+func (s *Stack) AdditionalBlockchainStack(index int) *Stack {
+	chain := s.AdditionalBlockchains[index-1]
+	chainStack := *s
+	chainStack.parent = s
+	chainStack.BlockchainIndex = index
+	chainStack.BlockchainProvider = chain.BlockchainProvider
+	chainStack.BlockchainConnector = chain.BlockchainConnector
+	chainStack.BlockchainNodeProvider = chain.BlockchainNodeProvider
+	chainStack.ChainIDPtr = chain.ChainIDPtr
+	chainStack.RemoteNodeURL = chain.RemoteNodeURL
+	chainStack.ExposedBlockchainPort = chain.ExposedBlockchainPort
+	// An additional blockchain always runs a single node in the default mode, so it does not take the node per
+	// member settings or the geth mode of the stack, or the node ports of its members
+	chainStack.NodePerMember = false
+	chainStack.GethMode = ""
+	chainStack.AdditionalBlockchains = nil
+	chainStack.Namespaces = nil
+	chainStack.InitDir = filepath.Join(s.InitDir, fmt.Sprintf("chain%d", index))
+	chainStack.RuntimeDir = filepath.Join(s.RuntimeDir, fmt.Sprintf("chain%d", index))
+
+	clashes := s.BlockchainProvider.Equals(chain.BlockchainProvider)
+	for _, earlier := range s.AdditionalBlockchains[:index-1] {
+		clashes = clashes || earlier.BlockchainProvider.Equals(chain.BlockchainProvider)
+	}
+	if clashes {
+		chainStack.ServicePrefix = fmt.Sprintf("chain%d_", index)
+		chainStack.Name = fmt.Sprintf("%s_chain%d", s.Name, index)
+	}
+
+	chainStack.Members = make([]*Organization, len(s.Members))
+	chainStack.State = &StackState{
+		DeployedContracts: make([]*DeployedContract, 0),
+		Accounts:          make([]interface{}, 0, len(s.Members)),
+	}
+	for i, member := range s.Members {
+		chainMember := *member
+		chainMember.Account = nil
+		chainMember.ExposedBlockchainPort = 0
+		if i < len(chain.Members) {
+			chainMember.Account = chain.Members[i].Account
+			chainMember.ExposedConnectorPort = chain.Members[i].ExposedConnectorPort
+			chainMember.ExposedConnectorMetricsPort = chain.Members[i].ExposedConnectorMetricsPort
+		}
+		if chainMember.Account != nil {
+			chainStack.State.Accounts = append(chainStack.State.Accounts, chainMember.Account)
+		}
+		chainStack.Members[i] = &chainMember
+	}
+	return &chainStack
+}
+
+// Root returns the stack that a copy for an additional blockchain was made from, or the stack itself
+func (s *Stack) Root() *Stack {
+	if s.parent != nil {
+		return s.parent
+	}
+	return s
+}
+
+// BlockchainPluginName returns the name of the FireFly core blockchain plugin for the stack's blockchain
+func (s *Stack) BlockchainPluginName() string {
+	return fmt.Sprintf("blockchain%d", s.BlockchainIndex)
+}
+
+// ServiceName returns the compose service name, which is also the hostname, of a service of the stack's blockchain
+func (s *Stack) ServiceName(name string) string {
+	return s.ServicePrefix + name
+}
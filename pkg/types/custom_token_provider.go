@@ -0,0 +1,55 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// CustomTokenProvider is an fftokens token connector that is not built in to the CLI, such as one for a different
+// token standard. It is read from the file that is passed to --custom-token-provider at init.
+type CustomTokenProvider struct {
+	// Name is the name of the token provider in the token providers of the stack, and in the FireFly config
+	Name string `json:"name" yaml:"name"`
+	// Image is the docker image of the token connector
+	Image string `json:"image" yaml:"image"`
+	// Port is the port the token connector listens on inside its container
+	Port int `json:"port,omitempty" yaml:"port,omitempty"`
+	// HealthCheckPath is a path of the token connector API that FireFly waits to respond before it starts. There is
+	// no health check if it is not set.
+	HealthCheckPath string `json:"healthCheckPath,omitempty" yaml:"healthCheckPath,omitempty"`
+	// Environment is added to the environment of every token connector
+	Environment map[string]string `json:"environment,omitempty" yaml:"environment,omitempty"`
+	// Contracts is a compiled contracts JSON file with the factory contract. At init it is the path of the file,
+	// which is copied into the stack, and afterwards it is the path relative to the init directory of the stack.
+	Contracts string `json:"contracts,omitempty" yaml:"contracts,omitempty"`
+	// FactoryContract is the name of the contract in the contracts file that is deployed by the first member
+	FactoryContract string `json:"factoryContract,omitempty" yaml:"factoryContract,omitempty"`
+	// FactoryAddressEnv is the environment variable the address of the deployed factory contract is passed in
+	FactoryAddressEnv string `json:"factoryAddressEnv,omitempty" yaml:"factoryAddressEnv,omitempty"`
+}
+
+const (
+	DefaultCustomTokenProviderPort              = 3000
+	DefaultCustomTokenProviderFactoryAddressEnv = "FACTORY_CONTRACT_ADDRESS"
+)
+
+// CustomTokenProvider returns the custom token provider of the stack with the given name, or nil if there is none
+func (s *Stack) CustomTokenProvider(name string) *CustomTokenProvider {
+	for _, provider := range s.CustomTokenProviders {
+		if provider.Name == name {
+			return provider
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/hyperledger/firefly-common/pkg/fftypes"
+
+const DoctorStatus = "doctor_status"
+
+var (
+	DoctorStatusOK      = fftypes.FFEnumValue(DoctorStatus, "ok")
+	DoctorStatusWarning = fftypes.FFEnumValue(DoctorStatus, "warning")
+	DoctorStatusError   = fftypes.FFEnumValue(DoctorStatus, "error")
+	DoctorStatusSkipped = fftypes.FFEnumValue(DoctorStatus, "skipped")
+)
+
+// DoctorCheck is the finding of one of the checks that ff doctor runs, with what to do about it if it did not pass
+type DoctorCheck struct {
+	Name        string         `json:"name"`
+	Status      fftypes.FFEnum `json:"status"`
+	Message     string         `json:"message"`
+	Remediation string         `json:"remediation,omitempty"`
+}
@@ -20,10 +20,28 @@ type LogConfig struct {
 	Level string `yaml:"level,omitempty"`
 }
 
+type TLSConfig struct {
+	Enabled  bool   `yaml:"enabled,omitempty"`
+	CAFile   string `yaml:"caFile,omitempty"`
+	CertFile string `yaml:"certFile,omitempty"`
+	KeyFile  string `yaml:"keyFile,omitempty"`
+}
+
+type HTTPAuthConfig struct {
+	Type  string           `yaml:"type,omitempty"`
+	Basic *BasicAuthConfig `yaml:"basic,omitempty"`
+}
+
+type BasicAuthConfig struct {
+	PasswordFile string `yaml:"passwordfile,omitempty"`
+}
+
 type HTTPServerConfig struct {
-	Port      int    `yaml:"port,omitempty"`
-	Address   string `yaml:"address,omitempty"`
-	PublicURL string `yaml:"publicURL,omitempty"`
+	Port      int             `yaml:"port,omitempty"`
+	Address   string          `yaml:"address,omitempty"`
+	PublicURL string          `yaml:"publicURL,omitempty"`
+	TLS       *TLSConfig      `yaml:"tls,omitempty"`
+	Auth      *HTTPAuthConfig `yaml:"auth,omitempty"`
 }
 
 type AdminServerConfig struct {
@@ -44,13 +62,14 @@ type MetricsServerConfig struct {
 }
 
 type BasicAuth struct {
-	Username string `yaml:"username,omitempty"`
-	Password string `yaml:"password,omitempty"`
+	Username string `yaml:"username,omitempty" json:"username,omitempty"`
+	Password string `yaml:"password,omitempty" json:"password,omitempty"`
 }
 
 type HTTPEndpointConfig struct {
-	URL  string    `yaml:"url,omitempty"`
-	Auth BasicAuth `yaml:"auth,omitempty"`
+	URL  string     `yaml:"url,omitempty"`
+	Auth BasicAuth  `yaml:"auth,omitempty"`
+	TLS  *TLSConfig `yaml:"tls,omitempty"`
 }
 
 type UIConfig struct {
@@ -75,6 +94,7 @@ type EthconnectConfig struct {
 	URL   string     `yaml:"url,omitempty"`
 	Topic string     `yaml:"topic,omitempty"`
 	Auth  *BasicAuth `yaml:"auth,omitempty"`
+	TLS   *TLSConfig `yaml:"tls,omitempty"`
 }
 
 type TezosconnectConfig struct {
@@ -157,7 +177,8 @@ type TokensConfig struct {
 }
 
 type FFTokensConfig struct {
-	URL string `yaml:"url,omitempty"`
+	URL string     `yaml:"url,omitempty"`
+	TLS *TLSConfig `yaml:"tls,omitempty"`
 }
 
 type DBEventsConfig struct {
@@ -50,3 +50,12 @@ type MultipartyOrgConfig struct {
 	Description string `yaml:"description,omitempty"`
 	Key         string `yaml:"key"`
 }
+
+// StackNamespace is a namespace that was added to a stack with "ff namespaces add", in addition
+// to the default namespace that every stack has
+type StackNamespace struct {
+	Name           string   `json:"name"`
+	Multiparty     bool     `json:"multiparty,omitempty"`
+	TokenProviders []string `json:"tokenProviders,omitempty"`
+	Blockchain     int      `json:"blockchain,omitempty"`
+}
@@ -30,51 +30,169 @@ type StartOptions struct {
 	NoRollback bool
 }
 
+type LogsOptions struct {
+	Follow   bool
+	Members  []int
+	Services []string
+	Since    string
+	Until    string
+	Tail     string
+	Grep     string
+	Level    string
+	Output   string
+	SaveDir  string
+	ANSI     bool
+}
+
+const LogsOutput = "logs_output"
+
+var (
+	LogsOutputText  = fftypes.FFEnumValue(LogsOutput, "text")
+	LogsOutputJSONL = fftypes.FFEnumValue(LogsOutput, "jsonl")
+)
+
+type EventsOptions struct {
+	Member                  int
+	Namespace               string
+	Types                   []string
+	Topic                   string
+	Tag                     string
+	Group                   string
+	Author                  string
+	TransactionType         string
+	BlockchainEventName     string
+	BlockchainEventListener string
+	WithData                bool
+	Output                  string
+}
+
+const EventsOutput = "events_output"
+
+var (
+	EventsOutputText = fftypes.FFEnumValue(EventsOutput, "text")
+	EventsOutputJSON = fftypes.FFEnumValue(EventsOutput, "json")
+)
+
+type APIOptions struct {
+	Member     int
+	Namespace  string
+	SPI        bool
+	Data       string
+	Confirm    bool
+	Tag        string
+	Topics     []string
+	Recipients []string
+	Pool       string
+	Amount     string
+	To         string
+}
+
+type AddNamespaceOptions struct {
+	Multiparty     bool
+	TokenProviders []string
+	Blockchain     int
+}
+
+type AdditionalBlockchainOptions struct {
+	BlockchainProvider     string
+	BlockchainNodeProvider string
+	BlockchainConnector    string
+	ChainID                int64
+	RemoteNodeURL          string
+}
+
 type InitOptions struct {
-	StackName                 string
-	MemberCount               int
-	FireFlyBasePort           int
-	ServicesBasePort          int
-	PtmBasePort               int
-	DatabaseProvider          string
-	ExternalProcesses         int
-	OrgNames                  []string
-	NodeNames                 []string
-	BlockchainConnector       string
-	BlockchainProvider        string
-	BlockchainNodeProvider    string
-	PrivateTransactionManager string
-	Consensus                 string
-	TokenProviders            []string
-	FireFlyVersion            string
-	ManifestPath              string
-	PrometheusEnabled         bool
-	PrometheusPort            int
-	SandboxEnabled            bool
-	ExtraCoreConfigPath       string
-	ExtraConnectorConfigPath  string
-	BlockPeriod               int
-	ContractAddress           string
-	RemoteNodeURL             string
-	ChainID                   int64
-	Network                   string
-	Socket                    string
-	BlockfrostKey             string
-	BlockfrostBaseURL         string
-	DisableTokenFactories     bool
-	RequestTimeout            int
-	ReleaseChannel            string
-	MultipartyEnabled         bool
-	IPFSMode                  string
-	CCPYAMLPaths              []string
-	MSPPaths                  []string
-	ChannelName               string
-	ChaincodeName             string
-	CustomPinSupport          bool
-	RemoteNodeDeploy          bool
-	EnvironmentVars           map[string]string
+	StackName                  string
+	MemberCount                int
+	FireFlyBasePort            int
+	ServicesBasePort           int
+	PtmBasePort                int
+	DatabaseProvider           string
+	ExternalProcesses          int
+	OrgNames                   []string
+	NodeNames                  []string
+	BlockchainConnector        string
+	BlockchainProvider         string
+	BlockchainNodeProvider     string
+	GethMode                   string
+	NodePerMember              bool
+	PrivateTransactionManager  string
+	Consensus                  string
+	TokenProviders             []string
+	CustomTokenProviders       []*CustomTokenProvider
+	FireFlyVersion             string
+	ManifestPath               string
+	PrometheusEnabled          bool
+	PrometheusPort             int
+	GrafanaEnabled             bool
+	GrafanaPort                int
+	BesuTracingEnabled         bool
+	JaegerPort                 int
+	LogAggregation             string
+	LokiPort                   int
+	SandboxEnabled             bool
+	TLSEnabled                 bool
+	AuthMode                   string
+	ResourceProfile            string
+	ServiceResources           map[string]*ServiceResources
+	AdditionalBlockchains      []*AdditionalBlockchainOptions
+	ExtraCoreConfigPath        string
+	ExtraConnectorConfigPath   string
+	MemberCoreConfigPaths      map[int]string
+	MemberConnectorConfigPaths map[int]string
+	BlockPeriod                int
+	ContractAddress            string
+	RemoteNodeURL              string
+	ProviderOptions            map[string]string
+	ChainID                    int64
+	Network                    string
+	Socket                     string
+	BlockfrostKey              string
+	BlockfrostBaseURL          string
+	DisableTokenFactories      bool
+	RequestTimeout             int
+	ReleaseChannel             string
+	MultipartyEnabled          bool
+	IPFSMode                   string
+	CCPYAMLPaths               []string
+	MSPPaths                   []string
+	ChannelName                string
+	ChaincodeName              string
+	CustomPinSupport           bool
+	RemoteNodeDeploy           bool
+	EnvironmentVars            map[string]string
+	ServiceEnvironmentVars     map[string]map[string]string
+}
+
+// CoreConfigPaths returns the extra config files to merge into the FireFly core config of a member, with the
+// config for every member first so that the config for the member itself takes precedence
+func (o *InitOptions) CoreConfigPaths(memberIndex int) []string {
+	return extraConfigPaths(o.ExtraCoreConfigPath, o.MemberCoreConfigPaths[memberIndex])
+}
+
+// ConnectorConfigPaths returns the extra config files to merge into the blockchain connector config of a member,
+// with the config for every member first so that the config for the member itself takes precedence
+func (o *InitOptions) ConnectorConfigPaths(memberIndex int) []string {
+	return extraConfigPaths(o.ExtraConnectorConfigPath, o.MemberConnectorConfigPaths[memberIndex])
+}
+
+func extraConfigPaths(paths ...string) []string {
+	nonEmpty := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if path != "" {
+			nonEmpty = append(nonEmpty, path)
+		}
+	}
+	return nonEmpty
 }
 
+const LogAggregation = "log_aggregation"
+
+var (
+	LogAggregationNone = fftypes.FFEnumValue(LogAggregation, "none")
+	LogAggregationLoki = fftypes.FFEnumValue(LogAggregation, "loki")
+)
+
 const IPFSMode = "ipfs_mode"
 
 var (
@@ -82,6 +200,13 @@ var (
 	IPFSModePublic  = fftypes.FFEnumValue(IPFSMode, "public")
 )
 
+const AuthMode = "auth_mode"
+
+var (
+	AuthModeNone  = fftypes.FFEnumValue(AuthMode, "none")
+	AuthModeBasic = fftypes.FFEnumValue(AuthMode, "basic")
+)
+
 const BlockchainProvider = "blockchain_provider"
 
 var (
@@ -109,6 +234,14 @@ var (
 	BlockchainNodeProviderQuorum    = fftypes.FFEnumValue(BlockchainNodeProvider, "quorum")
 	BlockchainNodeProviderBesu      = fftypes.FFEnumValue(BlockchainNodeProvider, "besu")
 	BlockchainNodeProviderRemoteRPC = fftypes.FFEnumValue(BlockchainNodeProvider, "remote-rpc")
+	BlockchainNodeProviderAnvil     = fftypes.FFEnumValue(BlockchainNodeProvider, "anvil")
+)
+
+const GethMode = "geth_mode"
+
+var (
+	GethModeClique = fftypes.FFEnumValue(GethMode, "clique")
+	GethModeDev    = fftypes.FFEnumValue(GethMode, "dev")
 )
 
 const Consensus = "consensus"
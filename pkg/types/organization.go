@@ -21,6 +21,7 @@ type Organization struct {
 	Index                       *int         `json:"index,omitempty"`
 	Account                     interface{}  `json:"account,omitempty"`
 	ExposedFireflyPort          int          `json:"exposedFireflyPort,omitempty"`
+	ExposedBlockchainPort       int          `json:"exposedBlockchainPort,omitempty"`   // only set when the stack runs a blockchain node per member
 	ExposedFireflyAdminSPIPort  int          `json:"exposedFireflyAdminPort,omitempty"` // stack.json still contains the word "Admin" (rather than SPI) for migration
 	ExposedFireflyMetricsPort   int          `json:"exposedFireflyMetricsPort,omitempty"`
 	ExposedConnectorPort        int          `json:"exposedConnectorPort,omitempty"`
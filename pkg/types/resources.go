@@ -0,0 +1,164 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+// ServiceClass groups the services of a stack that resource limits and restart policies are set for
+const ServiceClass = "service_class"
+
+var (
+	ServiceClassCore       = fftypes.FFEnumValue(ServiceClass, "core")
+	ServiceClassConnector  = fftypes.FFEnumValue(ServiceClass, "connector")
+	ServiceClassBlockchain = fftypes.FFEnumValue(ServiceClass, "blockchain")
+	ServiceClassIPFS       = fftypes.FFEnumValue(ServiceClass, "ipfs")
+	ServiceClassPostgres   = fftypes.FFEnumValue(ServiceClass, "postgres")
+	ServiceClassTokens     = fftypes.FFEnumValue(ServiceClass, "tokens")
+)
+
+const ResourceProfile = "resource_profile"
+
+var (
+	ResourceProfileDefault = fftypes.FFEnumValue(ResourceProfile, "default")
+	ResourceProfileSmall   = fftypes.FFEnumValue(ResourceProfile, "small")
+)
+
+// RestartPolicies are the restart policies docker compose accepts for a service
+var RestartPolicies = []string{"no", "always", "on-failure", "unless-stopped"}
+
+// memoryLimitRegex matches the memory sizes that both docker-compose v1 and docker compose v2 accept, such as 512M or 1.5g
+var memoryLimitRegex = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?([kKmMgG][bB]?|[bB])?$`)
+
+// ServiceResources are the resource limits, restart policy and ulimits for a class of services. They are set by the
+// init flags and stored in the "resources" section of stack.json, keyed by service class, where they can also be edited.
+// They are written to the docker compose file whenever it is generated, which is at init, the first time the stack is
+// started and on upgrade.
+type ServiceResources struct {
+	CPUs    string             `json:"cpus,omitempty"`
+	Memory  string             `json:"memory,omitempty"`
+	Restart string             `json:"restart,omitempty"`
+	Ulimits map[string]*Ulimit `json:"ulimits,omitempty"`
+}
+
+type Ulimit struct {
+	Soft int `json:"soft" yaml:"soft"`
+	Hard int `json:"hard" yaml:"hard"`
+}
+
+// ValidateCPUs checks a CPU limit is a number greater than zero
+func ValidateCPUs(cpus string) error {
+	if v, err := strconv.ParseFloat(cpus, 64); err != nil || v <= 0 {
+		return fmt.Errorf("invalid cpus '%s': must be a number greater than zero", cpus)
+	}
+	return nil
+}
+
+// ValidateMemoryLimit checks a memory limit is a size docker compose accepts
+func ValidateMemoryLimit(memory string) error {
+	if !memoryLimitRegex.MatchString(memory) {
+		return fmt.Errorf("invalid memory '%s': must be a number of bytes with an optional b, k, m or g unit, for example 512m or 1G", memory)
+	}
+	return nil
+}
+
+// ValidateRestartPolicy checks a restart policy is one docker compose accepts, allowing a maximum retry count after
+// on-failure
+func ValidateRestartPolicy(restart string) error {
+	policy, _, _ := strings.Cut(restart, ":")
+	if !slices.Contains(RestartPolicies, policy) {
+		return fmt.Errorf("invalid restart policy '%s'. Options are: %v", restart, RestartPolicies)
+	}
+	return nil
+}
+
+// Validate checks the values that are set are ones docker compose accepts
+func (r *ServiceResources) Validate() error {
+	if r == nil {
+		return nil
+	}
+	if r.CPUs != "" {
+		if err := ValidateCPUs(r.CPUs); err != nil {
+			return err
+		}
+	}
+	if r.Memory != "" {
+		if err := ValidateMemoryLimit(r.Memory); err != nil {
+			return err
+		}
+	}
+	if r.Restart != "" {
+		if err := ValidateRestartPolicy(r.Restart); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Merge returns a copy of the resources with the values that are set in the override replacing them
+func (r *ServiceResources) Merge(override *ServiceResources) *ServiceResources {
+	merged := &ServiceResources{}
+	if r != nil {
+		*merged = *r
+	}
+	if override == nil {
+		return merged
+	}
+	if override.CPUs != "" {
+		merged.CPUs = override.CPUs
+	}
+	if override.Memory != "" {
+		merged.Memory = override.Memory
+	}
+	if override.Restart != "" {
+		merged.Restart = override.Restart
+	}
+	if len(override.Ulimits) > 0 {
+		ulimits := make(map[string]*Ulimit, len(merged.Ulimits)+len(override.Ulimits))
+		for name, ulimit := range merged.Ulimits {
+			ulimits[name] = ulimit
+		}
+		for name, ulimit := range override.Ulimits {
+			ulimits[name] = ulimit
+		}
+		merged.Ulimits = ulimits
+	}
+	return merged
+}
+
+// ResourceProfileResources returns the resources preset for each class of services by a resource profile. The
+// small profile fits a three member stack on a laptop with 16GB of memory, alongside everything else running on it.
+func ResourceProfileResources(profile fftypes.FFEnum) map[fftypes.FFEnum]*ServiceResources {
+	if !profile.Equals(ResourceProfileSmall) {
+		return map[fftypes.FFEnum]*ServiceResources{}
+	}
+	return map[fftypes.FFEnum]*ServiceResources{
+		ServiceClassCore:       {CPUs: "1", Memory: "512M", Restart: "on-failure"},
+		ServiceClassConnector:  {CPUs: "0.5", Memory: "256M", Restart: "on-failure"},
+		ServiceClassBlockchain: {CPUs: "1", Memory: "768M", Restart: "on-failure"},
+		ServiceClassIPFS:       {CPUs: "0.5", Memory: "256M", Restart: "on-failure"},
+		ServiceClassPostgres:   {CPUs: "0.5", Memory: "256M", Restart: "on-failure"},
+		ServiceClassTokens:     {CPUs: "0.5", Memory: "256M", Restart: "on-failure"},
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// Passwords that were hard-coded by CLI versions that did not generate secrets for each stack.
+// Stacks created by those versions keep using them.
+const (
+	legacyPostgresPassword      = "f1refly"
+	legacyKeystorePassword      = "correcthorsebatterystaple"
+	legacyFabricCAAdminPassword = "adminpw"
+)
+
+// StackSecrets holds the credentials generated for a stack. It is stored in secrets.json
+// next to stack.json, and is only readable by the user that created the stack.
+type StackSecrets struct {
+	PostgresPassword      string           `json:"postgresPassword,omitempty"`
+	KeystorePassword      string           `json:"keystorePassword,omitempty"`
+	FabricCAAdminPassword string           `json:"fabricCAAdminPassword,omitempty"`
+	GrafanaAdminPassword  string           `json:"grafanaAdminPassword,omitempty"`
+	Members               []*MemberSecrets `json:"members,omitempty"`
+}
+
+type MemberSecrets struct {
+	ID        string     `json:"id"`
+	BasicAuth *BasicAuth `json:"basicAuth,omitempty"`
+}
+
+func (s *StackSecrets) Member(id string) *MemberSecrets {
+	for _, member := range s.Members {
+		if member.ID == id {
+			return member
+		}
+	}
+	return nil
+}
+
+// SetLegacyDefaults fills in any password that is not set with the value older CLI versions used
+func (s *StackSecrets) SetLegacyDefaults() {
+	if s.PostgresPassword == "" {
+		s.PostgresPassword = legacyPostgresPassword
+	}
+	if s.KeystorePassword == "" {
+		s.KeystorePassword = legacyKeystorePassword
+	}
+	if s.FabricCAAdminPassword == "" {
+		s.FabricCAAdminPassword = legacyFabricCAAdminPassword
+	}
+}
@@ -18,6 +18,7 @@ package types
 
 import (
 	"os"
+	"path"
 	"path/filepath"
 
 	"github.com/hyperledger/firefly-cli/internal/constants"
@@ -25,43 +26,63 @@ import (
 )
 
 type Stack struct {
-	Name                      string                 `json:"name,omitempty"`
-	Members                   []*Organization        `json:"members,omitempty"`
-	SwarmKey                  string                 `json:"swarmKey,omitempty"`
-	ExposedBlockchainPort     int                    `json:"exposedBlockchainPort,omitempty"`
-	ExposedPtmPort            int                    `json:"exposedPtmPort,omitempty"`
-	Database                  fftypes.FFEnum         `json:"database"`
-	BlockchainProvider        fftypes.FFEnum         `json:"blockchainProvider"`
-	BlockchainConnector       fftypes.FFEnum         `json:"blockchainConnector"`
-	BlockchainNodeProvider    fftypes.FFEnum         `json:"blockchainNodeProvider"`
-	PrivateTransactionManager fftypes.FFEnum         `json:"privateTransactionManager"`
-	Consensus                 fftypes.FFEnum         `json:"consensus"`
-	TokenProviders            []fftypes.FFEnum       `json:"tokenProviders"`
-	VersionManifest           *VersionManifest       `json:"versionManifest,omitempty"`
-	PrometheusEnabled         bool                   `json:"prometheusEnabled,omitempty"`
-	SandboxEnabled            bool                   `json:"sandboxEnabled,omitempty"`
-	MultipartyEnabled         bool                   `json:"multiparty"`
-	ExposedPrometheusPort     int                    `json:"exposedPrometheusPort,omitempty"`
-	ContractAddress           string                 `json:"contractAddress,omitempty"`
-	ChainIDPtr                *int64                 `json:"chainID,omitempty"`
-	Network                   string                 `json:"network,omitempty"`
-	Socket                    string                 `json:"socket,omitempty"`
-	BlockfrostKey             string                 `json:"blockfrostKey,omitempty"`
-	BlockfrostBaseURL         string                 `json:"blockfrostBaseURL,omitempty"`
-	RemoteNodeURL             string                 `json:"remoteNodeURL,omitempty"`
-	DisableTokenFactories     bool                   `json:"disableTokenFactories,omitempty"`
-	RequestTimeout            int                    `json:"requestTimeout,omitempty"`
-	IPFSMode                  fftypes.FFEnum         `json:"ipfsMode"`
-	RemoteFabricNetwork       bool                   `json:"remoteFabricNetwork,omitempty"`
-	ChannelName               string                 `json:"channelName,omitempty"`
-	ChaincodeName             string                 `json:"chaincodeName,omitempty"`
-	CustomPinSupport          bool                   `json:"customPinSupport,omitempty"`
-	RemoteNodeDeploy          bool                   `json:"remoteNodeDeploy,omitempty"`
-	EnvironmentVars           map[string]interface{} `json:"environmentVars"`
-	InitDir                   string                 `json:"-"`
-	RuntimeDir                string                 `json:"-"`
-	StackDir                  string                 `json:"-"`
-	State                     *StackState            `json:"-"`
+	Name                      string                               `json:"name,omitempty"`
+	Members                   []*Organization                      `json:"members,omitempty"`
+	SwarmKey                  string                               `json:"swarmKey,omitempty"`
+	ExposedBlockchainPort     int                                  `json:"exposedBlockchainPort,omitempty"`
+	ExposedPtmPort            int                                  `json:"exposedPtmPort,omitempty"`
+	Database                  fftypes.FFEnum                       `json:"database"`
+	BlockchainProvider        fftypes.FFEnum                       `json:"blockchainProvider"`
+	BlockchainConnector       fftypes.FFEnum                       `json:"blockchainConnector"`
+	BlockchainNodeProvider    fftypes.FFEnum                       `json:"blockchainNodeProvider"`
+	GethMode                  fftypes.FFEnum                       `json:"gethMode,omitempty"`
+	NodePerMember             bool                                 `json:"nodePerMember,omitempty"`
+	PrivateTransactionManager fftypes.FFEnum                       `json:"privateTransactionManager"`
+	Consensus                 fftypes.FFEnum                       `json:"consensus"`
+	BlockPeriod               int                                  `json:"blockPeriod,omitempty"`
+	TokenProviders            []fftypes.FFEnum                     `json:"tokenProviders"`
+	CustomTokenProviders      []*CustomTokenProvider               `json:"customTokenProviders,omitempty"`
+	VersionManifest           *VersionManifest                     `json:"versionManifest,omitempty"`
+	PrometheusEnabled         bool                                 `json:"prometheusEnabled,omitempty"`
+	GrafanaEnabled            bool                                 `json:"grafanaEnabled,omitempty"`
+	BesuTracingEnabled        bool                                 `json:"besuTracingEnabled,omitempty"`
+	SandboxEnabled            bool                                 `json:"sandboxEnabled,omitempty"`
+	MultipartyEnabled         bool                                 `json:"multiparty"`
+	ExposedPrometheusPort     int                                  `json:"exposedPrometheusPort,omitempty"`
+	ExposedGrafanaPort        int                                  `json:"exposedGrafanaPort,omitempty"`
+	ExposedJaegerPort         int                                  `json:"exposedJaegerPort,omitempty"`
+	LogAggregation            fftypes.FFEnum                       `json:"logAggregation,omitempty"`
+	ExposedLokiPort           int                                  `json:"exposedLokiPort,omitempty"`
+	ContractAddress           string                               `json:"contractAddress,omitempty"`
+	ChainIDPtr                *int64                               `json:"chainID,omitempty"`
+	Network                   string                               `json:"network,omitempty"`
+	Socket                    string                               `json:"socket,omitempty"`
+	BlockfrostKey             string                               `json:"blockfrostKey,omitempty"`
+	BlockfrostBaseURL         string                               `json:"blockfrostBaseURL,omitempty"`
+	RemoteNodeURL             string                               `json:"remoteNodeURL,omitempty"`
+	DisableTokenFactories     bool                                 `json:"disableTokenFactories,omitempty"`
+	RequestTimeout            int                                  `json:"requestTimeout,omitempty"`
+	IPFSMode                  fftypes.FFEnum                       `json:"ipfsMode"`
+	RemoteFabricNetwork       bool                                 `json:"remoteFabricNetwork,omitempty"`
+	ChannelName               string                               `json:"channelName,omitempty"`
+	ChaincodeName             string                               `json:"chaincodeName,omitempty"`
+	CustomPinSupport          bool                                 `json:"customPinSupport,omitempty"`
+	RemoteNodeDeploy          bool                                 `json:"remoteNodeDeploy,omitempty"`
+	EnvironmentVars           map[string]interface{}               `json:"environmentVars"`
+	ServiceEnvironmentVars    map[string]map[string]interface{}    `json:"serviceEnvironmentVars,omitempty"`
+	TLSEnabled                bool                                 `json:"tlsEnabled,omitempty"`
+	AuthMode                  fftypes.FFEnum                       `json:"authMode,omitempty"`
+	Resources                 map[fftypes.FFEnum]*ServiceResources `json:"resources,omitempty"`
+	Namespaces                []*StackNamespace                    `json:"namespaces,omitempty"`
+	AdditionalBlockchains     []*AdditionalBlockchain              `json:"additionalBlockchains,omitempty"`
+	InitDir                   string                               `json:"-"`
+	RuntimeDir                string                               `json:"-"`
+	StackDir                  string                               `json:"-"`
+	State                     *StackState                          `json:"-"`
+	Secrets                   *StackSecrets                        `json:"-"`
+	BlockchainIndex           int                                  `json:"-"`
+	ServicePrefix             string                               `json:"-"`
+	parent                    *Stack
 }
 
 func (s *Stack) ChainID() int64 {
@@ -72,6 +93,9 @@ func (s *Stack) ChainID() int64 {
 }
 
 func (s *Stack) HasRunBefore() (bool, error) {
+	if s.parent != nil {
+		return s.parent.HasRunBefore()
+	}
 	stackDir := filepath.Join(constants.StacksDir, s.Name)
 	isOldFileStructure, err := s.IsOldFileStructure()
 	if err != nil {
@@ -125,3 +149,105 @@ func (s *Stack) ConcatenateWithProvidedEnvironmentVars(input map[string]interfac
 	}
 	return result
 }
+
+// HTTPScheme returns the URL scheme used to reach the HTTP APIs of the services in this stack
+func (s *Stack) HTTPScheme() string {
+	if s.TLSEnabled {
+		return "https"
+	}
+	return "http"
+}
+
+// ServerTLSConfig returns the TLS settings for a service that serves HTTPS using the certificates in certsDir,
+// or nil if TLS is not enabled for this stack
+func (s *Stack) ServerTLSConfig(certsDir string) *TLSConfig {
+	if !s.TLSEnabled {
+		return nil
+	}
+	return &TLSConfig{
+		Enabled:  true,
+		CAFile:   path.Join(certsDir, "ca.pem"),
+		CertFile: path.Join(certsDir, "cert.pem"),
+		KeyFile:  path.Join(certsDir, "key.pem"),
+	}
+}
+
+// ClientTLSConfig returns the TLS settings for a client that trusts the stack CA in certsDir,
+// or nil if TLS is not enabled for this stack
+func (s *Stack) ClientTLSConfig(certsDir string) *TLSConfig {
+	if !s.TLSEnabled {
+		return nil
+	}
+	return &TLSConfig{
+		Enabled: true,
+		CAFile:  path.Join(certsDir, "ca.pem"),
+	}
+}
+
+// MemberBasicAuth returns the credentials for the APIs of a member's services,
+// or nil if basic auth is not enabled for this stack
+func (s *Stack) MemberBasicAuth(member *Organization) *BasicAuth {
+	if !s.AuthMode.Equals(AuthModeBasic) || s.Secrets == nil {
+		return nil
+	}
+	if memberSecrets := s.Secrets.Member(member.ID); memberSecrets != nil {
+		return memberSecrets.BasicAuth
+	}
+	return nil
+}
+
+// ServerAuthConfig returns the auth settings for a service that checks credentials against the htpasswd file
+// at passwordFile, or nil if basic auth is not enabled for this stack
+func (s *Stack) ServerAuthConfig(passwordFile string) *HTTPAuthConfig {
+	if !s.AuthMode.Equals(AuthModeBasic) {
+		return nil
+	}
+	return &HTTPAuthConfig{
+		Type: "basic",
+		Basic: &BasicAuthConfig{
+			PasswordFile: passwordFile,
+		},
+	}
+}
+
+// PostgresPassword returns the password for the postgres user of each member's database
+func (s *Stack) PostgresPassword() string {
+	if s.Secrets == nil || s.Secrets.PostgresPassword == "" {
+		return legacyPostgresPassword
+	}
+	return s.Secrets.PostgresPassword
+}
+
+// KeystorePassword returns the password used to encrypt the wallet files of the stack's accounts
+func (s *Stack) KeystorePassword() string {
+	if s.Secrets == nil || s.Secrets.KeystorePassword == "" {
+		return legacyKeystorePassword
+	}
+	return s.Secrets.KeystorePassword
+}
+
+// FabricCAAdminPassword returns the password of the admin identity registered with the Fabric CA
+func (s *Stack) FabricCAAdminPassword() string {
+	if s.Secrets == nil || s.Secrets.FabricCAAdminPassword == "" {
+		return legacyFabricCAAdminPassword
+	}
+	return s.Secrets.FabricCAAdminPassword
+}
+
+// GrafanaAdminPassword returns the password of the admin user of the stack's Grafana
+func (s *Stack) GrafanaAdminPassword() string {
+	if s.Secrets == nil {
+		return ""
+	}
+	return s.Secrets.GrafanaAdminPassword
+}
+
+// Namespace returns the namespace that was added to the stack with the given name, or nil if there is none
+func (s *Stack) Namespace(name string) *StackNamespace {
+	for _, namespace := range s.Namespaces {
+		if namespace.Name == name {
+			return namespace
+		}
+	}
+	return nil
+}